@@ -0,0 +1,102 @@
+/*
+Copyright © 2022 Nethermind
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/NethermindEth/posmoni/pkg/eth2"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v2"
+)
+
+var effective bool
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Validate and show posmoni configuration",
+	Long:  `Validate and show posmoni configuration, as read from the configuration file, environment variables and flags.`,
+}
+
+// configValidateCmd represents the config validate command
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the configuration for misconfigurations",
+	Long: `Check the configuration for misconfigurations: missing validators or consensus endpoints, invalid endpoint URLs, invalid or duplicated validators, unreadable validator files and token files, unknown networks, invalid settings and unknown keys in the configuration file.
+
+Exits with code 1 if any misconfiguration is found.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, errs := eth2.ValidateConfig()
+		if len(errs) > 0 {
+			fmt.Fprintf(os.Stderr, "Found %d misconfigurations:\n", len(errs))
+			for _, err := range errs {
+				fmt.Fprintf(os.Stderr, "- %v\n", err)
+			}
+			os.Exit(1)
+		}
+		fmt.Printf("Configuration is valid. Validators: %d, consensus endpoints: %d, execution endpoints: %d\n", len(cfg.Validators), len(cfg.Consensus), len(cfg.Execution))
+	},
+}
+
+// configShowCmd represents the config show command
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the configuration with secrets masked",
+	Long: `Print the configuration file with secrets masked. With --effective, print the merged result of the configuration file, environment variables, flags and default values.
+
+Keymanager tokens, alert webhook paths and passwords of endpoint URLs are masked.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		var out any
+		if effective {
+			cfg, errs := eth2.LoadConfig()
+			for _, err := range errs {
+				log.Warn(err)
+			}
+			out = cfg.Masked()
+		} else {
+			if viper.ConfigFileUsed() == "" {
+				log.Fatal("No configuration file found. Use --effective to show the configuration from environment variables and flags")
+			}
+			v := viper.New()
+			v.SetConfigFile(viper.ConfigFileUsed())
+			if err := v.ReadInConfig(); err != nil {
+				log.Fatal(err)
+			}
+			out = eth2.MaskSettings(v.AllSettings())
+		}
+
+		data, err := yaml.Marshal(out)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Print(string(data))
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configShowCmd)
+
+	// Flags
+	configShowCmd.Flags().BoolVar(&effective, "effective", false, "Show the merged result of configuration file, environment variables, flags and default values")
+}
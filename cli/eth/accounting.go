@@ -0,0 +1,98 @@
+/*
+Copyright © 2022 Nethermind
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package eth
+
+import (
+	"os"
+
+	"github.com/NethermindEth/posmoni/pkg/eth2"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	accountingFrom     string
+	accountingTo       string
+	accountingFormat   string
+	accountingPrices   string
+	accountingCurrency string
+)
+
+// AccountingCmd represents the accounting command
+var AccountingCmd = &cobra.Command{
+	Use:   "accounting",
+	Short: "Export daily validator income for accounting and tax tools",
+	Long: `Export the income of every validator per day (UTC), read from the monitor database. Income is split in consensus rewards, execution fees and MEV, with withdrawals listed apart since they are not income.
+
+Window bounds are epochs or dates in YYYY-MM-DD format (UTC), both included. Fiat values are added with a price file, a CSV with a date and the price of one ether per line:
+
+date,price
+2024-05-01,3012.55
+
+posmoni ethereum accounting --from 2024-01-01 --to 2024-12-31 --prices eth-usd.csv --format koinly > 2024.csv
+
+The koinly format is the Koinly universal CSV format, also accepted by other tax tools.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		repository, err := eth2.DefaultRepository()
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := repository.Migrate(); err != nil {
+			log.Fatal(err)
+		}
+
+		var prices map[string]float64
+		if accountingPrices != "" {
+			if prices, err = eth2.LoadPrices(accountingPrices); err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		from, err := eth2.ReportEpoch(accountingFrom, false, networkClock)
+		if err != nil {
+			log.Fatal(err)
+		}
+		to, err := eth2.ReportEpoch(accountingTo, true, networkClock)
+		if err != nil {
+			log.Fatal(err)
+		}
+		// Records are grouped by day, so the clock is always needed
+		clock, err := networkClock()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		records, err := eth2.BuildIncome(repository, clock, from, to, prices)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := eth2.WriteIncome(os.Stdout, records, accountingFormat, accountingCurrency); err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	// Flags
+	AccountingCmd.Flags().StringVar(&accountingFrom, "from", "", "First epoch or date (YYYY-MM-DD) of the export")
+	AccountingCmd.Flags().StringVar(&accountingTo, "to", "", "Last epoch or date (YYYY-MM-DD) of the export, included")
+	AccountingCmd.Flags().StringVar(&accountingFormat, "format", eth2.CSVFormat, "Output format: csv, json or koinly")
+	AccountingCmd.Flags().StringVar(&accountingPrices, "prices", "", "CSV file with the price of one ether per date (YYYY-MM-DD)")
+	AccountingCmd.Flags().StringVar(&accountingCurrency, "currency", "USD", "Fiat currency of the prices")
+	AccountingCmd.MarkFlagRequired("from")
+	AccountingCmd.MarkFlagRequired("to")
+}
@@ -0,0 +1,119 @@
+/*
+Copyright © 2022 Nethermind
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package eth
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/NethermindEth/posmoni/pkg/eth2"
+	"github.com/NethermindEth/posmoni/pkg/eth2/db"
+	net "github.com/NethermindEth/posmoni/pkg/eth2/networking"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var doppelgangerEpochs uint64
+
+// DoppelgangerCmd represents the doppelganger command
+var DoppelgangerCmd = &cobra.Command{
+	Use:   "doppelganger",
+	Short: "Check that monitored validators are not active elsewhere before starting a validator client",
+	Long: `Watch the monitored validators for a number of full epochs, starting from the next one, to find out if they are active elsewhere. Run it before a validator client is started or restarted with their keys, for example after migrating keys between machines. It doesn't rely on the doppelganger protection of the validator client.
+
+Each epoch is checked halfway through the next one, with the liveness reported by the consensus node ('/eth/v1/validator/liveness') and the attestations of the validators included in blocks. The check stops at the first epoch with live validators. Validators unknown to the consensus node are skipped.
+
+posmoni ethereum doppelganger --epochs 2 && systemctl start validator
+
+Exits with code 1 if any validator is live or the check could not be completed.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if doppelgangerEpochs == 0 {
+			log.Fatal("--epochs should be at least 1")
+		}
+		monitor, err := eth2.NewEth2Monitor(
+			db.EmptyRepository{},
+			&net.BeaconClient{RetryDuration: time.Second},
+			&net.ExecutionClient{RetryDuration: time.Second},
+			net.SubscribeOpts{},
+			eth2.ConfigOpts{
+				HandleCfg: false,
+				Checkers: []eth2.CfgChecker{
+					{Key: eth2.Validators, ErrMsg: eth2.NoValidatorsFoundError},
+					{Key: eth2.Consensus, ErrMsg: eth2.NoConsensusFoundError},
+				},
+			},
+		)
+		if err != nil {
+			log.Fatal(err)
+		}
+		clock, err := monitor.Clock()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		report := monitor.CheckDoppelganger(doppelgangerEpochs, clock)
+		for _, err := range report.Errors {
+			log.Error(err)
+		}
+		if !printDoppelgangerReport(os.Stdout, report) || len(report.Errors) > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+/*
+printDoppelgangerReport :
+Print the validators seen live as a table, or a summary if none was.
+
+params :-
+a. w io.Writer
+Writer to print the report to
+b. report eth2.DoppelgangerReport
+Result of the check
+
+returns :-
+a. bool
+True if no validator was live
+*/
+func printDoppelgangerReport(w io.Writer, report eth2.DoppelgangerReport) bool {
+	if len(report.Live) == 0 {
+		if len(report.Errors) == 0 {
+			fmt.Fprintf(w, "No validator of %d was live in epochs %d to %d\n", len(report.Validators), report.FromEpoch, report.ToEpoch)
+		}
+		return true
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VALIDATOR\tEPOCH\tLIVENESS\tINCLUSION_SLOT")
+	for _, l := range report.Live {
+		inclusion := "-"
+		if l.InclusionSlot > 0 {
+			inclusion = fmt.Sprint(l.InclusionSlot)
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%v\t%s\n", l.Validator, l.Epoch, l.Liveness, inclusion)
+	}
+	tw.Flush()
+	return false
+}
+
+func init() {
+	// Flags
+	DoppelgangerCmd.Flags().Uint64Var(&doppelgangerEpochs, "epochs", 2, "Full epochs to watch the validators for")
+}
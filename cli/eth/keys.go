@@ -0,0 +1,102 @@
+/*
+Copyright © 2022 Nethermind
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package eth
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/NethermindEth/posmoni/pkg/eth2"
+	"github.com/NethermindEth/posmoni/pkg/eth2/db"
+	net "github.com/NethermindEth/posmoni/pkg/eth2/networking"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// KeysCmd represents the keys command
+var KeysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Compare monitored validators against keys loaded in validator clients",
+	Long: `Compare the monitored validators against the keys loaded in the validator clients, using the keymanager API of every client set in the keymanagers setting. Check the project's README for more information.
+
+Reports monitored keys that no validator client has loaded, loaded keys that are not monitored, and keys loaded in more than one validator client. Validator indexes are resolved to public keys with the consensus nodes.
+
+Exits with code 1 if any key is not in the loaded state or any check failed.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		monitor, err := eth2.NewEth2Monitor(
+			db.EmptyRepository{},
+			&net.BeaconClient{RetryDuration: time.Second},
+			&net.ExecutionClient{RetryDuration: time.Second},
+			net.SubscribeOpts{},
+			eth2.ConfigOpts{
+				HandleCfg: false,
+				Checkers: []eth2.CfgChecker{
+					{Key: eth2.Validators, ErrMsg: eth2.NoValidatorsFoundError},
+					{Key: eth2.Consensus, ErrMsg: eth2.NoConsensusFoundError},
+				},
+			},
+		)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		report := monitor.CheckKeymanagers()
+		for _, err := range report.Errors {
+			log.Error(err)
+		}
+		if !printKeysTable(os.Stdout, report) || len(report.Errors) > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+/*
+printKeysTable :
+Print the state of every key as a table.
+
+params :-
+a. w io.Writer
+Writer to print the table to
+b. report eth2.KeyReport
+State of the keys
+
+returns :-
+a. bool
+True if every key is loaded once and monitored
+*/
+func printKeysTable(w io.Writer, report eth2.KeyReport) bool {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PUBKEY\tVALIDATOR\tSTATE\tCLIENTS")
+
+	ok := true
+	for _, k := range report.Keys {
+		clients := strings.Join(k.Clients, ",")
+		if clients == "" {
+			clients = "-"
+		}
+		if k.State != eth2.KeyLoaded {
+			ok = false
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", k.Pubkey, k.Validator, k.State, clients)
+	}
+	tw.Flush()
+	return ok
+}
@@ -0,0 +1,116 @@
+/*
+Copyright © 2022 Nethermind
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package eth
+
+import (
+	"os"
+	"time"
+
+	"github.com/NethermindEth/posmoni/pkg/eth2"
+	"github.com/NethermindEth/posmoni/pkg/eth2/db"
+	net "github.com/NethermindEth/posmoni/pkg/eth2/networking"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportFrom   string
+	reportTo     string
+	reportFormat string
+)
+
+// ReportCmd represents the report command
+var ReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Report validator rewards over a window of epochs or dates",
+	Long: `Report starting and ending balance, rewards, penalties, missed attestations, proposals and withdrawals of every validator over a window, read from the monitor database. Amounts are in gwei.
+
+Window bounds are epochs or dates in YYYY-MM-DD format (UTC), both included. Dates are converted to epochs with the network setting, or with the genesis and spec of the consensus nodes for custom networks. Reports of consecutive windows add up, e.g. monthly reports:
+
+posmoni ethereum report --from 2024-05-01 --to 2024-05-31 --format csv > may.csv
+
+Only epochs seen by a running monitor are in the database.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		repository, err := eth2.DefaultRepository()
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := repository.Migrate(); err != nil {
+			log.Fatal(err)
+		}
+
+		from, err := eth2.ReportEpoch(reportFrom, false, networkClock)
+		if err != nil {
+			log.Fatal(err)
+		}
+		to, err := eth2.ReportEpoch(reportTo, true, networkClock)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		reports, err := eth2.BuildReport(repository, from, to)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := eth2.WriteReport(os.Stdout, reports, reportFormat); err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+/*
+networkClock :
+Get the clock of the configured network, to convert dates to epochs. Consensus nodes are only asked for the genesis and spec of custom networks.
+
+params :-
+none
+
+returns :-
+a. *eth2.Clock
+Clock of the network
+b. error
+Error if any
+*/
+func networkClock() (*eth2.Clock, error) {
+	cfg, _ := eth2.LoadConfig()
+	if c, err := eth2.PresetClock(cfg.Network); err == nil {
+		return c, nil
+	}
+	monitor, err := eth2.NewEth2Monitor(
+		db.EmptyRepository{},
+		&net.BeaconClient{RetryDuration: time.Second},
+		&net.ExecutionClient{RetryDuration: time.Second},
+		net.SubscribeOpts{},
+		eth2.ConfigOpts{
+			HandleCfg: false,
+			Checkers:  []eth2.CfgChecker{{Key: eth2.Consensus, ErrMsg: eth2.NoConsensusFoundError}},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return monitor.Clock()
+}
+
+func init() {
+	// Flags
+	ReportCmd.Flags().StringVar(&reportFrom, "from", "", "First epoch or date (YYYY-MM-DD) of the report")
+	ReportCmd.Flags().StringVar(&reportTo, "to", "", "Last epoch or date (YYYY-MM-DD) of the report, included")
+	ReportCmd.Flags().StringVar(&reportFormat, "format", eth2.CSVFormat, "Output format: csv or json")
+	ReportCmd.MarkFlagRequired("from")
+	ReportCmd.MarkFlagRequired("to")
+}
@@ -16,8 +16,11 @@ limitations under the License.
 package eth
 
 import (
+	"fmt"
+	"io"
 	"os"
 	"os/signal"
+	"text/tabwriter"
 	"time"
 
 	"github.com/NethermindEth/posmoni/pkg/eth2"
@@ -28,17 +31,23 @@ import (
 )
 
 var (
-	executionEndp []string
-	consensusEndp []string
-	cron          int
+	cron            int
+	waitUntilSynced bool
+	consecutive     int
+	timeout         time.Duration
+	alignEpochs     bool
 )
 
 // TrackSyncCmd represents the TrackSync command
 var TrackSyncCmd = &cobra.Command{
 	Use:   "trackSync",
 	Short: "Track sync progress of Ethereum nodes",
-	Long:  `Track sync progress of Ethereum's execution and Ethereum2 consensus nodes. You need to provide a list of execution and consensus nodes endpoints or put them in a configuration file or environment variables. Check the project's README for more information.`,
-	Args:  cobra.NoArgs,
+	Long: `Track sync progress of Ethereum's execution and Ethereum2 consensus nodes. You need to provide a list of execution and consensus nodes endpoints with the --execution and --consensus flags, a configuration file or environment variables. Check the project's README for more information.
+
+Checks run every --cron seconds, or at the start of every epoch with --align-epochs. Epoch boundaries are computed from the network setting, or from the genesis and spec of the consensus nodes for custom networks.
+
+By default runs until SIGINT. With --wait-until-synced it exits with code 0 once every endpoint is synced (for --consecutive checks in a row), or with code 1 if --timeout is reached first.`,
+	Args: cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		monitor, err := eth2.NewEth2Monitor(
 			db.EmptyRepository{},
@@ -48,8 +57,8 @@ var TrackSyncCmd = &cobra.Command{
 			eth2.ConfigOpts{
 				HandleCfg: false,
 				Checkers: []eth2.CfgChecker{
-					{Key: eth2.Execution, ErrMsg: eth2.NoExecutionFoundError, Data: executionEndp},
-					{Key: eth2.Consensus, ErrMsg: eth2.NoConsensusFoundError, Data: consensusEndp},
+					{Key: eth2.Execution, ErrMsg: eth2.NoExecutionFoundError},
+					{Key: eth2.Consensus, ErrMsg: eth2.NoConsensusFoundError},
 				},
 			},
 		)
@@ -62,30 +71,119 @@ var TrackSyncCmd = &cobra.Command{
 		sigChan := make(chan os.Signal, 1)
 		signal.Notify(sigChan, os.Interrupt)
 
-		results := monitor.TrackSync(done, consensusEndp, executionEndp, time.Duration(cron)*time.Second)
+		// Use the endpoints the monitor was configured with, they could come from flags, config file or environment variables
+		consensus, execution := monitor.Endpoints()
+		var results <-chan eth2.EndpointSyncStatus
+		if alignEpochs {
+			clock, err := monitor.Clock()
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Infof("Checking sync progress at epoch boundaries, every %v", clock.EpochDuration())
+			results = monitor.TrackSyncOnSchedule(done, consensus, execution, clock.UntilNextEpoch)
+		} else {
+			results = monitor.TrackSync(done, consensus, execution, time.Duration(cron)*time.Second)
+		}
 
+		waiter := eth2.NewSyncWaiter(append(append([]string{}, consensus...), execution...), consecutive)
+		synced := make(chan struct{})
 		go func() {
+			round := make([]eth2.EndpointSyncStatus, 0)
 			for r := range results {
 				if r.Error != nil {
 					log.Errorf("Endpoint %s returned an error. Error: %v", r.Endpoint, r.Error)
 				}
+
+				round = append(round, r)
+				if len(round) == len(consensus)+len(execution) {
+					printSyncTable(os.Stdout, round)
+					round = make([]eth2.EndpointSyncStatus, 0)
+				}
+
+				if waitUntilSynced && waiter.Observe(r) {
+					close(synced)
+					return
+				}
 			}
 		}()
 
-		for range sigChan {
+		var timedOut <-chan time.Time
+		if waitUntilSynced && timeout > 0 {
+			timedOut = time.After(timeout)
+		}
+
+		select {
+		case <-sigChan:
 			log.Info("Received SIGINT, exiting...")
 			close(done)
 			time.Sleep(time.Second)
 			os.Exit(0)
+		case <-synced:
+			log.Infof("All endpoints synced for %d consecutive checks, exiting...", consecutive)
+			close(done)
+			os.Exit(0)
+		case <-timedOut:
+			log.Errorf("Endpoints not synced after %v, exiting...", timeout)
+			close(done)
+			os.Exit(1)
 		}
 	},
 }
 
+/*
+printSyncTable :
+Print sync progress of a round of checks as a table.
+
+params :-
+a. w io.Writer
+Writer to print the table to
+b. statuses []eth2.EndpointSyncStatus
+Sync status of every endpoint
+
+returns :-
+none
+*/
+func printSyncTable(w io.Writer, statuses []eth2.EndpointSyncStatus) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ENDPOINT\tLAYER\tSTATUS\tPROGRESS\tREMAINING\tSPEED\tETA")
+
+	for _, s := range statuses {
+		status, progress, remaining, speed, eta := "syncing", "-", "-", "-", "-"
+		switch {
+		case s.Error != nil:
+			status = "error"
+		case s.Synced:
+			status = "synced"
+		}
+
+		if s.Error == nil {
+			if s.Layer == eth2.ConsensusLayer {
+				progress = fmt.Sprintf("slot %d", s.HeadSlot)
+				remaining = fmt.Sprintf("%d slots", s.SyncDistance)
+			} else if !s.Synced {
+				progress = fmt.Sprintf("block %d/%d", s.CurrentBlock, s.HighestBlock)
+				if s.HighestBlock >= s.CurrentBlock {
+					remaining = fmt.Sprintf("%d blocks", s.HighestBlock-s.CurrentBlock)
+				}
+			}
+		}
+		if s.Speed > 0 {
+			speed = fmt.Sprintf("%.2f/s", s.Speed)
+			eta = s.ETA.String()
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", s.Endpoint, s.Layer, status, progress, remaining, speed, eta)
+	}
+	tw.Flush()
+}
+
 func init() {
 	//ethereumCmd.AddCommand(trackSyncCmd)
 
 	// Flags
-	TrackSyncCmd.Flags().StringSliceVar(&executionEndp, "execution", []string{}, "Execution endpoints to which track sync progress. Example: 'posmoni ethereum --execution=<endpoint1>,<endpoint2>'")
-	TrackSyncCmd.Flags().StringSliceVar(&consensusEndp, "consensus", []string{}, "Consensus endpoints to which track sync progress. Example: 'posmoni ethereum --consensus=<endpoint1>,<endpoint2>'")
 	TrackSyncCmd.Flags().IntVarP(&cron, "cron", "c", 60, "Wait time in seconds between sync progress checks")
+	TrackSyncCmd.Flags().BoolVar(&alignEpochs, "align-epochs", false, "Check sync progress at the start of every epoch instead of every --cron seconds")
+	TrackSyncCmd.Flags().BoolVar(&waitUntilSynced, "wait-until-synced", false, "Exit with code 0 once every endpoint is synced. Example: 'posmoni ethereum trackSync --wait-until-synced --timeout=2h'")
+	TrackSyncCmd.Flags().IntVar(&consecutive, "consecutive", 1, "Consecutive synced checks every endpoint needs before exiting. Used with --wait-until-synced")
+	TrackSyncCmd.Flags().DurationVar(&timeout, "timeout", 0, "Exit with code 1 if endpoints are not synced after this time. Zero means no timeout. Used with --wait-until-synced")
 }
@@ -18,6 +18,7 @@ package cli
 import (
 	"os"
 	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/NethermindEth/posmoni/cli/eth"
@@ -31,21 +32,90 @@ var ethereumCmd = &cobra.Command{
 	Use:   "ethereum",
 	Short: "Monitor validators of Ethereum Beacon Chain",
 	Long: `
-Monitor Ethereum Beacon Chain validator's balance changes and missed attestations using Beacon Chain official HTTP API.
+Monitor Ethereum Beacon Chain validator's balance changes and missed attestations using Beacon Chain official HTTP API. Withdrawals are read from the blocks since the previous check and kept in the database, so balance drops from withdrawals are not taken as missed attestations.
 
 Needs a consensus client endpoint to interacts with Beacon Chain API and a set of validator addresses or public indexes to monitor. All of these endpoints should be provided using a configuration file or environment variables.
 
 The configuration file must be a .yaml. By default posmoni searches for a .posmoni.yaml file at the HOME directory. Example of configuration file:
 
 validators: [269870, 0xb3456c17df6d9bddab9dedfcc590bbebccd24eca811099ad4b10f0fcd7583c91e160848713d4bb5c23ab1eeae9c9b3c0]
+# Validators can also be given as index ranges (1000-1999), paths to files with one validator per line or comma separated,
+# CSV files (.csv) with validators in the first column, and deposit_data-*.json files. Files are re-read when they change
+# validators: [1000-1999, /etc/posmoni/validators.txt, /etc/posmoni/deposit_data-1663939185.json]
+# Validators can also be grouped, with labels added to logs, database rows and alerts, and thresholds overriding the global ones
+# validators:
+#   - 269870
+#   - name: acme
+#     labels: {customer: acme, operator: ops-team, client: lighthouse, datacenter: fra1}
+#     validators: [269871, 269872]
+#     thresholds: {missed_attestations: 3, min_effective_balance: 31, missed_rewards: 5}
+#     fee_recipient: "0x388c818ca8b9251b393131c08a736a67ccb19297"
 consensus: "http://111.111.111.111:5052"
+execution: "http://111.111.111.111:8545"
+# mainnet, sepolia, holesky, gnosis or custom. Custom reads genesis and spec from the consensus nodes
+network: mainnet
+# Sqlite database file, eth2_monitor.db by default
+db_path: "/var/lib/posmoni/eth2_monitor.db"
+
+# Optional node health settings
+min_peers: 10
+min_inbound_peers: 0
+health_interval: 60
+health_grace_period: 180
+alerts_webhook: "http://222.222.222.222:8080/alerts"
+reorg_depth_threshold: 1
+missed_attestations_threshold: 1
+# Effective balance in ether below which validators are alerted, with the top-up that restores it. 0 disables the check
+min_effective_balance: 32
+# Percentage of the ideal attestation rewards of an epoch lost by the validators before alerting. 0 disables the check
+missed_rewards_threshold: 10
+# Epochs finality can fall behind its usual delay of 2 epochs before alerting, and percentage of the network attesting
+# to the correct target below which the network is degraded (0 disables it). Losses of the validators while the whole
+# network is degraded are alerted as network-wide, with info severity
+finality_stall_epochs: 2
+min_participation: 80
+
+# Optional keymanager APIs of the validator clients. Monitored validators are compared against the keys they have loaded,
+# and loaded keys that are not configured are monitored too unless keymanager_auto_monitor is false
+keymanagers:
+  - url: "http://333.333.333.333:7500"
+    token_file: "/var/lib/lighthouse/validators/api-token.txt"
+  - url: "http://444.444.444.444:5062"
+    token: "api-token-0x..."
+keymanager_auto_monitor: true
+
+# Optional expected fee recipients. Proposed blocks and the fee recipients registered in the consensus nodes are checked
+# against them, per validator first, then per group, then the global one
+fee_recipient: "0xabcf8e0d4e9587369b2301d0790347320302cc09"
+fee_recipients:
+  269870: "0x6d2e03b7effeae98bd302a9f836d0d6ab0002766"
+
+# Optional MEV-boost relays and mev-boost endpoints. Proposed blocks are checked against the payloads delivered by the relays,
+# alerting locally built blocks and payments below the bid, and mev-boost is alerted when down
+relays:
+  - "https://0xac6e77dfe25ecd6110b8e780608cce0dab71fdd5ebea22a16c0205200f2f8e2e3ad3b71d3499c54ad14d6c21b41a37ae@boost-relay.flashbots.net"
+  - "https://relay.ultrasound.money"
+mev_boost: ["http://127.0.0.1:18550"]
 
 logs:
 logLevel: debug
 
+Run 'posmoni ethereum doppelganger --epochs N' before starting a validator client with migrated keys, to check that the validators are not active elsewhere.
+
+Run 'posmoni config validate' to check the configuration, and 'posmoni config show --effective' to print it merged with environment variables, flags and default values.
+
+Balances, block proposals and attestations, with their inclusion distance, head, target and source correctness and effectiveness, are kept per epoch in the database. Validators keep a rolling attestation effectiveness over the last 225 epochs, and attestations and missed proposals record the consensus rewards lost against an ideal validator, computed from the effective balances and the total active balance of the network. Run 'posmoni ethereum report --from <date|epoch> --to <date|epoch>' to get per-validator rewards over a window, or 'posmoni ethereum accounting' to export daily income with fiat values.
+
+Changes to the configuration file are applied without restarting, and a SIGHUP reloads it too. Removed validators are kept in the database marked as inactive.
+
 Example of environment variables:
 "PM_VALIDATORS": "269870,0xb3456c17df6d9bddab9dedfcc590bbebccd24eca811099ad4b10f0fcd7583c91e160848713d4bb5c23ab1eeae9c9b3c0",
 "PM_CONSENSUS":  "http://111.111.111.111:5052"
+"PM_FEE_RECIPIENTS": "269870=0x6d2e03b7effeae98bd302a9f836d0d6ab0002766"
+"PM_LOG_LEVEL":  "debug"
+
+Every setting can also be given as a flag, available in every subcommand. Flags take precedence over environment variables, which take precedence over the config file and then default values. Example of a monitor run without a config file:
+posmoni ethereum --validators=269870,1000-1999 --consensus=http://111.111.111.111:5052 --db-path=/tmp/posmoni.db --log-level=debug
   `,
 	Run: func(cmd *cobra.Command, args []string) {
 		ExecuteEthMonitor()
@@ -55,14 +125,18 @@ Example of environment variables:
 func init() {
 	RootCmd.AddCommand(ethereumCmd)
 	ethereumCmd.AddCommand(eth.TrackSyncCmd)
+	ethereumCmd.AddCommand(eth.KeysCmd)
+	ethereumCmd.AddCommand(eth.ReportCmd)
+	ethereumCmd.AddCommand(eth.AccountingCmd)
+	ethereumCmd.AddCommand(eth.DoppelgangerCmd)
 }
 
 func ExecuteEthMonitor() {
-	// listen for SIGINT
+	// listen for SIGINT, and SIGHUP to reload the configuration
 	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGHUP)
 
-	monitor, err := eth2.DefaultEth2Monitor(eth2.ConfigOpts{HandleCfg: false})
+	monitor, err := eth2.DefaultEth2Monitor(eth2.ConfigOpts{HandleCfg: false, Checkers: eth2.DefaultCheckers()})
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -71,7 +145,15 @@ func ExecuteEthMonitor() {
 		log.Fatal(err)
 	}
 
-	for range sigChan {
+	for sig := range sigChan {
+		if sig == syscall.SIGHUP {
+			log.Info("Received SIGHUP, reloading configuration...")
+			if err := monitor.Reload(); err != nil {
+				log.Errorf(eth2.ReloadConfigError, err)
+			}
+			continue
+		}
+
 		log.Info("Received SIGINT, exiting...")
 		for _, done := range doneChans {
 			close(done)
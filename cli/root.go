@@ -22,6 +22,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/NethermindEth/posmoni/configs"
+	"github.com/NethermindEth/posmoni/pkg/eth2"
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -47,4 +48,6 @@ func init() {
 	cobra.OnInitialize(configs.InitConfig)
 
 	RootCmd.PersistentFlags().StringVar(&configs.CfgFile, "config", "", "config file (default is $HOME/.posmoni.yaml)")
+	// Every configuration key can be given as a flag too, taking precedence over environment variables and config file
+	cobra.CheckErr(eth2.BindFlags(RootCmd.PersistentFlags()))
 }
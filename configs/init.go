@@ -61,11 +61,9 @@ func InitLogging() {
 		TimestampFormat: "2006-01-02 15:04:05 --",
 	})
 
-	err := viper.UnmarshalKey("logs", &config)
-	if err != nil {
-		log.WithField(Component, "Logger Init").Errorf("Unable to decode into struct, %v", err)
-		return
-	}
+	// Read the level key directly, so it can also come from the --log-level flag or PM_LOG_LEVEL
+	viper.BindEnv("logs.logLevel", "PM_LOG_LEVEL")
+	config.Level = viper.GetString("logs.logLevel")
 	log.WithField(Component, "Logger Init").Infof("Logging configuration: %+v", config)
 
 	level, err := log.ParseLevel(strings.ToLower(config.Level))
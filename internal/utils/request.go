@@ -26,6 +26,28 @@ b. error
 Error if any
 */
 func GetRequest(url string, retryDuration time.Duration) (*http.Response, error) {
+	return GetRequestWithHeaders(url, nil, retryDuration)
+}
+
+/*
+GetRequestWithHeaders :
+Make a GET request with custom headers to the given URL. Uses exponential retries with backoff.
+
+params :-
+a. url string
+URL to make the request to
+b. headers map[string]string
+Headers to add to the request, e.g. Authorization
+c. retryDuration time.Duration
+Duration to wait between retries
+
+returns :-
+a. http.Response
+Response from the request
+b. error
+Error if any
+*/
+func GetRequestWithHeaders(url string, headers map[string]string, retryDuration time.Duration) (*http.Response, error) {
 	logFields := log.Fields{"Method": "GetRequest"}
 	var response *http.Response
 
@@ -34,8 +56,14 @@ func GetRequest(url string, retryDuration time.Duration) (*http.Response, error)
 	b.MaxElapsedTime = retryDuration
 
 	err := backoff.Retry(func() (err error) {
-		// To make a request with custom headers, use NewRequest and Client.Do.
-		response, err = http.Get(url)
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			return backoff.Permanent(err)
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		response, err = http.DefaultClient.Do(req)
 		if err != nil {
 			log.WithFields(logFields).Errorf("request failed. Error: %v", err)
 			log.WithFields(logFields).Info("Retrying request")
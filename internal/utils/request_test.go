@@ -85,6 +85,57 @@ func TestGetRequest(t *testing.T) {
 	}
 }
 
+func TestGetRequestWithHeaders(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(
+		http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			if req.Header.Get("Authorization") != "Bearer secret" {
+				rw.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			rw.WriteHeader(http.StatusOK)
+			rw.Write([]byte("OK"))
+		}))
+	defer server.Close()
+
+	tcs := []struct {
+		name     string
+		headers  map[string]string
+		wantCode int
+	}{
+		{
+			"With token",
+			map[string]string{"Authorization": "Bearer secret"},
+			http.StatusOK,
+		},
+		{
+			"Without token",
+			nil,
+			http.StatusUnauthorized,
+		},
+		{
+			"Wrong token",
+			map[string]string{"Authorization": "Bearer wrong"},
+			http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := GetRequestWithHeaders(server.URL, tc.headers, time.Second)
+			if err != nil {
+				t.Fatalf("GetRequestWithHeaders() failed. Error: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.wantCode {
+				t.Errorf("GetRequestWithHeaders() got status code %d, expected %d", resp.StatusCode, tc.wantCode)
+			}
+		})
+	}
+}
+
 func TestPostRequest(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,293 @@
+package eth2
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NethermindEth/posmoni/pkg/eth2/db"
+)
+
+// KoinlyFormat : Koinly universal CSV format, also accepted by other tax tools
+const KoinlyFormat = "koinly"
+
+// gweiPerEth : Gwei in one ether
+const gweiPerEth = 1_000_000_000
+
+// koinlyHeader : Header of the Koinly universal CSV format
+var koinlyHeader = []string{
+	"Date", "Sent Amount", "Sent Currency", "Received Amount", "Received Currency", "Fee Amount", "Fee Currency",
+	"Net Worth Amount", "Net Worth Currency", "Label", "Description", "TxHash",
+}
+
+// IncomeRecord : Struct Represent the income of a validator on a day, in UTC. Amounts are in gwei
+type IncomeRecord struct {
+	Date      string `json:"date"`
+	Validator uint   `json:"validator"`
+	Group     string `json:"group,omitempty"`
+	// First and last epochs of the day with a balance snapshot or proposal, to audit the record
+	FromEpoch uint64 `json:"from_epoch"`
+	ToEpoch   uint64 `json:"to_epoch"`
+	// Balance change of the day with withdrawals added back. Negative on days with more penalties than rewards
+	ConsensusRewards int64  `json:"consensus_rewards"`
+	ExecutionFees    uint64 `json:"execution_fees"`
+	MEV              uint64 `json:"mev"`
+	// Withdrawn gwei. Not income, withdrawn rewards are already in ConsensusRewards
+	Withdrawals uint64 `json:"withdrawals"`
+	// Fiat price of one ether on the day, and fiat value of the income. Zero without price data
+	Price     float64 `json:"price,omitempty"`
+	FiatValue float64 `json:"fiat_value,omitempty"`
+}
+
+// Income : Get the total income of the record in gwei
+func (r IncomeRecord) Income() int64 {
+	return r.ConsensusRewards + int64(r.ExecutionFees) + int64(r.MEV)
+}
+
+/*
+BuildIncome :
+Build daily income records of every validator over a window of epochs, from balance snapshots and proposals in the repository. Days are in UTC and epochs belong to the day they start on.
+
+params :-
+a. r db.Repository
+Repository to read snapshots and proposals from
+b. clock *Clock
+Clock of the network, to get the day of epochs
+c. fromEpoch uint64
+First epoch of the window
+d. toEpoch uint64
+Last epoch of the window, included
+e. prices map[string]float64
+Fiat price of one ether by date in YYYY-MM-DD format. Can be nil
+
+returns :-
+a. []IncomeRecord
+Income records sorted by date and validator index
+b. error
+Error if any
+*/
+func BuildIncome(r db.Repository, clock *Clock, fromEpoch, toEpoch uint64, prices map[string]float64) ([]IncomeRecord, error) {
+	if fromEpoch > toEpoch {
+		return nil, fmt.Errorf(ReportRangeError, fromEpoch, toEpoch)
+	}
+
+	snapshots, err := windowSnapshots(r, fromEpoch, toEpoch)
+	if err != nil {
+		return nil, err
+	}
+	proposals, err := r.Proposals(fromEpoch, toEpoch)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]IncomeRecord, 0)
+	byKey := make(map[string]int)
+	groups := make(map[uint]string)
+	record := func(date string, idx uint, epoch uint64) *IncomeRecord {
+		key := fmt.Sprintf("%s/%d", date, idx)
+		i, ok := byKey[key]
+		if !ok {
+			group, known := groups[idx]
+			if !known {
+				if v, err := r.Validator(idx); err == nil {
+					group = v.Group
+				}
+				groups[idx] = group
+			}
+			i = len(records)
+			byKey[key] = i
+			records = append(records, IncomeRecord{Date: date, Validator: idx, Group: group, FromEpoch: epoch, ToEpoch: epoch})
+		}
+		rec := &records[i]
+		if epoch < rec.FromEpoch {
+			rec.FromEpoch = epoch
+		}
+		if epoch > rec.ToEpoch {
+			rec.ToEpoch = epoch
+		}
+		return rec
+	}
+
+	walkSnapshots(snapshots, fromEpoch, func(prev, s *db.BalanceSnapshot) {
+		// The first snapshot of a validator is only the balance the next ones are compared to
+		if prev == nil {
+			return
+		}
+		rec := record(clock.EpochStart(s.Epoch).UTC().Format(reportDateLayout), s.Idx, s.Epoch)
+		rec.ConsensusRewards += balanceChange(prev, s)
+		rec.Withdrawals += s.Withdrawn
+	})
+	for _, p := range proposals {
+		if p.Missed {
+			continue
+		}
+		rec := record(clock.SlotStart(p.Slot).UTC().Format(reportDateLayout), p.Idx, p.Epoch)
+		rec.ExecutionFees += p.ExecutionFees
+		rec.MEV += p.MEV
+	}
+
+	for i := range records {
+		if price, ok := prices[records[i].Date]; ok {
+			records[i].Price = price
+			records[i].FiatValue = float64(records[i].Income()) / gweiPerEth * price
+		}
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Date != records[j].Date {
+			return records[i].Date < records[j].Date
+		}
+		return records[i].Validator < records[j].Validator
+	})
+	return records, nil
+}
+
+/*
+LoadPrices :
+Read fiat prices of one ether from a CSV file with a date in YYYY-MM-DD format and a price per line. A header line is skipped. Dates with a time, like '2024-05-01 00:00:00', are accepted.
+
+params :-
+a. path string
+Price file path
+
+returns :-
+a. map[string]float64
+Prices by date
+b. error
+Error if the file can't be read or has invalid lines
+*/
+func LoadPrices(path string) (map[string]float64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	cr := csv.NewReader(f)
+	cr.FieldsPerRecord = -1
+	lines, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make(map[string]float64, len(lines))
+	for i, line := range lines {
+		if len(line) < 2 {
+			return nil, fmt.Errorf(PriceFileError, path, i+1, strings.Join(line, ","))
+		}
+		date := strings.TrimSpace(line[0])
+		if len(date) > len(reportDateLayout) {
+			date = date[:len(reportDateLayout)]
+		}
+		price, err := strconv.ParseFloat(strings.TrimSpace(line[1]), 64)
+		if _, dateErr := time.Parse(reportDateLayout, date); err != nil || dateErr != nil {
+			if i == 0 {
+				// Header
+				continue
+			}
+			return nil, fmt.Errorf(PriceFileError, path, i+1, strings.Join(line, ","))
+		}
+		prices[date] = price
+	}
+	return prices, nil
+}
+
+/*
+WriteIncome :
+Write income records in CSV, JSON or Koinly universal format. Ether amounts are written with 9 decimals, so they match the gwei amounts exactly. In Koinly format, consensus rewards are labeled as staking, execution fees and MEV as reward, and days with net penalties as cost. Withdrawals are left out since they are not income.
+
+params :-
+a. w io.Writer
+Writer to write the records to
+b. records []IncomeRecord
+Income records
+c. format string
+Output format, csv, json or koinly
+d. currency string
+Fiat currency of the prices, e.g. USD
+
+returns :-
+a. error
+Error if any
+*/
+func WriteIncome(w io.Writer, records []IncomeRecord, format, currency string) error {
+	switch format {
+	case JSONFormat:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(records)
+	case CSVFormat:
+		cur := strings.ToLower(currency)
+		cw := csv.NewWriter(w)
+		cw.Write([]string{
+			"date", "validator", "group", "from_epoch", "to_epoch", "consensus_rewards_eth", "execution_fees_eth", "mev_eth",
+			"withdrawals_eth", "income_eth", "price_" + cur, "income_" + cur,
+		})
+		for _, r := range records {
+			price, fiat := "", ""
+			if r.Price != 0 {
+				price, fiat = formatFiat(r.Price), formatFiat(r.FiatValue)
+			}
+			cw.Write([]string{
+				r.Date, fmt.Sprint(r.Validator), r.Group, fmt.Sprint(r.FromEpoch), fmt.Sprint(r.ToEpoch), formatGwei(r.ConsensusRewards),
+				formatGwei(int64(r.ExecutionFees)), formatGwei(int64(r.MEV)), formatGwei(int64(r.Withdrawals)), formatGwei(r.Income()), price, fiat,
+			})
+		}
+		cw.Flush()
+		return cw.Error()
+	case KoinlyFormat:
+		cw := csv.NewWriter(w)
+		cw.Write(koinlyHeader)
+		for _, r := range records {
+			date := r.Date + " 23:59:59 UTC"
+			if r.ConsensusRewards > 0 {
+				cw.Write(koinlyLine(date, r.ConsensusRewards, r.Price, currency, "staking", fmt.Sprintf("Consensus rewards of validator %d, epochs %d-%d", r.Validator, r.FromEpoch, r.ToEpoch)))
+			} else if r.ConsensusRewards < 0 {
+				cw.Write(koinlyLine(date, r.ConsensusRewards, r.Price, currency, "cost", fmt.Sprintf("Consensus penalties of validator %d, epochs %d-%d", r.Validator, r.FromEpoch, r.ToEpoch)))
+			}
+			if el := int64(r.ExecutionFees + r.MEV); el > 0 {
+				cw.Write(koinlyLine(date, el, r.Price, currency, "reward", fmt.Sprintf("Execution fees and MEV of validator %d, epochs %d-%d", r.Validator, r.FromEpoch, r.ToEpoch)))
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	default:
+		return fmt.Errorf(ReportFormatError, format, []string{CSVFormat, JSONFormat, KoinlyFormat})
+	}
+}
+
+// koinlyLine : Build a Koinly line receiving an amount of gwei, or sending it if negative, valued with a fiat price if known
+func koinlyLine(date string, gwei int64, price float64, currency, label, description string) []string {
+	amount := gwei
+	if amount < 0 {
+		amount = -amount
+	}
+	worth, worthCurrency := "", ""
+	if price != 0 {
+		worth, worthCurrency = formatFiat(float64(amount)/gweiPerEth*price), strings.ToUpper(currency)
+	}
+	if gwei < 0 {
+		return []string{date, formatGwei(amount), "ETH", "", "", "", "", worth, worthCurrency, label, description, ""}
+	}
+	return []string{date, "", "", formatGwei(amount), "ETH", "", "", worth, worthCurrency, label, description, ""}
+}
+
+// formatGwei : Format an amount of gwei in ether with 9 decimals
+func formatGwei(gwei int64) string {
+	sign := ""
+	if gwei < 0 {
+		sign, gwei = "-", -gwei
+	}
+	return fmt.Sprintf("%s%d.%09d", sign, gwei/gweiPerEth, gwei%gweiPerEth)
+}
+
+// formatFiat : Format a fiat amount with 2 decimals
+func formatFiat(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
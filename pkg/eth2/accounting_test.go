@@ -0,0 +1,254 @@
+package eth2
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/posmoni/internal/utils"
+	"github.com/NethermindEth/posmoni/pkg/eth2/db"
+	net "github.com/NethermindEth/posmoni/pkg/eth2/networking"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestBuildIncome(t *testing.T) {
+	ormdb, err := gorm.Open(sqlite.Open("file:income?mode=memory&cache=shared"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	repository := &db.SQLiteRepository{DB: ormdb}
+	defer cleanup(repository)
+	if err := repository.Migrate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := populateDb(repository, []db.Validator{{Idx: 1, Group: "acme"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 225 epochs a day, 2022-01-02 starts at epoch 225
+	clock, err := NewClock(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), 12, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshots := []db.BalanceSnapshot{
+		{Idx: 1, Epoch: 224, Balance: 32000000000},
+		{Idx: 1, Epoch: 225, Balance: 32000010000},
+		{Idx: 1, Epoch: 300, Balance: 32000020000},
+		// Withdrawal of 20000 gwei with 5000 gwei of rewards
+		{Idx: 1, Epoch: 450, Balance: 32000005000, Withdrawn: 20000},
+		{Idx: 2, Epoch: 449, Balance: 32000000000},
+		{Idx: 2, Epoch: 450, Balance: 31999990000, Missed: true},
+	}
+	for _, s := range snapshots {
+		if err := repository.SaveSnapshot(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	proposals := []db.Proposal{
+		{Idx: 1, Slot: 7201, Epoch: 225, ExecutionFees: 30000000, MEV: 1000000000},
+		{Idx: 2, Slot: 14402, Epoch: 450, Missed: true},
+	}
+	for _, p := range proposals {
+		if err := repository.SaveProposal(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tcs := []struct {
+		name    string
+		from    uint64
+		to      uint64
+		prices  map[string]float64
+		want    []IncomeRecord
+		isError bool
+	}{
+		{
+			"Test case 1, two days with prices of one of them",
+			225,
+			674,
+			map[string]float64{"2022-01-02": 3000, "2022-01-04": 2000},
+			[]IncomeRecord{
+				{Date: "2022-01-02", Validator: 1, Group: "acme", FromEpoch: 225, ToEpoch: 300, ConsensusRewards: 20000, ExecutionFees: 30000000, MEV: 1000000000, Price: 3000, FiatValue: 3090.06},
+				{Date: "2022-01-03", Validator: 1, Group: "acme", FromEpoch: 450, ToEpoch: 450, ConsensusRewards: 5000, Withdrawals: 20000},
+				{Date: "2022-01-03", Validator: 2, FromEpoch: 450, ToEpoch: 450, ConsensusRewards: -10000},
+			},
+			false,
+		},
+		{
+			"Test case 2, start after end",
+			674,
+			225,
+			nil,
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := BuildIncome(repository, clock, tc.from, tc.to, tc.prices)
+
+			if err := utils.CheckErr("BuildIncome()", tc.isError, err); err != nil {
+				t.Fatal(err)
+			}
+			if assert.Len(t, got, len(tc.want)) {
+				for i := range tc.want {
+					assert.InDelta(t, tc.want[i].FiatValue, got[i].FiatValue, 0.001)
+					got[i].FiatValue = tc.want[i].FiatValue
+					assert.Equal(t, tc.want[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBuildIncomeFromTrackedData(t *testing.T) {
+	monitor, err := setup([][]net.ValidatorBalance{{{Index: "1", Balance: "32000010000"}}}, net.SubscribeOpts{}, ConfigOpts{Checkers: []CfgChecker{
+		{Key: Validators, ErrMsg: NoValidatorsFoundError, Data: []string{"1"}},
+		{Key: Consensus, ErrMsg: NoConsensusFoundError, Data: []string{"cl1"}},
+	}})
+	if err != nil {
+		t.Fatalf("Setup failed. Error %v", err)
+	}
+	defer cleanup(monitor.repository)
+	if err := populateDb(monitor.repository, []db.Validator{{Idx: 1, Balance: 32000000000}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := monitor.repository.SaveSnapshot(db.BalanceSnapshot{Idx: 1, Epoch: 1, Balance: 32000000000}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Validator 1 proposes a local block paying 42000 gwei of priority fees and a block built with MEV-boost paying 0.05 ether,
+	// and gets a withdrawal of 5000 gwei
+	tbc := monitor.beaconClient.(*TestBeaconClient)
+	tbc.duties = map[string][]net.ProposerDuty{"2": {{ValidatorIndex: "1", Slot: "64"}, {ValidatorIndex: "1", Slot: "65"}}}
+	tbc.blocks = map[string]map[string]net.BeaconBlock{"cl1": {
+		"64": {Slot: "64", ProposerIndex: "1", Body: net.BeaconBlockBody{ExecutionPayload: net.ExecutionPayload{BlockNumber: "100", FeeRecipient: "0xfee"}}},
+		"65": {Slot: "65", ProposerIndex: "1", Body: net.BeaconBlockBody{ExecutionPayload: net.ExecutionPayload{BlockNumber: "101", FeeRecipient: "0xbuilder"}}},
+		"head": {Slot: "95", Body: net.BeaconBlockBody{ExecutionPayload: net.ExecutionPayload{
+			Withdrawals: []net.Withdrawal{{Index: "1", ValidatorIndex: "1", Address: "0xaa", Amount: "5000"}},
+		}}},
+	}}
+	monitor.executionClient = &TestExecutionClient{
+		fullBlocks: map[string]map[uint64]net.FullExecutionBlock{"el1": {
+			100: {ExecutionBlock: net.ExecutionBlock{Miner: "0xfee", BaseFeePerGas: 1000000000}, Transactions: []net.ExecutionTransaction{{Hash: "0x01", From: "0xaa", To: "0xbb"}}},
+			101: {ExecutionBlock: net.ExecutionBlock{Miner: "0xbuilder", BaseFeePerGas: 1000000000}, Transactions: []net.ExecutionTransaction{
+				{Hash: "0x02", From: "0xbuilder", To: "0xfee", Value: bigQuantity("50000000000000000")},
+			}},
+		}},
+		receipts: map[string]map[uint64][]net.Receipt{"el1": {
+			100: {{TransactionHash: "0x01", GasUsed: 21000, EffectiveGasPrice: bigQuantity("3000000000")}},
+		}},
+	}
+	monitor.config.execution = []string{"el1"}
+	monitor.settings.feeRecipient = "0xfee"
+
+	monitor.getValidatorBalance(fillChannel([]net.Checkpoint{{Epoch: "2"}}))
+
+	clock, err := NewClock(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), 12, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := BuildIncome(monitor.repository, clock, 2, 2, nil)
+	assert.NoError(t, err)
+	want := []IncomeRecord{
+		{Date: "2022-01-01", Validator: 1, FromEpoch: 2, ToEpoch: 2, ConsensusRewards: 15000, ExecutionFees: 42000, MEV: 50000000, Withdrawals: 5000},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestWriteIncome(t *testing.T) {
+	t.Parallel()
+
+	records := []IncomeRecord{
+		{Date: "2022-01-02", Validator: 1, Group: "acme", FromEpoch: 225, ToEpoch: 300, ConsensusRewards: 20000, ExecutionFees: 30000000, MEV: 1000000000, Price: 3000, FiatValue: 3090.06},
+		{Date: "2022-01-03", Validator: 2, FromEpoch: 450, ToEpoch: 450, ConsensusRewards: -10000, Withdrawals: 20000},
+	}
+
+	tcs := []struct {
+		name    string
+		format  string
+		want    string
+		isError bool
+	}{
+		{
+			"Test case 1, csv",
+			CSVFormat,
+			"date,validator,group,from_epoch,to_epoch,consensus_rewards_eth,execution_fees_eth,mev_eth,withdrawals_eth,income_eth,price_usd,income_usd\n" +
+				"2022-01-02,1,acme,225,300,0.000020000,0.030000000,1.000000000,0.000000000,1.030020000,3000.00,3090.06\n" +
+				"2022-01-03,2,,450,450,-0.000010000,0.000000000,0.000000000,0.000020000,-0.000010000,,\n",
+			false,
+		},
+		{
+			"Test case 2, koinly",
+			KoinlyFormat,
+			"Date,Sent Amount,Sent Currency,Received Amount,Received Currency,Fee Amount,Fee Currency,Net Worth Amount,Net Worth Currency,Label,Description,TxHash\n" +
+				"2022-01-02 23:59:59 UTC,,,0.000020000,ETH,,,0.06,USD,staking,\"Consensus rewards of validator 1, epochs 225-300\",\n" +
+				"2022-01-02 23:59:59 UTC,,,1.030000000,ETH,,,3090.00,USD,reward,\"Execution fees and MEV of validator 1, epochs 225-300\",\n" +
+				"2022-01-03 23:59:59 UTC,0.000010000,ETH,,,,,,,cost,\"Consensus penalties of validator 2, epochs 450-450\",\n",
+			false,
+		},
+		{
+			"Test case 3, unknown format",
+			"qif",
+			"",
+			true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := WriteIncome(&buf, records, tc.format, "usd")
+
+			if err := utils.CheckErr("WriteIncome()", tc.isError, err); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+}
+
+func TestLoadPrices(t *testing.T) {
+	t.Parallel()
+	td := t.TempDir()
+
+	tcs := []struct {
+		name    string
+		content string
+		want    map[string]float64
+		isError bool
+	}{
+		{
+			"Test case 1, prices with header",
+			"date,price\n2022-01-02,3000.5\n2022-01-03 00:00:00,2990\n",
+			map[string]float64{"2022-01-02": 3000.5, "2022-01-03": 2990},
+			false,
+		},
+		{
+			"Test case 2, prices without header",
+			"2022-01-02,3000.5\n",
+			map[string]float64{"2022-01-02": 3000.5},
+			false,
+		},
+		{
+			"Test case 3, invalid price",
+			"date,price\n2022-01-02,n/a\n",
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := LoadPrices(writeFile(t, td, "prices.csv", tc.content))
+
+			if err := utils.CheckErr("LoadPrices()", tc.isError, err); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package alerts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/NethermindEth/posmoni/configs"
+	"github.com/NethermindEth/posmoni/internal/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+// LogAlerter : Struct Alerter interface implementation that writes alerts to the log
+type LogAlerter struct{}
+
+/*
+Send :
+Write the alert to the log using a log level matching its severity.
+
+params :-
+a. alert Alert
+Alert to deliver
+
+returns :-
+a. error
+Error if any
+*/
+func (LogAlerter) Send(alert Alert) error {
+	logFields := log.Fields{configs.Component: "Alerts", "Kind": alert.Kind, "Source": alert.Source}
+	for k, v := range alert.Labels {
+		logFields[k] = v
+	}
+
+	entry := log.WithFields(logFields)
+	switch alert.Severity {
+	case Critical:
+		entry.Error(alert.Message)
+	case Warning:
+		entry.Warn(alert.Message)
+	default:
+		entry.Info(alert.Message)
+	}
+	return nil
+}
+
+// WebhookAlerter : Struct Alerter interface implementation that posts alerts as JSON to a webhook
+type WebhookAlerter struct {
+	// Webhook URL to post alerts to
+	URL string
+	// Time between retries when a request fails
+	RetryDuration time.Duration
+}
+
+/*
+Send :
+Post the alert as a JSON body to the webhook URL.
+
+params :-
+a. alert Alert
+Alert to deliver
+
+returns :-
+a. error
+Error if any
+*/
+func (w WebhookAlerter) Send(alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	resp, err := utils.PostRequest(w.URL, "application/json", bytes.NewBuffer(body), true, w.RetryDuration)
+	if err != nil {
+		return fmt.Errorf(WebhookRequestError, w.URL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf(WebhookResponseError, w.URL, resp.StatusCode)
+	}
+	return nil
+}
+
+// MultiAlerter : Struct Alerter interface implementation that fans out alerts to several alerters
+type MultiAlerter []Alerter
+
+/*
+Send :
+Deliver the alert to every alerter. All alerters are tried even if some of them fail.
+
+params :-
+a. alert Alert
+Alert to deliver
+
+returns :-
+a. error
+First error found if any
+*/
+func (m MultiAlerter) Send(alert Alert) error {
+	var firstErr error
+	for _, a := range m {
+		if err := a.Send(alert); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
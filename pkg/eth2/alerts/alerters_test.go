@@ -0,0 +1,95 @@
+package alerts
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/posmoni/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookAlerter(t *testing.T) {
+	t.Parallel()
+
+	alert := Alert{
+		Kind:     "node_health",
+		Severity: Critical,
+		Source:   "http://localhost:5052",
+		Message:  "down",
+		Labels:   map[string]string{"layer": "consensus"},
+		Time:     time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tcs := []struct {
+		name    string
+		status  int
+		noSrv   bool
+		isError bool
+	}{
+		{"Test case 1, webhook accepts alert", http.StatusOK, false, false},
+		{"Test case 2, webhook accepts alert, no content", http.StatusNoContent, false, false},
+		{"Test case 3, webhook rejects alert", http.StatusBadRequest, false, true},
+		{"Test case 4, webhook unreachable", 0, true, true},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var got Alert
+			srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				data, err := ioutil.ReadAll(req.Body)
+				if err != nil {
+					t.Fatalf("Got error reading request body. Error: %v", err)
+				}
+				if err = json.Unmarshal(data, &got); err != nil {
+					t.Fatalf("Got error unmarshalling request body. Error: %v", err)
+				}
+				rw.WriteHeader(tc.status)
+			}))
+			url := srv.URL
+			if tc.noSrv {
+				srv.Close()
+			} else {
+				defer srv.Close()
+			}
+
+			err := WebhookAlerter{URL: url, RetryDuration: time.Millisecond}.Send(alert)
+			descr := fmt.Sprintf("Send(%+v)", alert)
+			if err = utils.CheckErr(descr, tc.isError, err); err != nil {
+				t.Error(err)
+			}
+
+			if !tc.noSrv {
+				assert.Equal(t, alert, got, descr+" posted a wrong body")
+			}
+		})
+	}
+}
+
+type alerterMock struct {
+	sent []Alert
+	err  error
+}
+
+func (am *alerterMock) Send(alert Alert) error {
+	am.sent = append(am.sent, alert)
+	return am.err
+}
+
+func TestMultiAlerter(t *testing.T) {
+	t.Parallel()
+
+	failing := &alerterMock{err: errors.New("failed")}
+	working := &alerterMock{}
+
+	err := MultiAlerter{failing, working, LogAlerter{}}.Send(Alert{Kind: "test", Severity: Warning})
+
+	assert.Error(t, err)
+	assert.Len(t, failing.sent, 1)
+	assert.Len(t, working.sent, 1, "alert should be delivered even if a previous alerter failed")
+}
@@ -0,0 +1,6 @@
+package alerts
+
+const (
+	WebhookRequestError  = "sending alert to webhook %s failed. Error: %v"
+	WebhookResponseError = "sending alert to webhook %s failed. Status code: %d"
+)
@@ -0,0 +1,6 @@
+package alerts
+
+// Alerter : Interface Represents a channel to deliver alerts to
+type Alerter interface {
+	Send(alert Alert) error
+}
@@ -0,0 +1,24 @@
+package alerts
+
+import "time"
+
+// Severity : Represent how urgent an alert is
+type Severity string
+
+const (
+	Info     Severity = "info"
+	Warning  Severity = "warning"
+	Critical Severity = "critical"
+)
+
+// Alert : Struct Represent a notification raised by the monitor
+type Alert struct {
+	// Kind of check that raised the alert, e.g. 'node_health'
+	Kind     string   `json:"kind"`
+	Severity Severity `json:"severity"`
+	// Endpoint or validator the alert refers to
+	Source  string            `json:"source"`
+	Message string            `json:"message"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Time    time.Time         `json:"time"`
+}
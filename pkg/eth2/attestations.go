@@ -0,0 +1,316 @@
+package eth2
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/NethermindEth/posmoni/configs"
+	"github.com/NethermindEth/posmoni/pkg/eth2/db"
+	net "github.com/NethermindEth/posmoni/pkg/eth2/networking"
+	log "github.com/sirupsen/logrus"
+)
+
+// effectivenessWindow : Epochs the rolling attestation effectiveness is computed over, about a day on mainnet
+const effectivenessWindow = 225
+
+// scannedBlock : Struct Represent a block found while scanning for attestations
+type scannedBlock struct {
+	slot  uint64
+	block net.BeaconBlock
+}
+
+/*
+trackAttestations :
+Record how the attestations of the monitored validators for the epoch before a finalized checkpoint were included: inclusion distance, head, target and source correctness, effectiveness, and rewards lost against an ideal attestation. Committees are resolved from the checkpoint state, and the blocks of the epoch and the next one are scanned for the aggregation bits of the validators. The rolling effectiveness of the validators is updated afterwards.
+
+params :-
+a. stateID string
+State of the finalized checkpoint
+b. epoch uint64
+Epoch of the finalized checkpoint
+
+returns :-
+none
+*/
+func (e *eth2Monitor) trackAttestations(stateID string, epoch uint64) {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "trackAttestations"}
+	if epoch == 0 {
+		return
+	}
+	target := epoch - 1
+
+	committees, err := e.beaconClient.Committees(stateID, strconv.FormatUint(target, 10))
+	if err != nil {
+		log.WithFields(logFields).Errorf(CommitteesError, target, err)
+		return
+	}
+
+	// Validators configured by public key are matched by their resolved index
+	monitored := make(map[string]bool)
+	for _, v := range e.validators.Indexes() {
+		monitored[v] = true
+	}
+
+	// Committee sizes and seats of the monitored validators, by slot and committee index
+	sizes := make(map[uint64]map[uint64]int)
+	seats := make(map[uint64]map[uint64]map[int]uint)
+	duties := make(map[uint]*db.Attestation)
+	var start, end uint64 = math.MaxUint64, 0
+	for _, c := range committees {
+		slot, err := strconv.ParseUint(c.Slot, 10, 64)
+		if err != nil {
+			log.WithFields(logFields).Errorf(ParseUintError, err)
+			continue
+		}
+		index, err := strconv.ParseUint(c.Index, 10, 64)
+		if err != nil {
+			log.WithFields(logFields).Errorf(ParseUintError, err)
+			continue
+		}
+		if slot < start {
+			start = slot
+		}
+		if slot > end {
+			end = slot
+		}
+		if sizes[slot] == nil {
+			sizes[slot] = make(map[uint64]int)
+			seats[slot] = make(map[uint64]map[int]uint)
+		}
+		sizes[slot][index] = len(c.Validators)
+
+		for pos, v := range c.Validators {
+			if !monitored[v] {
+				continue
+			}
+			idx, err := parseUint(v)
+			if err != nil {
+				log.WithFields(logFields).Errorf(ParseUintError, err)
+				continue
+			}
+			if seats[slot][index] == nil {
+				seats[slot][index] = make(map[int]uint)
+			}
+			seats[slot][index][pos] = idx
+			duties[idx] = &db.Attestation{Idx: idx, Epoch: target, Slot: slot, Missed: true}
+		}
+	}
+	if len(duties) == 0 {
+		return
+	}
+
+	// Attestations can be included until the end of the next epoch
+	blocks, err := e.scanBlocks(start+1, end+(end-start+1))
+	if err != nil {
+		log.WithFields(logFields).Errorf(AttestationBlockError, target, err)
+		return
+	}
+
+	for _, b := range blocks {
+		for _, att := range b.block.Body.Attestations {
+			slot, err := strconv.ParseUint(att.Data.Slot, 10, 64)
+			if err != nil || seats[slot] == nil {
+				continue
+			}
+			bits, err := decodeBits(att.AggregationBits)
+			if err != nil {
+				log.WithFields(logFields).Errorf(AttestationBitsError, b.slot, err)
+				continue
+			}
+			committeeIdxs, err := attestationCommittees(att)
+			if err != nil {
+				log.WithFields(logFields).Errorf(AttestationBitsError, b.slot, err)
+				continue
+			}
+
+			// Since Electra, aggregation bits of the committees are concatenated
+			offset := 0
+			for _, c := range committeeIdxs {
+				for pos, idx := range seats[slot][c] {
+					d := duties[idx]
+					if !d.Missed || !bitSet(bits, offset+pos) {
+						continue
+					}
+					d.Missed = false
+					d.InclusionSlot = b.slot
+					d.InclusionDistance = b.slot - d.Slot
+					// Attestations with a wrong source can't be included
+					d.CorrectSource = true
+					d.CorrectTarget = strings.EqualFold(att.Data.Target.Root, rootAt(blocks, start))
+					d.CorrectHead = strings.EqualFold(att.Data.BeaconBlockRoot, rootAt(blocks, d.Slot))
+					d.Effectiveness = float64(earliestInclusion(blocks, d.Slot)-d.Slot) / float64(d.InclusionDistance)
+				}
+				offset += sizes[slot][c]
+			}
+		}
+	}
+
+	var missed int
+	var total float64
+	var idealRewards, missedRewards uint64
+	// Rewards of the validators checked against the monitor threshold under the empty name, and of groups with their own threshold
+	rewards := make(map[string]*epochRewards)
+	for idx, d := range duties {
+		validator := strconv.FormatUint(uint64(idx), 10)
+		vFields := e.validatorFields(logFields, validator)
+		// Effective balance of the last balance check, unknown before the first one
+		if v, err := e.repository.Validator(idx); err == nil && v.EffectiveBalance > 0 {
+			d.IdealReward, d.MissedReward = attestationRewards(*d, v.EffectiveBalance, e.totalActiveBalance, end-start+1)
+			idealRewards += d.IdealReward
+			missedRewards += d.MissedReward
+
+			key, r := "", epochRewards{}
+			if g, ok := e.validators.Group(validator); ok && g.Thresholds.MissedRewards > 0 {
+				key, r.group = g.Name, &g
+			}
+			if rewards[key] == nil {
+				rewards[key] = &r
+			}
+			rewards[key].add(d.IdealReward, d.MissedReward)
+		}
+		if d.Missed {
+			missed++
+			log.WithFields(vFields).Warnf("Attestation of validator %d at slot %d was not included", idx, d.Slot)
+		} else {
+			log.WithFields(vFields).Debugf("Attestation of validator %d at slot %d included at distance %d, head: %v, target: %v, effectiveness: %.2f", idx, d.Slot, d.InclusionDistance, d.CorrectHead, d.CorrectTarget, d.Effectiveness)
+		}
+		total += d.Effectiveness
+		if err := e.repository.SaveAttestation(*d); err != nil {
+			log.WithFields(vFields).Errorf(SaveAttestationError, idx, target, err)
+		}
+	}
+	log.WithFields(logFields).Infof("Attestations of epoch %d: %d included, %d missed, mean effectiveness %.2f, missed rewards: %d of %d gwei", target, len(duties)-missed, missed, total/float64(len(duties)), missedRewards, idealRewards)
+
+	e.updateEffectiveness(target, duties)
+	for _, r := range rewards {
+		e.checkMissedRewards(target, *r)
+	}
+}
+
+/*
+updateEffectiveness :
+Update the rolling attestation effectiveness of validators with the attestations recorded in the last epochs.
+
+params :-
+a. epoch uint64
+Last epoch with recorded attestations
+b. duties map[uint]*db.Attestation
+Attestations of the epoch by validator index. Only these validators are updated
+
+returns :-
+none
+*/
+func (e *eth2Monitor) updateEffectiveness(epoch uint64, duties map[uint]*db.Attestation) {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "updateEffectiveness"}
+
+	var from uint64
+	if epoch+1 > effectivenessWindow {
+		from = epoch + 1 - effectivenessWindow
+	}
+	history, err := e.repository.Attestations(from, epoch)
+	if err != nil {
+		log.WithFields(logFields).Errorf(LoadAttestationsError, from, epoch, err)
+		return
+	}
+
+	sums := make(map[uint]float64)
+	counts := make(map[uint]int)
+	for _, a := range history {
+		sums[a.Idx] += a.Effectiveness
+		counts[a.Idx]++
+	}
+	for idx := range duties {
+		if counts[idx] == 0 {
+			continue
+		}
+		if err := e.repository.SetEffectiveness(idx, sums[idx]/float64(counts[idx])); err != nil {
+			log.WithFields(logFields).Errorf(SyncValidatorError, idx, err)
+		}
+	}
+}
+
+/*
+scanBlocks :
+Get the blocks of a range of slots, skipping missed slots.
+
+params :-
+a. from uint64
+First slot
+b. to uint64
+Last slot, included
+
+returns :-
+a. []scannedBlock
+Blocks sorted by slot
+b. error
+Error of the first block no consensus endpoint answered for
+*/
+func (e *eth2Monitor) scanBlocks(from, to uint64) ([]scannedBlock, error) {
+	blocks := make([]scannedBlock, 0)
+	for slot := from; slot <= to; slot++ {
+		block, missed, err := e.slotBlock(strconv.FormatUint(slot, 10))
+		if err != nil {
+			return nil, fmt.Errorf(SlotBlockError, slot, err)
+		}
+		if !missed {
+			blocks = append(blocks, scannedBlock{slot: slot, block: block})
+		}
+	}
+	return blocks, nil
+}
+
+// rootAt : Get the root of the canonical block at a slot, or of the last one before it if the slot was missed, from the parent root of the next block
+func rootAt(blocks []scannedBlock, slot uint64) string {
+	for _, b := range blocks {
+		if b.slot > slot {
+			return b.block.ParentRoot
+		}
+	}
+	return ""
+}
+
+// earliestInclusion : Get the first slot after an attestation slot with a block, where the attestation could be included at the earliest
+func earliestInclusion(blocks []scannedBlock, slot uint64) uint64 {
+	for _, b := range blocks {
+		if b.slot > slot {
+			return b.slot
+		}
+	}
+	return slot + 1
+}
+
+// attestationCommittees : Get the indexes of the committees aggregated in an attestation, from its committee bits since Electra or its data before
+func attestationCommittees(att net.Attestation) ([]uint64, error) {
+	if att.CommitteeBits == "" {
+		index, err := strconv.ParseUint(att.Data.Index, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return []uint64{index}, nil
+	}
+
+	bits, err := decodeBits(att.CommitteeBits)
+	if err != nil {
+		return nil, err
+	}
+	committees := make([]uint64, 0)
+	for i := 0; i < len(bits)*8; i++ {
+		if bitSet(bits, i) {
+			committees = append(committees, uint64(i))
+		}
+	}
+	return committees, nil
+}
+
+// decodeBits : Decode a 0x prefixed hex encoded SSZ bitfield
+func decodeBits(s string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(s, "0x"))
+}
+
+// bitSet : Check if a bit of an SSZ bitfield is set. Bits are little endian within bytes
+func bitSet(bits []byte, i int) bool {
+	return i/8 < len(bits) && bits[i/8]&(1<<(i%8)) != 0
+}
@@ -0,0 +1,181 @@
+package eth2
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/posmoni/internal/utils"
+	"github.com/NethermindEth/posmoni/pkg/eth2/db"
+	net "github.com/NethermindEth/posmoni/pkg/eth2/networking"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrackAttestations(t *testing.T) {
+	// Validators 1 to 4 are monitored, 4 by public key, attesting at slots 32 to 34 of epoch 1. Epochs of 4 slots make source votes late after 2 slots
+	committees := []net.Committee{
+		{Slot: "32", Index: "0", Validators: []string{"5", "1", "6"}},
+		{Slot: "32", Index: "1", Validators: []string{"2", "7"}},
+		{Slot: "33", Index: "0", Validators: []string{"3", "8"}},
+		{Slot: "34", Index: "0", Validators: []string{"4"}},
+		{Slot: "35", Index: "0", Validators: []string{"9"}},
+	}
+	checkpoint := net.AttestationCheckpoint{Epoch: "1", Root: "0xr32"}
+	// Slot 34 and slots after 36 are missed
+	blocks := map[string]map[string]net.BeaconBlock{"cl1": {
+		"33": {Slot: "33", ParentRoot: "0xr32", Body: net.BeaconBlockBody{Attestations: []net.Attestation{
+			// Validator 1 at position 1 of a committee of 3
+			{AggregationBits: "0x0a", Data: net.AttestationData{Slot: "32", Index: "0", BeaconBlockRoot: "0xr32", Target: checkpoint}},
+		}}},
+		"35": {Slot: "35", ParentRoot: "0xr33", Body: net.BeaconBlockBody{Attestations: []net.Attestation{
+			// Electra aggregate of committees 0 and 1, validator 2 at position 0 of committee 1 after the 3 bits of committee 0
+			{AggregationBits: "0x28", CommitteeBits: "0x03", Data: net.AttestationData{Slot: "32", Index: "0", BeaconBlockRoot: "0xr31", Target: checkpoint}},
+			// Validator 3 at position 0, with a wrong target
+			{AggregationBits: "0x05", Data: net.AttestationData{Slot: "33", Index: "0", BeaconBlockRoot: "0xr33", Target: net.AttestationCheckpoint{Epoch: "1", Root: "0xbad"}}},
+		}}},
+		"36": {Slot: "36", ParentRoot: "0xr35"},
+	}}
+
+	included := []db.Attestation{
+		{Idx: 1, Epoch: 0, Slot: 0, Missed: true},
+		{Idx: 1, Epoch: 1, Slot: 32, InclusionSlot: 33, InclusionDistance: 1, CorrectHead: true, CorrectTarget: true, CorrectSource: true, Effectiveness: 1, IdealReward: 9639},
+		{Idx: 2, Epoch: 1, Slot: 32, InclusionSlot: 35, InclusionDistance: 3, CorrectTarget: true, CorrectSource: true, Effectiveness: 1.0 / 3, IdealReward: 9639, MissedReward: 7497},
+		{Idx: 3, Epoch: 1, Slot: 33, InclusionSlot: 35, InclusionDistance: 2, CorrectHead: true, CorrectSource: true, Effectiveness: 1, IdealReward: 9639, MissedReward: 11781},
+		{Idx: 4, Epoch: 1, Slot: 34, Missed: true, IdealReward: 9639, MissedReward: 16779},
+	}
+
+	tcs := []struct {
+		name       string
+		committees map[string][]net.Committee
+		blocks     map[string]map[string]net.BeaconBlock
+		// groups of the validators, ungrouped if nil
+		groups        []ValidatorGroup
+		want          []db.Attestation
+		effectiveness map[uint]float64
+		// labels of the missed rewards alerts
+		wantAlerts []map[string]string
+	}{
+		{
+			"Test case 1, included, late, wrong head, wrong target and missed attestations",
+			map[string][]net.Committee{"1": committees},
+			blocks,
+			nil,
+			included,
+			// Validator 1 missed its attestation of epoch 0
+			map[uint]float64{1: 0.5, 2: 1.0 / 3, 3: 1, 4: 0},
+			[]map[string]string{{"epoch": "1", "ideal_rewards": "38556", "missed_rewards": "36057", "validators": "3"}},
+		},
+		{
+			"Test case 2, committees unavailable, nothing saved",
+			nil,
+			blocks,
+			nil,
+			[]db.Attestation{{Idx: 1, Epoch: 0, Slot: 0, Missed: true}},
+			map[uint]float64{1: 0, 2: 0, 3: 0, 4: 0},
+			nil,
+		},
+		{
+			"Test case 3, blocks unavailable, nothing saved",
+			map[string][]net.Committee{"1": committees},
+			nil,
+			nil,
+			[]db.Attestation{{Idx: 1, Epoch: 0, Slot: 0, Missed: true}},
+			map[uint]float64{1: 0, 2: 0, 3: 0, 4: 0},
+			nil,
+		},
+		{
+			"Test case 4, missed rewards of a group alerted on its own",
+			map[string][]net.Committee{"1": committees},
+			blocks,
+			[]ValidatorGroup{{Name: "acme", Validators: []string{"3", "0xAA04"}, Thresholds: GroupThresholds{MissedRewards: 50}}},
+			included,
+			map[uint]float64{1: 0.5, 2: 1.0 / 3, 3: 1, 4: 0},
+			[]map[string]string{
+				{"epoch": "1", "ideal_rewards": "19278", "missed_rewards": "7497", "validators": "1"},
+				{"epoch": "1", "ideal_rewards": "19278", "missed_rewards": "28560", "validators": "2", "group": "acme"},
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			monitor, err := setup(nil, net.SubscribeOpts{}, ConfigOpts{Checkers: []CfgChecker{
+				{Key: Validators, ErrMsg: NoValidatorsFoundError, Data: []string{"1", "2", "3", "0xAA04"}},
+				{Key: Consensus, ErrMsg: NoConsensusFoundError, Data: []string{"cl1"}},
+			}})
+			if err != nil {
+				t.Fatalf("Setup failed. Error %v", err)
+			}
+			defer cleanup(monitor.repository)
+			if _, _, err := monitor.validators.reconfigure([]string{"1", "2", "3", "0xAA04"}, tc.groups); err != nil {
+				t.Fatal(err)
+			}
+			validators := []db.Validator{
+				{Idx: 1, EffectiveBalance: 32000000000}, {Idx: 2, EffectiveBalance: 32000000000},
+				{Idx: 3, EffectiveBalance: 32000000000}, {Idx: 4, EffectiveBalance: 32000000000},
+			}
+			if err := populateDb(monitor.repository, validators); err != nil {
+				t.Fatal(err)
+			}
+			if err := monitor.repository.SaveAttestation(db.Attestation{Idx: 1, Epoch: 0, Missed: true}); err != nil {
+				t.Fatal(err)
+			}
+			tbc := monitor.beaconClient.(*TestBeaconClient)
+			tbc.committees = tc.committees
+			tbc.blocks = tc.blocks
+			tbc.registry = []net.ValidatorInfo{{Index: "4", Validator: net.ValidatorData{Pubkey: "0xaa04"}}}
+			monitor.resolveValidators()
+			// A million validators of 32 ether
+			monitor.totalActiveBalance = 32000000000000000
+			am := &alerterMock{}
+			monitor.alerter = am
+
+			monitor.trackAttestations("0xstate", 2)
+
+			got, err := monitor.repository.Attestations(0, 10)
+			assert.NoError(t, err)
+			if assert.Len(t, got, len(tc.want)) {
+				for i := range tc.want {
+					assert.InDelta(t, tc.want[i].Effectiveness, got[i].Effectiveness, 0.0001)
+					got[i].Effectiveness = tc.want[i].Effectiveness
+					assert.Equal(t, tc.want[i], got[i])
+				}
+			}
+			for idx, want := range tc.effectiveness {
+				v, err := monitor.repository.Validator(idx)
+				assert.NoError(t, err)
+				assert.InDelta(t, want, v.Effectiveness, 0.0001)
+			}
+			labels := make([]map[string]string, 0)
+			for _, a := range am.all() {
+				assert.Equal(t, MissedRewardsAlert, a.Kind)
+				labels = append(labels, a.Labels)
+			}
+			assert.ElementsMatch(t, tc.wantAlerts, labels)
+		})
+	}
+}
+
+func TestAttestationCommittees(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		att     net.Attestation
+		want    []uint64
+		isError bool
+	}{
+		{"Test case 1, committee of the data before Electra", net.Attestation{Data: net.AttestationData{Index: "3"}}, []uint64{3}, false},
+		{"Test case 2, committee bits since Electra", net.Attestation{CommitteeBits: "0x0501", Data: net.AttestationData{Index: "0"}}, []uint64{0, 2, 8}, false},
+		{"Test case 3, invalid committee bits", net.Attestation{CommitteeBits: "0xzz"}, nil, true},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := attestationCommittees(tc.att)
+
+			if err := utils.CheckErr("attestationCommittees()", tc.isError, err); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
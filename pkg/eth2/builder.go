@@ -0,0 +1,203 @@
+package eth2
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NethermindEth/posmoni/configs"
+	"github.com/NethermindEth/posmoni/pkg/eth2/alerts"
+	"github.com/NethermindEth/posmoni/pkg/eth2/db"
+	log "github.com/sirupsen/logrus"
+)
+
+// relayDelivery : Struct Represent the relays that delivered a proposed block
+type relayDelivery struct {
+	// True if the configured relays were asked
+	checked bool
+	// Relays that answered
+	answered int
+	// Relays that delivered the block
+	relays []string
+	// Highest bid of the relays in wei, nil if none delivered the block
+	bid *big.Int
+	// Fee recipient of the proposer in the bid trace of the highest bid, in lower case
+	proposerFeeRecipient string
+}
+
+/*
+relayDelivery :
+Ask the configured relays whether they delivered a proposed block, and with which bid.
+
+params :-
+a. validator string
+Validator public index
+b. blockHash string
+Execution block hash of the proposed block
+c. slot uint64
+Slot of the block
+
+returns :-
+a. relayDelivery
+Relays that delivered the block. Not checked if no relay is configured or the block has no execution payload
+*/
+func (e *eth2Monitor) relayDelivery(validator, blockHash string, slot uint64) relayDelivery {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "relayDelivery"}
+	delivery := relayDelivery{relays: make([]string, 0)}
+	if len(e.settings.relays) == 0 || e.relayClient == nil || blockHash == "" {
+		return delivery
+	}
+	delivery.checked = true
+	vFields := e.validatorFields(logFields, validator)
+
+	for _, relay := range e.settings.relays {
+		traces, err := e.relayClient.DeliveredPayloads(relay, slot)
+		if err != nil {
+			log.WithFields(vFields).Errorf(RelayPayloadsError, relay, slot, err)
+			continue
+		}
+		delivery.answered++
+
+		for _, t := range traces {
+			if !strings.EqualFold(t.BlockHash, blockHash) {
+				continue
+			}
+			value, ok := new(big.Int).SetString(t.Value, 10)
+			if !ok {
+				log.WithFields(vFields).Errorf(BidValueError, t.Value, relay, slot)
+				continue
+			}
+			delivery.relays = append(delivery.relays, relay)
+			if delivery.bid == nil || value.Cmp(delivery.bid) > 0 {
+				delivery.bid = value
+				delivery.proposerFeeRecipient = strings.ToLower(t.ProposerFeeRecipient)
+			}
+		}
+	}
+	return delivery
+}
+
+/*
+checkRelayedProposal :
+Record the relays that delivered a proposed block and their bid. A locally built block is alerted when every relay answered, since a relay that failed may have delivered it. A block paying the proposer less than the bid is alerted when its execution rewards are known.
+
+params :-
+a. validator string
+Validator public index
+b. delivery relayDelivery
+Relays that delivered the block
+c. proposal *db.Proposal
+Proposal of the block, with its execution rewards. Relay and bid value are set on it
+d. rewardsKnown bool
+True if the execution rewards of the proposal were read from an execution node
+
+returns :-
+none
+*/
+func (e *eth2Monitor) checkRelayedProposal(validator string, delivery relayDelivery, proposal *db.Proposal, rewardsKnown bool) {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "checkRelayedProposal"}
+	if !delivery.checked {
+		return
+	}
+	vFields := e.validatorFields(logFields, validator)
+
+	labels := map[string]string{"validator": validator, "slot": strconv.FormatUint(proposal.Slot, 10)}
+	switch {
+	case len(delivery.relays) > 0:
+		proposal.Relay = strings.Join(delivery.relays, ",")
+		proposal.BidValue = weiToGwei(delivery.bid)
+		log.WithFields(vFields).Infof("Block of validator %d at slot %d was delivered by %s with a bid of %d gwei", proposal.Idx, proposal.Slot, proposal.Relay, proposal.BidValue)
+
+		// Builders paying the proposer as fee recipient of the block leave no payment transaction
+		paid := proposal.MEV
+		if paid == 0 {
+			paid = proposal.ExecutionFees
+		}
+		if !rewardsKnown || paid >= proposal.BidValue {
+			return
+		}
+		labels["relay"] = proposal.Relay
+		labels["bid_value"] = strconv.FormatUint(proposal.BidValue, 10)
+		labels["paid"] = strconv.FormatUint(paid, 10)
+		e.sendBuilderAlert(alerts.Critical, validator, fmt.Sprintf(BidValueMsg, proposal.Idx, proposal.Slot, paid, proposal.BidValue, proposal.Relay), labels)
+	case delivery.answered == len(e.settings.relays):
+		log.WithFields(vFields).Warnf("Block of validator %d at slot %d was built locally", proposal.Idx, proposal.Slot)
+		e.sendBuilderAlert(alerts.Warning, validator, fmt.Sprintf(LocalBlockMsg, proposal.Idx, proposal.Slot, strings.Join(e.settings.relays, ",")), labels)
+	}
+}
+
+// sendBuilderAlert : Send an alert about the builder of a proposed block, labeled with the group of the validator
+func (e *eth2Monitor) sendBuilderAlert(severity alerts.Severity, validator, msg string, labels map[string]string) {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "sendBuilderAlert"}
+	err := e.alerter.Send(alerts.Alert{
+		Kind:     BuilderAlert,
+		Severity: severity,
+		Source:   validator,
+		Message:  msg,
+		Labels:   e.groupLabels(labels, validator),
+		Time:     time.Now(),
+	})
+	if err != nil {
+		log.WithFields(logFields).Errorf(SendAlertError, err)
+	}
+}
+
+/*
+TrackMevBoost :
+Periodically check that the mev-boost endpoints are up with a relay available, and raise alerts on state changes. Validator clients fall back to local block building while mev-boost is down.
+
+params :-
+a. done <-chan struct{}
+Channel to get stop signal from
+b. wait time.Duration
+Time between checks
+c. tracker *stateTracker
+Tracker to debounce mev-boost states with
+
+returns :-
+none
+*/
+func (e *eth2Monitor) TrackMevBoost(done <-chan struct{}, wait time.Duration, tracker *stateTracker) {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "TrackMevBoost"}
+
+	var w time.Duration
+	for {
+		select {
+		case <-done:
+			return
+		case <-time.After(w):
+			// Don't wait the first time
+			w = wait
+			now := time.Now()
+			for _, endpoint := range e.settings.mevBoost {
+				state := MevBoostUp
+				if err := e.relayClient.BuilderStatus(endpoint); err != nil {
+					log.WithFields(logFields).Warnf(MevBoostStatusError, endpoint, err)
+					state = MevBoostDown
+				}
+
+				tr, ok := tracker.observe(endpoint, string(state), now)
+				if !ok {
+					continue
+				}
+				severity := alerts.Critical
+				if MevBoostState(tr.to) == MevBoostUp {
+					severity = alerts.Info
+				}
+				err := e.alerter.Send(alerts.Alert{
+					Kind:     MevBoostAlert,
+					Severity: severity,
+					Source:   endpoint,
+					Message:  fmt.Sprintf(MevBoostTransitionMsg, endpoint, tr.from, tr.to, tr.lasted),
+					Labels:   map[string]string{"endpoint": endpoint, "state": tr.to, "previous_state": tr.from},
+					Time:     now,
+				})
+				if err != nil {
+					log.WithFields(logFields).Errorf(SendAlertError, err)
+				}
+			}
+		}
+	}
+}
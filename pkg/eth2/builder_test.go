@@ -0,0 +1,197 @@
+package eth2
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/posmoni/pkg/eth2/alerts"
+	"github.com/NethermindEth/posmoni/pkg/eth2/db"
+	net "github.com/NethermindEth/posmoni/pkg/eth2/networking"
+	"github.com/stretchr/testify/assert"
+)
+
+type relayMock struct {
+	mu sync.Mutex
+	// payloads delivered by relay. Missing relays fail
+	payloads map[string][]net.BidTrace
+	// mev-boost endpoints that are down
+	down map[string]bool
+}
+
+func (rm *relayMock) DeliveredPayloads(relay string, slot uint64) ([]net.BidTrace, error) {
+	traces, ok := rm.payloads[relay]
+	if !ok {
+		return nil, fmt.Errorf("Intentional error")
+	}
+	delivered := make([]net.BidTrace, 0)
+	for _, t := range traces {
+		if t.Slot == fmt.Sprint(slot) {
+			delivered = append(delivered, t)
+		}
+	}
+	return delivered, nil
+}
+
+func (rm *relayMock) BuilderStatus(endpoint string) error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	if rm.down[endpoint] {
+		return fmt.Errorf("Intentional error")
+	}
+	return nil
+}
+
+func (rm *relayMock) setDown(endpoint string, down bool) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.down[endpoint] = down
+}
+
+func TestCheckRelayedProposal(t *testing.T) {
+	t.Parallel()
+
+	// Bid of 0.05 ether
+	trace := net.BidTrace{Slot: "67", BlockHash: "0xB10C", Value: "50000000000000000", ProposerFeeRecipient: "0xFEE"}
+	lowTrace := net.BidTrace{Slot: "67", BlockHash: "0xb10c", Value: "40000000000000000", ProposerFeeRecipient: "0xother"}
+
+	tcs := []struct {
+		name         string
+		payloads     map[string][]net.BidTrace
+		proposal     db.Proposal
+		rewardsKnown bool
+		wantRelay    string
+		wantBid      uint64
+		// fee recipient of the proposer in the trace of the highest bid
+		wantFeeRecipient string
+		wantSeverity     alerts.Severity
+	}{
+		{
+			"Test case 1, block delivered by a relay paying the bid",
+			map[string][]net.BidTrace{"relay1": {trace}, "relay2": {}},
+			db.Proposal{Idx: 3, Slot: 67, MEV: 50000000},
+			true,
+			"relay1",
+			50000000,
+			"0xfee",
+			"",
+		},
+		{
+			"Test case 2, block delivered by two relays paying less than the highest bid",
+			map[string][]net.BidTrace{"relay1": {lowTrace}, "relay2": {trace}},
+			db.Proposal{Idx: 3, Slot: 67, MEV: 40000000},
+			true,
+			"relay1,relay2",
+			50000000,
+			"0xfee",
+			alerts.Critical,
+		},
+		{
+			"Test case 3, builder paying the proposer as fee recipient",
+			map[string][]net.BidTrace{"relay1": {trace}, "relay2": {}},
+			db.Proposal{Idx: 3, Slot: 67, ExecutionFees: 50000001},
+			true,
+			"relay1",
+			50000000,
+			"0xfee",
+			"",
+		},
+		{
+			"Test case 4, paid less than the bid, execution rewards unknown",
+			map[string][]net.BidTrace{"relay1": {trace}, "relay2": {}},
+			db.Proposal{Idx: 3, Slot: 67},
+			false,
+			"relay1",
+			50000000,
+			"0xfee",
+			"",
+		},
+		{
+			"Test case 5, block built locally",
+			map[string][]net.BidTrace{"relay1": {{Slot: "66", BlockHash: "0xb10c"}}, "relay2": {{Slot: "67", BlockHash: "0xother"}}},
+			db.Proposal{Idx: 3, Slot: 67, ExecutionFees: 42000},
+			true,
+			"",
+			0,
+			"",
+			alerts.Warning,
+		},
+		{
+			"Test case 6, not delivered by the answering relay, another one failing",
+			map[string][]net.BidTrace{"relay1": {}},
+			db.Proposal{Idx: 3, Slot: 67, ExecutionFees: 42000},
+			true,
+			"",
+			0,
+			"",
+			"",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			vs, err := newValidatorSet([]string{"3"}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			monitor := eth2Monitor{
+				relayClient: &relayMock{payloads: tc.payloads},
+				validators:  vs,
+				settings:    monitorSettings{relays: []string{"relay1", "relay2"}},
+				alerter:     &alerterMock{},
+			}
+
+			proposal := tc.proposal
+			delivery := monitor.relayDelivery("3", "0xb10c", proposal.Slot)
+			monitor.checkRelayedProposal("3", delivery, &proposal, tc.rewardsKnown)
+
+			assert.Equal(t, tc.wantFeeRecipient, delivery.proposerFeeRecipient)
+			assert.Equal(t, tc.wantRelay, proposal.Relay)
+			assert.Equal(t, tc.wantBid, proposal.BidValue)
+			sent := monitor.alerter.(*alerterMock).all()
+			if tc.wantSeverity == "" {
+				assert.Empty(t, sent)
+			} else if assert.Len(t, sent, 1) {
+				assert.Equal(t, BuilderAlert, sent[0].Kind)
+				assert.Equal(t, tc.wantSeverity, sent[0].Severity)
+				assert.Equal(t, "3", sent[0].Source)
+				assert.Equal(t, "67", sent[0].Labels["slot"])
+			}
+		})
+	}
+}
+
+func TestTrackMevBoost(t *testing.T) {
+	t.Parallel()
+
+	rm := &relayMock{down: map[string]bool{}}
+	am := &alerterMock{}
+	monitor := eth2Monitor{
+		relayClient: rm,
+		settings:    monitorSettings{mevBoost: []string{"boost1", "boost2"}},
+		alerter:     am,
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go monitor.TrackMevBoost(done, time.Millisecond*10, newStateTracker(0, string(MevBoostUp)))
+
+	time.Sleep(time.Millisecond * 50)
+	// Up from the start is not alerted
+	assert.Empty(t, am.all())
+
+	rm.setDown("boost2", true)
+	time.Sleep(time.Millisecond * 50)
+	rm.setDown("boost2", false)
+	time.Sleep(time.Millisecond * 50)
+	sent := am.all()
+	if assert.Len(t, sent, 2) {
+		assert.Equal(t, MevBoostAlert, sent[0].Kind)
+		assert.Equal(t, alerts.Critical, sent[0].Severity)
+		assert.Equal(t, "boost2", sent[0].Source)
+		assert.Equal(t, string(MevBoostDown), sent[0].Labels["state"])
+		assert.Equal(t, alerts.Info, sent[1].Severity)
+		assert.Equal(t, string(MevBoostUp), sent[1].Labels["state"])
+	}
+}
@@ -0,0 +1,246 @@
+package eth2
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/NethermindEth/posmoni/configs"
+	log "github.com/sirupsen/logrus"
+)
+
+// Network names
+const (
+	Mainnet = "mainnet"
+	Sepolia = "sepolia"
+	Holesky = "holesky"
+	Gnosis  = "gnosis"
+	// Genesis and spec are read from the consensus nodes
+	CustomNetwork = "custom"
+)
+
+// NetworkPreset : Struct Represent the genesis and timing parameters of a known network
+type NetworkPreset struct {
+	Name string
+	// Unix time of the genesis
+	GenesisTime    int64
+	SecondsPerSlot uint64
+	SlotsPerEpoch  uint64
+}
+
+// networkPresets : Known networks by name
+var networkPresets = map[string]NetworkPreset{
+	Mainnet: {Name: Mainnet, GenesisTime: 1606824023, SecondsPerSlot: 12, SlotsPerEpoch: 32},
+	Sepolia: {Name: Sepolia, GenesisTime: 1655733600, SecondsPerSlot: 12, SlotsPerEpoch: 32},
+	Holesky: {Name: Holesky, GenesisTime: 1695902400, SecondsPerSlot: 12, SlotsPerEpoch: 32},
+	Gnosis:  {Name: Gnosis, GenesisTime: 1638993340, SecondsPerSlot: 5, SlotsPerEpoch: 16},
+}
+
+// Clock : Struct Convert between wall time, slots and epochs of a network
+type Clock struct {
+	genesis       time.Time
+	slotDuration  time.Duration
+	slotsPerEpoch uint64
+}
+
+/*
+NewClock :
+Factory for Clock.
+
+params :-
+a. genesis time.Time
+Genesis time of the network
+b. secondsPerSlot uint64
+Slot duration in seconds
+c. slotsPerEpoch uint64
+Number of slots of an epoch
+
+returns :-
+a. *Clock
+Clock of the network
+b. error
+Error if slot duration or slots per epoch are zero
+*/
+func NewClock(genesis time.Time, secondsPerSlot, slotsPerEpoch uint64) (*Clock, error) {
+	if secondsPerSlot == 0 || slotsPerEpoch == 0 {
+		return nil, fmt.Errorf(InvalidSpecError, secondsPerSlot, slotsPerEpoch)
+	}
+	return &Clock{genesis: genesis, slotDuration: time.Duration(secondsPerSlot) * time.Second, slotsPerEpoch: slotsPerEpoch}, nil
+}
+
+// Genesis : Get the genesis time of the network
+func (c *Clock) Genesis() time.Time {
+	return c.genesis
+}
+
+// SlotsPerEpoch : Get the number of slots of an epoch
+func (c *Clock) SlotsPerEpoch() uint64 {
+	return c.slotsPerEpoch
+}
+
+// SlotDuration : Get the duration of a slot
+func (c *Clock) SlotDuration() time.Duration {
+	return c.slotDuration
+}
+
+// EpochDuration : Get the duration of an epoch
+func (c *Clock) EpochDuration() time.Duration {
+	return c.slotDuration * time.Duration(c.slotsPerEpoch)
+}
+
+// SlotAt : Get the slot at a given time. Times before genesis are slot 0
+func (c *Clock) SlotAt(t time.Time) uint64 {
+	if t.Before(c.genesis) {
+		return 0
+	}
+	return uint64(t.Sub(c.genesis) / c.slotDuration)
+}
+
+// EpochAt : Get the epoch at a given time. Times before genesis are epoch 0
+func (c *Clock) EpochAt(t time.Time) uint64 {
+	return c.EpochOf(c.SlotAt(t))
+}
+
+// EpochOf : Get the epoch of a slot
+func (c *Clock) EpochOf(slot uint64) uint64 {
+	return slot / c.slotsPerEpoch
+}
+
+// SlotStart : Get the start time of a slot
+func (c *Clock) SlotStart(slot uint64) time.Time {
+	return c.genesis.Add(time.Duration(slot) * c.slotDuration)
+}
+
+// EpochStart : Get the start time of an epoch
+func (c *Clock) EpochStart(epoch uint64) time.Time {
+	return c.SlotStart(epoch * c.slotsPerEpoch)
+}
+
+/*
+UntilNextEpoch :
+Get the time left until the start of the next epoch. Before genesis, the time left until genesis.
+
+params :-
+a. t time.Time
+Time to count from
+
+returns :-
+a. time.Duration
+Time left until the next epoch starts
+*/
+func (c *Clock) UntilNextEpoch(t time.Time) time.Duration {
+	if t.Before(c.genesis) {
+		return c.genesis.Sub(t)
+	}
+	return c.EpochStart(c.EpochAt(t) + 1).Sub(t)
+}
+
+/*
+Clock :
+Build the clock of the configured network. Presets are checked against the genesis of the consensus nodes, so a node on another network is reported. With the custom network, genesis and spec are read from the first consensus node that answers.
+
+params :-
+none
+
+returns :-
+a. *Clock
+Clock of the network
+b. error
+Error if any
+*/
+func (e *eth2Monitor) Clock() (*Clock, error) {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "Clock"}
+	network := e.settings.network
+
+	if network != CustomNetwork {
+		clock, err := PresetClock(network)
+		if err != nil {
+			return nil, err
+		}
+		p := networkPresets[network]
+		for _, endpoint := range e.config.consensus {
+			g, err := e.beaconClient.Genesis(endpoint)
+			if err != nil {
+				log.WithFields(logFields).Debugf("Could not check genesis of %s. Error: %v", endpoint, err)
+				continue
+			}
+			if g.GenesisTime != strconv.FormatInt(p.GenesisTime, 10) {
+				return nil, fmt.Errorf(NetworkMismatchError, endpoint, g.GenesisTime, network, p.GenesisTime)
+			}
+		}
+		return clock, nil
+	}
+
+	for _, endpoint := range e.config.consensus {
+		c, err := e.nodeClock(endpoint)
+		if err != nil {
+			log.WithFields(logFields).Errorf(NodeClockError, endpoint, err)
+			continue
+		}
+		return c, nil
+	}
+
+	return nil, fmt.Errorf(NoClockError, e.config.consensus)
+}
+
+/*
+PresetClock :
+Build the clock of a known network from its preset, without asking the consensus nodes.
+
+params :-
+a. network string
+Network name
+
+returns :-
+a. *Clock
+Clock of the network
+b. error
+Error if the network has no preset
+*/
+func PresetClock(network string) (*Clock, error) {
+	p, ok := networkPresets[network]
+	if !ok {
+		return nil, fmt.Errorf(UnknownNetworkError, network, []string{Mainnet, Sepolia, Holesky, Gnosis, CustomNetwork})
+	}
+	return NewClock(time.Unix(p.GenesisTime, 0), p.SecondsPerSlot, p.SlotsPerEpoch)
+}
+
+/*
+nodeClock :
+Build a clock from the genesis and spec of a consensus node.
+
+params :-
+a. endpoint string
+Consensus endpoint
+
+returns :-
+a. *Clock
+Clock of the network of the node
+b. error
+Error if any
+*/
+func (e *eth2Monitor) nodeClock(endpoint string) (*Clock, error) {
+	g, err := e.beaconClient.Genesis(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := e.beaconClient.Spec(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	genesis, err := strconv.ParseInt(g.GenesisTime, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	secondsPerSlot, err := strconv.ParseUint(spec.SecondsPerSlot, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	slotsPerEpoch, err := strconv.ParseUint(spec.SlotsPerEpoch, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClock(time.Unix(genesis, 0), secondsPerSlot, slotsPerEpoch)
+}
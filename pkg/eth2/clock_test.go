@@ -0,0 +1,193 @@
+package eth2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/posmoni/internal/utils"
+	net "github.com/NethermindEth/posmoni/pkg/eth2/networking"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClockConversions(t *testing.T) {
+	t.Parallel()
+
+	genesis := time.Unix(1606824023, 0)
+	c, err := NewClock(genesis, 12, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tcs := []struct {
+		name      string
+		at        time.Time
+		wantSlot  uint64
+		wantEpoch uint64
+		wantNext  time.Duration
+	}{
+		{
+			"Test case 1, before genesis",
+			genesis.Add(-time.Minute),
+			0,
+			0,
+			time.Minute,
+		},
+		{
+			"Test case 2, at genesis",
+			genesis,
+			0,
+			0,
+			384 * time.Second,
+		},
+		{
+			"Test case 3, middle of a slot",
+			genesis.Add(30 * time.Second),
+			2,
+			0,
+			354 * time.Second,
+		},
+		{
+			"Test case 4, epoch boundary",
+			genesis.Add(384 * time.Second),
+			32,
+			1,
+			384 * time.Second,
+		},
+		{
+			"Test case 5, one second before an epoch boundary",
+			genesis.Add(10*384*time.Second - time.Second),
+			319,
+			9,
+			time.Second,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantSlot, c.SlotAt(tc.at), "SlotAt() gave wrong slot")
+			assert.Equal(t, tc.wantEpoch, c.EpochAt(tc.at), "EpochAt() gave wrong epoch")
+			assert.Equal(t, tc.wantNext, c.UntilNextEpoch(tc.at), "UntilNextEpoch() gave wrong duration")
+		})
+	}
+
+	assert.Equal(t, genesis.Add(384*time.Second), c.EpochStart(1))
+	assert.Equal(t, genesis.Add(12*time.Second), c.SlotStart(1))
+	assert.Equal(t, uint64(3), c.EpochOf(127))
+	assert.Equal(t, 384*time.Second, c.EpochDuration())
+
+	_, err = NewClock(genesis, 0, 32)
+	assert.Error(t, err)
+}
+
+func TestMonitorClock(t *testing.T) {
+	t.Parallel()
+
+	mainnet := net.Genesis{GenesisTime: "1606824023"}
+	gnosisSpec := net.Spec{ConfigName: "gnosis", SecondsPerSlot: "5", SlotsPerEpoch: "16"}
+
+	tcs := []struct {
+		name        string
+		network     string
+		genesis     map[string]net.Genesis
+		specs       map[string]net.Spec
+		wantGenesis int64
+		wantSlot    time.Duration
+		wantSlots   uint64
+		isError     bool
+	}{
+		{
+			"Test case 1, mainnet preset, node unreachable",
+			Mainnet,
+			nil,
+			nil,
+			1606824023,
+			12 * time.Second,
+			32,
+			false,
+		},
+		{
+			"Test case 2, mainnet preset, node on mainnet",
+			Mainnet,
+			map[string]net.Genesis{"cl1": mainnet},
+			nil,
+			1606824023,
+			12 * time.Second,
+			32,
+			false,
+		},
+		{
+			"Test case 3, sepolia preset, node on mainnet",
+			Sepolia,
+			map[string]net.Genesis{"cl1": mainnet},
+			nil,
+			0,
+			0,
+			0,
+			true,
+		},
+		{
+			"Test case 4, unknown network",
+			"ropsten",
+			nil,
+			nil,
+			0,
+			0,
+			0,
+			true,
+		},
+		{
+			"Test case 5, custom network from the second node",
+			CustomNetwork,
+			map[string]net.Genesis{"cl1": {GenesisTime: "1638993340"}, "cl2": {GenesisTime: "1638993340"}},
+			map[string]net.Spec{"cl2": gnosisSpec},
+			1638993340,
+			5 * time.Second,
+			16,
+			false,
+		},
+		{
+			"Test case 6, custom network, no node answers",
+			CustomNetwork,
+			nil,
+			nil,
+			0,
+			0,
+			0,
+			true,
+		},
+		{
+			"Test case 7, custom network, bad spec",
+			CustomNetwork,
+			map[string]net.Genesis{"cl1": mainnet},
+			map[string]net.Spec{"cl1": {SecondsPerSlot: "0", SlotsPerEpoch: "32"}},
+			0,
+			0,
+			0,
+			true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			tbc := newTestBeaconClient(nil, nil)
+			tbc.genesis = tc.genesis
+			tbc.specs = tc.specs
+			monitor := eth2Monitor{
+				beaconClient: tbc,
+				config:       eth2Config{consensus: []string{"cl1", "cl2"}},
+				settings:     monitorSettings{network: tc.network},
+			}
+
+			got, err := monitor.Clock()
+			if err = utils.CheckErr("Clock()", tc.isError, err); err != nil {
+				t.Fatal(err)
+			}
+			if tc.isError {
+				return
+			}
+			assert.Equal(t, tc.wantGenesis, got.Genesis().Unix())
+			assert.Equal(t, tc.wantSlot, got.SlotDuration())
+			assert.Equal(t, tc.wantSlots, got.SlotsPerEpoch())
+		})
+	}
+}
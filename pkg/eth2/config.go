@@ -0,0 +1,518 @@
+package eth2
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+)
+
+// defaultDBPath : Sqlite database file used if none is configured
+const defaultDBPath = "eth2_monitor.db"
+
+// maskedSecret : Replacement of secrets when showing the configuration
+const maskedSecret = "********"
+
+// addressPattern : Execution layer address, like fee recipients
+var addressPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+
+// Config : Struct Represent the monitor configuration, merged from config file, environment variables, flags and defaults. It is marshalled to YAML in the shape of a config file
+type Config struct {
+	// Every validator, grouped or not
+	Validators []string `yaml:"-"`
+	// Validator groups. Their validators are also in Validators
+	Groups    []ValidatorGroup `yaml:"-"`
+	Consensus []string         `yaml:"consensus"`
+	Execution []string         `yaml:"execution,omitempty"`
+	Network   string           `yaml:"network"`
+	DBPath    string           `yaml:"db_path"`
+	// Node health settings. Intervals are in seconds
+	MinPeers          uint64 `yaml:"min_peers"`
+	MinInboundPeers   uint64 `yaml:"min_inbound_peers"`
+	HealthInterval    int64  `yaml:"health_interval"`
+	HealthGracePeriod int64  `yaml:"health_grace_period"`
+	// Alert settings
+	AlertsWebhook               string `yaml:"alerts_webhook,omitempty"`
+	ReorgDepthThreshold         uint64 `yaml:"reorg_depth_threshold"`
+	MissedAttestationsThreshold uint   `yaml:"missed_attestations_threshold"`
+	// Effective balance in ether below which validators are alerted. Zero disables the check
+	MinEffectiveBalance uint64 `yaml:"min_effective_balance"`
+	// Percentage of the ideal attestation rewards of an epoch lost before alerting. Zero disables the check
+	MissedRewardsThreshold uint `yaml:"missed_rewards_threshold"`
+	// Epochs finality can fall behind its usual delay of 2 epochs before alerting
+	FinalityStallEpochs uint64 `yaml:"finality_stall_epochs"`
+	// Percentage of the network attesting to the correct target below which the network is degraded. Zero disables the check
+	MinParticipation uint `yaml:"min_participation"`
+	// Validator clients settings
+	Keymanagers           []Keymanager `yaml:"keymanagers,omitempty"`
+	KeymanagerAutoMonitor bool         `yaml:"keymanager_auto_monitor"`
+	// Expected fee recipients, globally and by validator index
+	FeeRecipient  string            `yaml:"fee_recipient,omitempty"`
+	FeeRecipients map[string]string `yaml:"fee_recipients,omitempty"`
+	// MEV-boost relays and mev-boost endpoints
+	Relays   []string `yaml:"relays,omitempty"`
+	MevBoost []string `yaml:"mev_boost,omitempty"`
+	LogLevel string   `yaml:"-"`
+}
+
+// configFields : Fields of Config marshalled as they are, without its MarshalYAML method
+type configFields Config
+
+// configFile : Struct Represent a configuration in the shape of a config file, with groups inside the validators list and the log level under logs
+type configFile struct {
+	Validators   []any `yaml:"validators"`
+	configFields `yaml:",inline"`
+	Logs         *logsConfig `yaml:"logs,omitempty"`
+}
+
+// logsConfig : Struct Represent the logs section of a config file
+type logsConfig struct {
+	LogLevel string `yaml:"logLevel"`
+}
+
+/*
+MarshalYAML :
+Get the configuration in the shape of a config file, so a shown configuration can be used as one. Grouped validators are listed only in their group, placed where its first validator is.
+
+params :-
+none
+
+returns :-
+a. any
+Configuration to marshal
+b. error
+Always nil
+*/
+func (c Config) MarshalYAML() (any, error) {
+	groupOf := make(map[string]int)
+	for i, g := range c.Groups {
+		for _, v := range g.Validators {
+			groupOf[v] = i
+		}
+	}
+
+	f := configFile{Validators: make([]any, 0, len(c.Validators)), configFields: configFields(c)}
+	added := make(map[int]bool)
+	for _, v := range c.Validators {
+		i, grouped := groupOf[v]
+		if !grouped {
+			f.Validators = append(f.Validators, v)
+			continue
+		}
+		if !added[i] {
+			f.Validators = append(f.Validators, c.Groups[i])
+			added[i] = true
+		}
+	}
+	if c.LogLevel != "" {
+		f.Logs = &logsConfig{LogLevel: c.LogLevel}
+	}
+	return f, nil
+}
+
+// knownKeys : Configuration keys accepted in config files, in lower case as viper reports them
+var knownKeys = map[string]bool{
+	"validators": true, "consensus": true, "execution": true, "network": true, "db_path": true,
+	"min_peers": true, "min_inbound_peers": true, "health_interval": true, "health_grace_period": true,
+	"alerts_webhook": true, "reorg_depth_threshold": true, "missed_attestations_threshold": true, "min_effective_balance": true,
+	"missed_rewards_threshold": true, "finality_stall_epochs": true, "min_participation": true, "keymanagers": true, "keymanager_auto_monitor": true, "fee_recipient": true, "fee_recipients": true,
+	"relays": true, "mev_boost": true, "logs": true, "logs.loglevel": true,
+}
+
+// mapKeys : Configuration keys holding maps, whose nested keys are not checked
+var mapKeys = []string{"fee_recipients."}
+
+/*
+dbPath :
+Get the sqlite database file from config file or enviroment variables, falling back to the default one.
+
+params :-
+none
+
+returns :-
+a. string
+Database file path
+*/
+func dbPath() string {
+	viper.BindEnv(DBPath)
+	viper.SetDefault(DBPath, defaultDBPath)
+	return viper.GetString(DBPath)
+}
+
+/*
+LoadConfig :
+Get the monitor configuration from config file, enviroment variables and flags, falling back to default values. Loading goes on after errors, so the result can be shown even if it is invalid.
+
+params :-
+none
+
+returns :-
+a. Config
+Monitor configuration
+b. []error
+Errors found while loading
+*/
+func LoadConfig() (Config, []error) {
+	errs := make([]error, 0)
+	viper.SetEnvPrefix("PM")
+	for _, k := range []string{Validators, Consensus, Execution} {
+		viper.BindEnv(k)
+	}
+
+	var cfg Config
+	var err error
+	if cfg.Validators, cfg.Groups, err = checkValidators(NoValidatorsFoundError); err != nil {
+		errs = append(errs, err)
+	}
+	if cfg.Consensus, err = checkVariable(Consensus, NoConsensusFoundError); err != nil {
+		errs = append(errs, err)
+	}
+	if viper.IsSet(Execution) {
+		if cfg.Execution, err = checkVariable(Execution, NoExecutionFoundError); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if cfg.Keymanagers, err = loadKeymanagers(); err != nil {
+		errs = append(errs, err)
+	}
+
+	cfg.setSettings(loadSettings())
+	cfg.DBPath = dbPath()
+	cfg.LogLevel = viper.GetString(LogLevel)
+
+	return cfg, errs
+}
+
+// setSettings : Set the settings of a configuration from the monitor settings
+func (c *Config) setSettings(s monitorSettings) {
+	c.Network = s.network
+	c.MinPeers = s.minPeers
+	c.MinInboundPeers = s.minInboundPeers
+	c.HealthInterval = int64(s.healthInterval.Seconds())
+	c.HealthGracePeriod = int64(s.healthGracePeriod.Seconds())
+	c.AlertsWebhook = s.alertsWebhook
+	c.ReorgDepthThreshold = s.reorgDepthThreshold
+	c.MissedAttestationsThreshold = s.missedAttestationsThreshold
+	c.MinEffectiveBalance = s.minEffectiveBalance / gweiPerEth
+	c.MissedRewardsThreshold = s.missedRewardsThreshold
+	c.FinalityStallEpochs = s.finalityStallEpochs
+	c.MinParticipation = s.minParticipation
+	c.KeymanagerAutoMonitor = s.keymanagerAutoMonitor
+	c.FeeRecipient = s.feeRecipient
+	c.FeeRecipients = s.feeRecipients
+	c.Relays = s.relays
+	c.MevBoost = s.mevBoost
+}
+
+/*
+ValidateConfig :
+Load the monitor configuration and check it for misconfigurations, including unknown keys in the config file.
+
+params :-
+none
+
+returns :-
+a. Config
+Monitor configuration
+b. []error
+Every misconfiguration found. Empty if the configuration is valid
+*/
+func ValidateConfig() (Config, []error) {
+	cfg, errs := LoadConfig()
+	errs = append(errs, unknownKeys()...)
+	return cfg, append(errs, cfg.Validate()...)
+}
+
+// unknownKeys : Get an error for every unknown key of the config file
+func unknownKeys() []error {
+	errs := make([]error, 0)
+	keys := viper.AllKeys()
+	sort.Strings(keys)
+	for _, k := range keys {
+		if !knownKeys[k] && !isMapKey(k) {
+			errs = append(errs, fmt.Errorf(UnknownConfigKeyError, k))
+		}
+	}
+	return errs
+}
+
+// isMapKey : Check if a configuration key is nested in a map key
+func isMapKey(key string) bool {
+	for _, prefix := range mapKeys {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+Validate :
+Check the configuration values: endpoint URLs, validator entries, duplicates, network and settings.
+
+params :-
+none
+
+returns :-
+a. []error
+Every invalid value found. Empty if the configuration is valid
+*/
+func (c Config) Validate() []error {
+	errs := make([]error, 0)
+
+	seen := make(map[string]bool, len(c.Validators))
+	for _, v := range c.Validators {
+		if seen[v] {
+			errs = append(errs, fmt.Errorf(DuplicatedValidatorError, v))
+		}
+		seen[v] = true
+	}
+	if len(c.Validators) > 0 {
+		if _, err := newValidatorSet(c.Validators, c.Groups); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, u := range c.Consensus {
+		errs = appendErr(errs, checkURL(Consensus, u))
+	}
+	for _, u := range c.Execution {
+		errs = appendErr(errs, checkURL(Execution, u))
+	}
+	if c.AlertsWebhook != "" {
+		errs = appendErr(errs, checkURL(AlertsWebhook, c.AlertsWebhook))
+	}
+	for _, u := range c.Relays {
+		errs = appendErr(errs, checkURL(Relays, u))
+	}
+	for _, u := range c.MevBoost {
+		errs = appendErr(errs, checkURL(MevBoost, u))
+	}
+	for _, km := range c.Keymanagers {
+		errs = appendErr(errs, checkURL(Keymanagers, km.URL))
+		if km.Token == "" && km.TokenFile != "" {
+			if _, err := os.Stat(km.TokenFile); err != nil {
+				errs = append(errs, fmt.Errorf(KeymanagerTokenError, km.URL, err))
+			}
+		}
+	}
+
+	for _, g := range c.Groups {
+		if g.FeeRecipient != "" {
+			errs = appendErr(errs, checkAddress("fee_recipient of group "+g.Name, g.FeeRecipient))
+		}
+	}
+
+	errs = append(errs, c.validateSettings()...)
+	if c.LogLevel != "" {
+		if _, err := log.ParseLevel(strings.ToLower(c.LogLevel)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+/*
+validateSettings :
+Check the values of the monitor settings. Invalid settings are refused by the monitor, since they break its loops or silently disable alerts.
+
+params :-
+none
+
+returns :-
+a. []error
+Every invalid setting found. Empty if the settings are valid
+*/
+func (c Config) validateSettings() []error {
+	errs := make([]error, 0)
+
+	if c.FeeRecipient != "" {
+		errs = appendErr(errs, checkAddress(FeeRecipient, c.FeeRecipient))
+	}
+	validators := make([]string, 0, len(c.FeeRecipients))
+	for v := range c.FeeRecipients {
+		validators = append(validators, v)
+	}
+	sort.Strings(validators)
+	for _, v := range validators {
+		errs = appendErr(errs, checkAddress(FeeRecipients+"."+v, c.FeeRecipients[v]))
+	}
+
+	if _, ok := networkPresets[c.Network]; !ok && c.Network != CustomNetwork {
+		errs = append(errs, fmt.Errorf(UnknownNetworkError, c.Network, networkNames()))
+	}
+	if c.HealthInterval <= 0 {
+		errs = append(errs, fmt.Errorf(InvalidSettingError, strings.ToLower(HealthInterval), c.HealthInterval))
+	}
+	if c.HealthGracePeriod < 0 {
+		errs = append(errs, fmt.Errorf(InvalidSettingError, strings.ToLower(HealthGracePeriod), c.HealthGracePeriod))
+	}
+	if c.MissedAttestationsThreshold == 0 {
+		errs = append(errs, fmt.Errorf(InvalidSettingError, strings.ToLower(MissedAttestationsThreshold), c.MissedAttestationsThreshold))
+	}
+	return errs
+}
+
+// appendErr : Append an error to a list if it is not nil
+func appendErr(errs []error, err error) []error {
+	if err != nil {
+		return append(errs, err)
+	}
+	return errs
+}
+
+// checkURL : Check that a configuration value is an absolute http(s) URL
+func checkURL(key, value string) error {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf(InvalidURLError, strings.ToLower(key), value)
+	}
+	return nil
+}
+
+// checkAddress : Check that a configuration value is a 0x prefixed 20 bytes hex address
+func checkAddress(key, value string) error {
+	if !addressPattern.MatchString(value) {
+		return fmt.Errorf(InvalidAddressError, strings.ToLower(key), value)
+	}
+	return nil
+}
+
+// networkNames : Get the names of the accepted networks, sorted
+func networkNames() []string {
+	names := []string{CustomNetwork}
+	for n := range networkPresets {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+/*
+Masked :
+Get a copy of the configuration with secrets masked, to show it. Keymanager tokens, URL passwords and alert webhook paths are masked.
+
+params :-
+none
+
+returns :-
+a. Config
+Configuration safe to show
+*/
+func (c Config) Masked() Config {
+	m := c
+	m.Consensus = maskURLs(c.Consensus)
+	m.Execution = maskURLs(c.Execution)
+	m.MevBoost = maskURLs(c.MevBoost)
+	m.AlertsWebhook = maskWebhook(c.AlertsWebhook)
+	if c.Keymanagers != nil {
+		m.Keymanagers = make([]Keymanager, len(c.Keymanagers))
+		for i, km := range c.Keymanagers {
+			m.Keymanagers[i] = maskKeymanager(km)
+		}
+	}
+	return m
+}
+
+/*
+MaskSettings :
+Mask secrets of raw settings as read from a config file, to show them. Keys are expected in lower case, as viper gives them.
+
+params :-
+a. settings map[string]any
+Raw settings
+
+returns :-
+a. map[string]any
+Settings safe to show
+*/
+func MaskSettings(settings map[string]any) map[string]any {
+	masked := make(map[string]any, len(settings))
+	for k, v := range settings {
+		masked[k] = v
+	}
+
+	for _, k := range []string{"consensus", "execution", "mev_boost"} {
+		switch v := masked[k].(type) {
+		case string:
+			masked[k] = strings.Join(maskURLs(strings.Split(v, ",")), ",")
+		case []any:
+			masked[k] = maskURLs(cast.ToStringSlice(v))
+		}
+	}
+	if v, ok := masked["alerts_webhook"].(string); ok {
+		masked["alerts_webhook"] = maskWebhook(v)
+	}
+	if items, ok := masked["keymanagers"].([]any); ok {
+		kms := make([]any, len(items))
+		for i, item := range items {
+			raw, ok := asMap(item)
+			if !ok {
+				kms[i] = maskURL(cast.ToString(item))
+				continue
+			}
+			km := make(map[string]any, len(raw))
+			for k, v := range raw {
+				km[k] = v
+			}
+			if _, ok := km["url"]; ok {
+				km["url"] = maskURL(cast.ToString(km["url"]))
+			}
+			if _, ok := km["token"]; ok {
+				km["token"] = maskedSecret
+			}
+			kms[i] = km
+		}
+		masked["keymanagers"] = kms
+	}
+	return masked
+}
+
+// maskKeymanager : Mask the token and URL password of a keymanager
+func maskKeymanager(km Keymanager) Keymanager {
+	km.URL = maskURL(km.URL)
+	if km.Token != "" {
+		km.Token = maskedSecret
+	}
+	return km
+}
+
+// maskURLs : Mask the passwords of several URLs
+func maskURLs(urls []string) []string {
+	if urls == nil {
+		return nil
+	}
+	masked := make([]string, len(urls))
+	for i, u := range urls {
+		masked[i] = maskURL(u)
+	}
+	return masked
+}
+
+// maskURL : Mask the password of an URL with 'xxxxx'. Values that are not URLs are kept
+func maskURL(value string) string {
+	u, err := url.Parse(strings.TrimSpace(value))
+	if err != nil || u.User == nil {
+		return value
+	}
+	return u.Redacted()
+}
+
+// maskWebhook : Mask the path and query of a webhook URL, where services like Slack or Discord put their tokens
+func maskWebhook(value string) string {
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" {
+		return value
+	}
+	if u.Path != "" && u.Path != "/" || u.RawQuery != "" {
+		return u.Scheme + "://" + u.Host + "/" + maskedSecret
+	}
+	return maskURL(value)
+}
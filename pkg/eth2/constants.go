@@ -5,3 +5,49 @@ const (
 	Consensus  = "CONSENSUS"
 	Execution  = "EXECUTION"
 )
+
+// Monitor settings keys
+const (
+	// Minimum connected peers of a consensus node
+	MinPeers = "MIN_PEERS"
+	// Minimum inbound peers of a consensus node
+	MinInboundPeers = "MIN_INBOUND_PEERS"
+	// Seconds between node health checks
+	HealthInterval = "HEALTH_INTERVAL"
+	// Seconds a node health state should last before alerting about it
+	HealthGracePeriod = "HEALTH_GRACE_PERIOD"
+	// Webhook URL to post alerts to
+	AlertsWebhook = "ALERTS_WEBHOOK"
+	// Chain reorgs deeper than this number of slots are alerted
+	ReorgDepthThreshold = "REORG_DEPTH_THRESHOLD"
+	// Consecutive missed attestations of a validator before alerting. Can be overridden per validator group
+	MissedAttestationsThreshold = "MISSED_ATTESTATIONS_THRESHOLD"
+	// Effective balance in ether below which validators are alerted. Zero disables the check
+	MinEffectiveBalance = "MIN_EFFECTIVE_BALANCE"
+	// Percentage of the ideal attestation rewards of an epoch lost by the validators before alerting. Zero disables the check
+	MissedRewardsThreshold = "MISSED_REWARDS_THRESHOLD"
+	// Epochs finality can fall behind its usual delay of 2 epochs before alerting
+	FinalityStallEpochs = "FINALITY_STALL_EPOCHS"
+	// Percentage of the network attesting to the correct target below which the network is degraded. Zero disables the check
+	MinParticipation = "MIN_PARTICIPATION"
+	// Network preset name: mainnet, sepolia, holesky, gnosis or custom
+	Network = "NETWORK"
+	// Keymanager APIs of the validator clients to compare monitored validators against
+	Keymanagers = "KEYMANAGERS"
+	// Monitor keys loaded in the validator clients that are not configured
+	KeymanagerAutoMonitor = "KEYMANAGER_AUTO_MONITOR"
+	// Expected fee recipient of every validator. Can be overridden per validator group
+	FeeRecipient = "FEE_RECIPIENT"
+	// Expected fee recipients by validator index, overriding the group and global ones
+	FeeRecipients = "FEE_RECIPIENTS"
+	// MEV-boost relays to check proposals against
+	Relays = "RELAYS"
+	// mev-boost endpoints of the validator clients, checked for being up
+	MevBoost = "MEV_BOOST"
+	// Path of the sqlite database file
+	DBPath = "DB_PATH"
+	// Logging settings, with the log level under logLevel
+	Logs = "LOGS"
+	// Log level, nested under the logging settings
+	LogLevel = "logs.logLevel"
+)
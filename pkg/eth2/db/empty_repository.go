@@ -15,6 +15,54 @@ func (er EmptyRepository) Validator(index uint) (v Validator, e error) {
 	return
 }
 
+func (er EmptyRepository) SetInactive(index uint, inactive bool) error {
+	return nil
+}
+
+func (er EmptyRepository) SaveReorg(Reorg) error {
+	return nil
+}
+
+func (er EmptyRepository) SaveSnapshot(BalanceSnapshot) error {
+	return nil
+}
+
+func (er EmptyRepository) Snapshots(fromEpoch, toEpoch uint64) (s []BalanceSnapshot, e error) {
+	return
+}
+
+func (er EmptyRepository) LastSnapshotSlot() (s uint64, e error) {
+	return
+}
+
+func (er EmptyRepository) SaveProposal(Proposal) error {
+	return nil
+}
+
+func (er EmptyRepository) Proposals(fromEpoch, toEpoch uint64) (p []Proposal, e error) {
+	return
+}
+
+func (er EmptyRepository) SaveWithdrawal(Withdrawal) error {
+	return nil
+}
+
+func (er EmptyRepository) Withdrawals(fromEpoch, toEpoch uint64) (w []Withdrawal, e error) {
+	return
+}
+
+func (er EmptyRepository) SaveAttestation(Attestation) error {
+	return nil
+}
+
+func (er EmptyRepository) Attestations(fromEpoch, toEpoch uint64) (a []Attestation, e error) {
+	return
+}
+
+func (er EmptyRepository) SetEffectiveness(index uint, effectiveness float64) error {
+	return nil
+}
+
 func (er EmptyRepository) Migrate() error {
 	return nil
 }
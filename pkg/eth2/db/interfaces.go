@@ -4,5 +4,17 @@ type Repository interface {
 	FirstOrCreate(Validator) (Validator, error)
 	Update(Validator) error
 	Validator(index uint) (Validator, error)
+	SetInactive(index uint, inactive bool) error
+	SaveReorg(Reorg) error
+	SaveSnapshot(BalanceSnapshot) error
+	Snapshots(fromEpoch, toEpoch uint64) ([]BalanceSnapshot, error)
+	LastSnapshotSlot() (uint64, error)
+	SaveProposal(Proposal) error
+	Proposals(fromEpoch, toEpoch uint64) ([]Proposal, error)
+	SaveWithdrawal(Withdrawal) error
+	Withdrawals(fromEpoch, toEpoch uint64) ([]Withdrawal, error)
+	SaveAttestation(Attestation) error
+	Attestations(fromEpoch, toEpoch uint64) ([]Attestation, error)
+	SetEffectiveness(index uint, effectiveness float64) error
 	Migrate() error
 }
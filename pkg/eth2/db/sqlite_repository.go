@@ -9,6 +9,31 @@ type ValidatorORM struct {
 	Validator
 }
 
+type ReorgORM struct {
+	gorm.Model
+	Reorg
+}
+
+type BalanceSnapshotORM struct {
+	gorm.Model
+	BalanceSnapshot
+}
+
+type ProposalORM struct {
+	gorm.Model
+	Proposal
+}
+
+type WithdrawalORM struct {
+	gorm.Model
+	Withdrawal
+}
+
+type AttestationORM struct {
+	gorm.Model
+	Attestation
+}
+
 type SQLiteRepository struct {
 	DB *gorm.DB
 }
@@ -34,8 +59,11 @@ func (r *SQLiteRepository) Update(v Validator) error {
 	}
 
 	m.Balance = v.Balance
+	m.EffectiveBalance = v.EffectiveBalance
 	m.MissedAtts = v.MissedAtts
 	m.MissedAttsTotal = v.MissedAttsTotal
+	m.Group = v.Group
+	m.Labels = v.Labels
 
 	return r.DB.Save(&m).Error
 }
@@ -48,6 +76,133 @@ func (r *SQLiteRepository) Validator(index uint) (Validator, error) {
 	return m.Validator, nil
 }
 
+func (r *SQLiteRepository) SetInactive(index uint, inactive bool) error {
+	return r.DB.Model(&ValidatorORM{}).Where("idx = ?", index).Update("inactive", inactive).Error
+}
+
+func (r *SQLiteRepository) SetEffectiveness(index uint, effectiveness float64) error {
+	return r.DB.Model(&ValidatorORM{}).Where("idx = ?", index).Update("effectiveness", effectiveness).Error
+}
+
+func (r *SQLiteRepository) SaveReorg(reorg Reorg) error {
+	return r.DB.Create(&ReorgORM{Reorg: reorg}).Error
+}
+
+func (r *SQLiteRepository) SaveSnapshot(s BalanceSnapshot) error {
+	var m BalanceSnapshotORM
+	if err := r.DB.Where("idx = ? AND epoch = ?", s.Idx, s.Epoch).First(&m).Session(&gorm.Session{}).Error; err != nil {
+		if err != gorm.ErrRecordNotFound {
+			return err
+		}
+		return r.DB.Create(&BalanceSnapshotORM{BalanceSnapshot: s}).Error
+	}
+
+	// A checkpoint seen again, e.g. after a restart, replaces the previous snapshot
+	m.BalanceSnapshot = s
+	return r.DB.Save(&m).Error
+}
+
+func (r *SQLiteRepository) Snapshots(fromEpoch, toEpoch uint64) ([]BalanceSnapshot, error) {
+	var ms []BalanceSnapshotORM
+	if err := r.DB.Where("epoch BETWEEN ? AND ?", fromEpoch, toEpoch).Order("idx, epoch").Find(&ms).Error; err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]BalanceSnapshot, len(ms))
+	for i, m := range ms {
+		snapshots[i] = m.BalanceSnapshot
+	}
+	return snapshots, nil
+}
+
+func (r *SQLiteRepository) LastSnapshotSlot() (uint64, error) {
+	var slot uint64
+	if err := r.DB.Model(&BalanceSnapshotORM{}).Select("COALESCE(MAX(slot), 0)").Scan(&slot).Error; err != nil {
+		return 0, err
+	}
+	return slot, nil
+}
+
+func (r *SQLiteRepository) SaveProposal(p Proposal) error {
+	var m ProposalORM
+	if err := r.DB.Where("slot = ?", p.Slot).First(&m).Session(&gorm.Session{}).Error; err != nil {
+		if err != gorm.ErrRecordNotFound {
+			return err
+		}
+		return r.DB.Create(&ProposalORM{Proposal: p}).Error
+	}
+
+	m.Proposal = p
+	return r.DB.Save(&m).Error
+}
+
+func (r *SQLiteRepository) Proposals(fromEpoch, toEpoch uint64) ([]Proposal, error) {
+	var ms []ProposalORM
+	if err := r.DB.Where("epoch BETWEEN ? AND ?", fromEpoch, toEpoch).Order("slot").Find(&ms).Error; err != nil {
+		return nil, err
+	}
+
+	proposals := make([]Proposal, len(ms))
+	for i, m := range ms {
+		proposals[i] = m.Proposal
+	}
+	return proposals, nil
+}
+
+func (r *SQLiteRepository) SaveWithdrawal(w Withdrawal) error {
+	var m WithdrawalORM
+	if err := r.DB.Where("\"index\" = ?", w.Index).First(&m).Session(&gorm.Session{}).Error; err != nil {
+		if err != gorm.ErrRecordNotFound {
+			return err
+		}
+		return r.DB.Create(&WithdrawalORM{Withdrawal: w}).Error
+	}
+
+	// A block scanned again, e.g. after a restart, replaces the previous withdrawal
+	m.Withdrawal = w
+	return r.DB.Save(&m).Error
+}
+
+func (r *SQLiteRepository) Withdrawals(fromEpoch, toEpoch uint64) ([]Withdrawal, error) {
+	var ms []WithdrawalORM
+	if err := r.DB.Where("epoch BETWEEN ? AND ?", fromEpoch, toEpoch).Order("\"index\"").Find(&ms).Error; err != nil {
+		return nil, err
+	}
+
+	withdrawals := make([]Withdrawal, len(ms))
+	for i, m := range ms {
+		withdrawals[i] = m.Withdrawal
+	}
+	return withdrawals, nil
+}
+
+func (r *SQLiteRepository) SaveAttestation(a Attestation) error {
+	var m AttestationORM
+	if err := r.DB.Where("idx = ? AND epoch = ?", a.Idx, a.Epoch).First(&m).Session(&gorm.Session{}).Error; err != nil {
+		if err != gorm.ErrRecordNotFound {
+			return err
+		}
+		return r.DB.Create(&AttestationORM{Attestation: a}).Error
+	}
+
+	// An epoch checked again, e.g. after a restart, replaces the previous attestation
+	m.Attestation = a
+	return r.DB.Save(&m).Error
+}
+
+func (r *SQLiteRepository) Attestations(fromEpoch, toEpoch uint64) ([]Attestation, error) {
+	var ms []AttestationORM
+	if err := r.DB.Where("epoch BETWEEN ? AND ?", fromEpoch, toEpoch).Order("idx, epoch").Find(&ms).Error; err != nil {
+		return nil, err
+	}
+
+	attestations := make([]Attestation, len(ms))
+	for i, m := range ms {
+		attestations[i] = m.Attestation
+	}
+	return attestations, nil
+}
+
 func (r *SQLiteRepository) Migrate() error {
-	return r.DB.AutoMigrate(&ValidatorORM{})
+	return r.DB.AutoMigrate(&ValidatorORM{}, &ReorgORM{}, &BalanceSnapshotORM{}, &ProposalORM{}, &WithdrawalORM{}, &AttestationORM{})
 }
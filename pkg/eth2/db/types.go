@@ -1,8 +1,103 @@
 package db
 
 type Validator struct {
-	Idx             uint
-	Balance         uint64
-	MissedAtts      uint
-	MissedAttsTotal uint
+	Idx     uint
+	Balance uint64
+	// Effective balance in gwei, zero if unknown
+	EffectiveBalance uint64
+	MissedAtts       uint
+	MissedAttsTotal  uint
+	// Validator group name, empty if ungrouped
+	Group string
+	// Group labels as sorted 'key=value' pairs separated by commas
+	Labels string
+	// True if the validator was removed from the configuration. Its history is kept
+	Inactive bool
+	// Mean attestation effectiveness over the last epochs, from 0 to 1
+	Effectiveness float64
+}
+
+type Reorg struct {
+	// Slot of the new head
+	Slot         uint64
+	Depth        uint64
+	OldHeadBlock string
+	NewHeadBlock string
+	OldHeadState string
+	NewHeadState string
+	// Comma separated slots whose blocks were removed from the canonical chain
+	AffectedSlots string
+	// Comma separated indexes of our validators whose proposals were removed from the canonical chain
+	OrphanedProposals string
+}
+
+// Balance of a validator at a finalized checkpoint
+type BalanceSnapshot struct {
+	Idx   uint
+	Epoch uint64
+	// Balance in gwei
+	Balance uint64
+	// True if the balance, with withdrawals added back, decreased since the previous checkpoint
+	Missed bool
+	// Gwei withdrawn since the previous checkpoint
+	Withdrawn uint64
+	// Effective balance in gwei, zero if unknown
+	EffectiveBalance uint64
+	// Slot the balance was read at, up to which withdrawals were scanned. Zero if read at head without a scan
+	Slot uint64
+}
+
+// Block proposal duty of a validator
+type Proposal struct {
+	Idx   uint
+	Slot  uint64
+	Epoch uint64
+	// True if no block was found at the slot
+	Missed bool
+	// Execution layer rewards of the block in gwei: priority fees and MEV payments to the fee recipient
+	ExecutionFees uint64
+	MEV           uint64
+	// Address the execution layer rewards were paid to. Empty if missed or unknown
+	FeeRecipient string
+	// Relays that delivered the payload, separated by commas. Empty if locally built or not checked
+	Relay string
+	// Highest bid value of the relays in gwei
+	BidValue uint64
+	// Consensus rewards in gwei an ideal validator would have earned with the block. Only set if missed
+	MissedReward uint64
+}
+
+// Withdrawal of a validator balance, from the execution payload of a block
+type Withdrawal struct {
+	// Withdrawal index, unique across the chain
+	Index uint64
+	Idx   uint
+	Slot  uint64
+	Epoch uint64
+	// Withdrawal address
+	Address string
+	// Amount in gwei
+	Amount uint64
+}
+
+// Attestation duty of a validator and how it was included on chain
+type Attestation struct {
+	Idx uint
+	// Target epoch and slot of the attestation
+	Epoch uint64
+	Slot  uint64
+	// True if no block included the attestation
+	Missed bool
+	// Slot of the first block including the attestation, and distance to the attestation slot
+	InclusionSlot     uint64
+	InclusionDistance uint64
+	// True if the attestation voted for the canonical head, target and source
+	CorrectHead   bool
+	CorrectTarget bool
+	CorrectSource bool
+	// Earliest possible inclusion distance over the actual one, from 0 if missed to 1
+	Effectiveness float64
+	// Reward in gwei of an ideal attestation, and rewards lost against it with penalties. Zero if the effective balance was unknown
+	IdealReward  uint64
+	MissedReward uint64
 }
@@ -0,0 +1,232 @@
+package eth2
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/NethermindEth/posmoni/configs"
+	log "github.com/sirupsen/logrus"
+)
+
+// doppelgangerDelay : Share of the next epoch waited for before checking an epoch, so most attestations of the epoch are included in blocks
+const doppelgangerDelay = 2
+
+/*
+CheckDoppelganger :
+Watch the monitored validators for a number of epochs, to find out if they are active elsewhere before a validator client is started with their keys. Only full epochs are watched, starting from the next one. Each epoch is checked halfway through the next one, with the liveness reported by the consensus node and the attestations of the validators included in blocks. Nodes only report liveness for recent epochs, so the check can't be done afterwards. The check stops at the first epoch with live validators.
+
+params :-
+a. epochs uint64
+Epochs to watch, at least one
+b. clock *Clock
+Clock of the network
+
+returns :-
+a. DoppelgangerReport
+Validators seen live, and the errors that made the check incomplete
+*/
+func (e *eth2Monitor) CheckDoppelganger(epochs uint64, clock *Clock) DoppelgangerReport {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "CheckDoppelganger"}
+	if epochs == 0 {
+		epochs = 1
+	}
+	from := clock.EpochAt(time.Now()) + 1
+	report := DoppelgangerReport{FromEpoch: from, ToEpoch: from + epochs - 1, Live: make([]LiveValidator, 0)}
+
+	idxs, err := e.doppelgangerIndexes()
+	if err != nil {
+		report.Errors = append(report.Errors, err)
+		return report
+	}
+	report.Validators = idxs
+
+	for epoch := from; epoch <= report.ToEpoch; epoch++ {
+		at := clock.EpochStart(epoch + 1).Add(clock.EpochDuration() / doppelgangerDelay)
+		log.WithFields(logFields).Infof("Watching %d validators in epoch %d, checking at %s", len(idxs), epoch, at.Format(time.RFC3339))
+		time.Sleep(time.Until(at))
+
+		live, err := e.checkDoppelgangerEpoch(epoch, idxs, clock.SlotsPerEpoch(), clock.SlotAt(time.Now()))
+		if err != nil {
+			// Later epochs are still watched, the check fails anyway
+			log.WithFields(logFields).Error(err)
+			report.Errors = append(report.Errors, err)
+			continue
+		}
+		if len(live) > 0 {
+			report.ToEpoch = epoch
+			report.Live = live
+			return report
+		}
+		log.WithFields(logFields).Infof("No validator was live in epoch %d", epoch)
+	}
+	return report
+}
+
+/*
+doppelgangerIndexes :
+Get the indexes of the monitored validators. Validators unknown to the consensus node are skipped, since they can't be active.
+
+params :-
+none
+
+returns :-
+a. []string
+Validator indexes
+b. error
+Error if the validators could not be resolved or none is known
+*/
+func (e *eth2Monitor) doppelgangerIndexes() ([]string, error) {
+	infos, err := e.beaconClient.Validators("head", e.validators.List())
+	if err != nil {
+		return nil, fmt.Errorf(ValidatorIndexesError, err)
+	}
+	if len(infos) == 0 {
+		return nil, fmt.Errorf(NoKnownValidatorsError)
+	}
+
+	idxs := make([]string, 0, len(infos))
+	for _, info := range infos {
+		idxs = append(idxs, info.Index)
+	}
+	return idxs, nil
+}
+
+/*
+checkDoppelgangerEpoch :
+Find the validators active in an epoch, either reported live by the consensus node or with attestations included in blocks.
+
+params :-
+a. epoch uint64
+Epoch to check
+b. idxs []string
+Validator indexes
+c. slotsPerEpoch uint64
+Slots of an epoch
+d. head uint64
+Current slot. Blocks are scanned up to the slot before it, or the end of the next epoch
+
+returns :-
+a. []LiveValidator
+Validators active in the epoch, sorted by index
+b. error
+Error if liveness, committees or blocks could not be fetched
+*/
+func (e *eth2Monitor) checkDoppelgangerEpoch(epoch uint64, idxs []string, slotsPerEpoch, head uint64) ([]LiveValidator, error) {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "checkDoppelgangerEpoch"}
+
+	liveness, err := e.beaconClient.Liveness(strconv.FormatUint(epoch, 10), idxs)
+	if err != nil {
+		return nil, fmt.Errorf(LivenessError, epoch, err)
+	}
+	live := make(map[string]*LiveValidator)
+	for _, l := range liveness {
+		if l.IsLive {
+			live[l.Index] = &LiveValidator{Validator: l.Index, Epoch: epoch, Liveness: true}
+		}
+	}
+
+	committees, err := e.beaconClient.Committees("head", strconv.FormatUint(epoch, 10))
+	if err != nil {
+		return nil, fmt.Errorf(CommitteesError, epoch, err)
+	}
+	watched := make(map[string]bool, len(idxs))
+	for _, idx := range idxs {
+		watched[idx] = true
+	}
+
+	// Seats of the validators, by slot and committee index
+	sizes := make(map[uint64]map[uint64]int)
+	seats := make(map[uint64]map[uint64]map[int]string)
+	var start, end uint64 = math.MaxUint64, 0
+	for _, c := range committees {
+		slot, err := strconv.ParseUint(c.Slot, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf(CommitteesError, epoch, err)
+		}
+		index, err := strconv.ParseUint(c.Index, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf(CommitteesError, epoch, err)
+		}
+		if slot < start {
+			start = slot
+		}
+		if slot > end {
+			end = slot
+		}
+		if sizes[slot] == nil {
+			sizes[slot] = make(map[uint64]int)
+			seats[slot] = make(map[uint64]map[int]string)
+		}
+		sizes[slot][index] = len(c.Validators)
+		for pos, v := range c.Validators {
+			if !watched[v] {
+				continue
+			}
+			if seats[slot][index] == nil {
+				seats[slot][index] = make(map[int]string)
+			}
+			seats[slot][index][pos] = v
+		}
+	}
+	if len(sizes) == 0 {
+		return nil, fmt.Errorf(NoCommitteesError, epoch)
+	}
+
+	last := end + slotsPerEpoch
+	if head > 0 && head-1 < last {
+		last = head - 1
+	}
+	blocks, err := e.scanBlocks(start+1, last)
+	if err != nil {
+		return nil, fmt.Errorf(DoppelgangerBlocksError, epoch, err)
+	}
+
+	for _, b := range blocks {
+		for _, att := range b.block.Body.Attestations {
+			slot, err := strconv.ParseUint(att.Data.Slot, 10, 64)
+			if err != nil || seats[slot] == nil {
+				continue
+			}
+			bits, err := decodeBits(att.AggregationBits)
+			if err != nil {
+				log.WithFields(logFields).Errorf(AttestationBitsError, b.slot, err)
+				continue
+			}
+			committeeIdxs, err := attestationCommittees(att)
+			if err != nil {
+				log.WithFields(logFields).Errorf(AttestationBitsError, b.slot, err)
+				continue
+			}
+
+			offset := 0
+			for _, c := range committeeIdxs {
+				for pos, v := range seats[slot][c] {
+					if !bitSet(bits, offset+pos) {
+						continue
+					}
+					if live[v] == nil {
+						live[v] = &LiveValidator{Validator: v, Epoch: epoch}
+					}
+					if live[v].InclusionSlot == 0 {
+						live[v].InclusionSlot = b.slot
+					}
+				}
+				offset += sizes[slot][c]
+			}
+		}
+	}
+
+	found := make([]LiveValidator, 0, len(live))
+	for _, l := range live {
+		found = append(found, *l)
+	}
+	sort.Slice(found, func(i, j int) bool {
+		a, _ := strconv.ParseUint(found[i].Validator, 10, 64)
+		b, _ := strconv.ParseUint(found[j].Validator, 10, 64)
+		return a < b
+	})
+	return found, nil
+}
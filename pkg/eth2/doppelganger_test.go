@@ -0,0 +1,153 @@
+package eth2
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/posmoni/internal/utils"
+	net "github.com/NethermindEth/posmoni/pkg/eth2/networking"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoppelgangerIndexes(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name       string
+		validators []string
+		registry   []net.ValidatorInfo
+		want       []string
+		isError    bool
+	}{
+		{
+			"Test case 1, consensus node unavailable",
+			[]string{"1"},
+			nil,
+			nil,
+			true,
+		},
+		{
+			"Test case 2, public keys resolved, unknown validators skipped",
+			[]string{"1", "0xaa", "0xbb"},
+			[]net.ValidatorInfo{{Index: "1"}, {Index: "7", Validator: net.ValidatorData{Pubkey: "0xaa"}}},
+			[]string{"1", "7"},
+			false,
+		},
+		{
+			"Test case 3, no known validator",
+			[]string{"0xbb"},
+			[]net.ValidatorInfo{{Index: "1"}},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			vs, err := newValidatorSet(tc.validators, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			monitor := &eth2Monitor{beaconClient: &TestBeaconClient{registry: tc.registry}, validators: vs}
+
+			got, err := monitor.doppelgangerIndexes()
+			if err := utils.CheckErr("doppelgangerIndexes()", tc.isError, err); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestCheckDoppelgangerEpoch(t *testing.T) {
+	t.Parallel()
+
+	// Epochs of 4 slots, epoch 2 is checked
+	committees := map[string][]net.Committee{"2": {
+		{Slot: "8", Index: "0", Validators: []string{"1", "2"}},
+		{Slot: "9", Index: "0", Validators: []string{"3", "4"}},
+		{Slot: "10", Index: "0", Validators: []string{"5", "6"}},
+		{Slot: "11", Index: "0", Validators: []string{"7", "8"}},
+	}}
+	blocks := map[string]map[string]net.BeaconBlock{"cl1": {
+		"10": {Slot: "10", Body: net.BeaconBlockBody{Attestations: []net.Attestation{
+			// Validator 4 attested
+			{AggregationBits: "0x06", Data: net.AttestationData{Slot: "9", Index: "0"}},
+		}}},
+		"12": {Slot: "12", Body: net.BeaconBlockBody{Attestations: []net.Attestation{
+			// Validators 4 and 6 attested, 4 already found
+			{AggregationBits: "0x06", Data: net.AttestationData{Slot: "9", Index: "0"}},
+			{AggregationBits: "0x06", Data: net.AttestationData{Slot: "10", Index: "0"}},
+			// Not watched
+			{AggregationBits: "0x07", Data: net.AttestationData{Slot: "11", Index: "0"}},
+		}}},
+	}}
+
+	tcs := []struct {
+		name     string
+		liveness map[string][]net.ValidatorLiveness
+		blocks   map[string]map[string]net.BeaconBlock
+		head     uint64
+		want     []LiveValidator
+		isError  bool
+	}{
+		{
+			"Test case 1, liveness unavailable",
+			nil,
+			blocks,
+			14,
+			nil,
+			true,
+		},
+		{
+			"Test case 2, no validator live",
+			map[string][]net.ValidatorLiveness{"2": {{Index: "2"}, {Index: "4"}, {Index: "6"}}},
+			map[string]map[string]net.BeaconBlock{"cl1": {}},
+			14,
+			[]LiveValidator{},
+			false,
+		},
+		{
+			"Test case 3, live by liveness and by attestations",
+			map[string][]net.ValidatorLiveness{"2": {{Index: "2", IsLive: true}, {Index: "4", IsLive: true}, {Index: "6"}}},
+			blocks,
+			14,
+			[]LiveValidator{
+				{Validator: "2", Epoch: 2, Liveness: true},
+				{Validator: "4", Epoch: 2, Liveness: true, InclusionSlot: 10},
+				{Validator: "6", Epoch: 2, InclusionSlot: 12},
+			},
+			false,
+		},
+		{
+			"Test case 4, blocks after the head not scanned",
+			map[string][]net.ValidatorLiveness{"2": {}},
+			blocks,
+			12,
+			[]LiveValidator{{Validator: "4", Epoch: 2, InclusionSlot: 10}},
+			false,
+		},
+		{
+			"Test case 5, blocks unavailable",
+			map[string][]net.ValidatorLiveness{"2": {}},
+			map[string]map[string]net.BeaconBlock{},
+			14,
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			monitor := &eth2Monitor{
+				beaconClient: &TestBeaconClient{committees: committees, blocks: tc.blocks, liveness: tc.liveness},
+				config:       eth2Config{consensus: []string{"cl1"}},
+			}
+
+			got, err := monitor.checkDoppelgangerEpoch(2, []string{"2", "4", "6"}, 4, tc.head)
+			if err := utils.CheckErr("checkDoppelgangerEpoch()", tc.isError, err); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package eth2
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/NethermindEth/posmoni/configs"
+	"github.com/NethermindEth/posmoni/pkg/eth2/alerts"
+	net "github.com/NethermindEth/posmoni/pkg/eth2/networking"
+	log "github.com/sirupsen/logrus"
+)
+
+// Effective balance rules of the consensus specs, in gwei
+const (
+	effectiveBalanceIncrement = gweiPerEth
+	// Balance above the effective balance needed for the effective balance to rise
+	hysteresisUpward = effectiveBalanceIncrement * 5 / 4
+)
+
+// activeOngoing : Status of an active validator that is not exiting nor slashed
+const activeOngoing = "active_ongoing"
+
+/*
+effectiveBalances :
+Get the registry records of the monitored validators, with their effective balance and status.
+
+params :-
+a. stateID string
+Blockchain state ID from when to get the validators
+
+returns :-
+a. map[uint]net.ValidatorInfo
+Registry records by validator index. Nil if they could not be fetched
+*/
+func (e *eth2Monitor) effectiveBalances(stateID string) map[uint]net.ValidatorInfo {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "effectiveBalances"}
+
+	infos, err := e.beaconClient.Validators(stateID, e.validators.List())
+	if err != nil {
+		log.WithFields(logFields).Errorf(EffectiveBalancesError, err)
+		return nil
+	}
+
+	registry := make(map[uint]net.ValidatorInfo, len(infos))
+	for _, info := range infos {
+		idx, err := parseUint(info.Index)
+		if err != nil {
+			log.WithFields(logFields).Errorf(ParseUintError, err)
+			continue
+		}
+		registry[idx] = info
+	}
+	return registry
+}
+
+/*
+checkEffectiveBalance :
+Alert if the effective balance of an active validator is below the minimum of its group or the configured one, since rewards are proportional to it. A low effective balance is alerted again only if it drops further, and its recovery is alerted too.
+
+params :-
+a. idx uint
+Validator index
+b. status string
+Validator status. Only validators active, not exiting nor slashed, are checked
+c. balance uint64
+Validator balance in gwei
+d. effective uint64
+Validator effective balance in gwei
+
+returns :-
+none
+*/
+func (e *eth2Monitor) checkEffectiveBalance(idx uint, status string, balance, effective uint64) {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "checkEffectiveBalance"}
+	validator := strconv.FormatUint(uint64(idx), 10)
+	minimum := e.minEffectiveBalance(validator)
+	if minimum == 0 || status != activeOngoing {
+		return
+	}
+	if e.lowEffectiveBalances == nil {
+		e.lowEffectiveBalances = make(map[uint]uint64)
+	}
+
+	labels := map[string]string{"validator": validator, "effective_balance": strconv.FormatUint(effective, 10)}
+	alerted, low := e.lowEffectiveBalances[idx]
+	var alert alerts.Alert
+	switch {
+	case effective >= minimum:
+		if !low {
+			return
+		}
+		delete(e.lowEffectiveBalances, idx)
+		alert = alerts.Alert{Severity: alerts.Info, Message: fmt.Sprintf(EffectiveBalanceRestoredMsg, idx, formatGwei(int64(effective)))}
+	case !low || effective < alerted:
+		e.lowEffectiveBalances[idx] = effective
+		topUp := topUpAmount(balance, effective, minimum)
+		labels["balance"] = strconv.FormatUint(balance, 10)
+		labels["top_up"] = strconv.FormatUint(topUp, 10)
+		alert = alerts.Alert{
+			Severity: alerts.Warning,
+			Message: fmt.Sprintf(LowEffectiveBalanceMsg, idx, formatGwei(int64(effective)), formatGwei(int64(minimum)),
+				formatGwei(int64(balance)), formatGwei(int64(topUp))),
+		}
+	default:
+		return
+	}
+
+	alert.Kind = EffectiveBalanceAlert
+	alert.Source = validator
+	alert.Labels = e.groupLabels(labels, validator)
+	alert.Time = time.Now()
+	if err := e.alerter.Send(alert); err != nil {
+		log.WithFields(logFields).Errorf(SendAlertError, err)
+	}
+}
+
+/*
+topUpAmount :
+Get the gwei to deposit for the effective balance of a validator to reach a target. The effective balance only rises once the balance is 1.25 ether above it, and then it is set to the balance rounded down to whole ether.
+
+params :-
+a. balance uint64
+Validator balance in gwei
+b. effective uint64
+Validator effective balance in gwei
+c. target uint64
+Target effective balance in gwei
+
+returns :-
+a. uint64
+Gwei to deposit. Zero if the balance is already enough at the next effective balance update
+*/
+func topUpAmount(balance, effective, target uint64) uint64 {
+	needed := target
+	if up := effective + hysteresisUpward + 1; up > needed {
+		needed = up
+	}
+	if balance >= needed {
+		return 0
+	}
+	return needed - balance
+}
@@ -0,0 +1,78 @@
+package eth2
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/posmoni/pkg/eth2/alerts"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopUpAmount(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name      string
+		balance   uint64
+		effective uint64
+		target    uint64
+		want      uint64
+	}{
+		{"Test case 1, effective balance one ether below, upward hysteresis needed", 31100000000, 31000000000, 32000000000, 1150000001},
+		{"Test case 2, effective balance two ether below, target needed", 30500000000, 30000000000, 32000000000, 1500000000},
+		{"Test case 3, balance already enough", 32300000000, 31000000000, 32000000000, 0},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, topUpAmount(tc.balance, tc.effective, tc.target))
+		})
+	}
+}
+
+func TestCheckEffectiveBalance(t *testing.T) {
+	t.Parallel()
+
+	vs, err := newValidatorSet([]string{"1", "2"}, []ValidatorGroup{{Name: "acme", Validators: []string{"1"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	am := &alerterMock{}
+	monitor := eth2Monitor{
+		validators: vs,
+		settings:   monitorSettings{minEffectiveBalance: 32000000000},
+		alerter:    am,
+	}
+
+	// At the minimum, or not active
+	monitor.checkEffectiveBalance(1, activeOngoing, 32100000000, 32000000000)
+	monitor.checkEffectiveBalance(2, "active_exiting", 30000000000, 30000000000)
+	assert.Empty(t, am.all())
+
+	// Below the minimum, alerted once until it drops further
+	monitor.checkEffectiveBalance(1, activeOngoing, 31700000000, 31000000000)
+	monitor.checkEffectiveBalance(1, activeOngoing, 31800000000, 31000000000)
+	monitor.checkEffectiveBalance(1, activeOngoing, 30700000000, 30000000000)
+	sent := am.all()
+	if assert.Len(t, sent, 2) {
+		assert.Equal(t, EffectiveBalanceAlert, sent[0].Kind)
+		assert.Equal(t, alerts.Warning, sent[0].Severity)
+		assert.Equal(t, "1", sent[0].Source)
+		assert.Equal(t, map[string]string{
+			"validator": "1", "effective_balance": "31000000000", "balance": "31700000000", "top_up": "550000001", "group": "acme",
+		}, sent[0].Labels)
+		assert.Equal(t, "30000000000", sent[1].Labels["effective_balance"])
+	}
+
+	// Recovered
+	monitor.checkEffectiveBalance(1, activeOngoing, 32300000000, 32000000000)
+	monitor.checkEffectiveBalance(1, activeOngoing, 32300000000, 32000000000)
+	sent = am.all()
+	if assert.Len(t, sent, 3) {
+		assert.Equal(t, alerts.Info, sent[2].Severity)
+	}
+
+	// Disabled
+	monitor.settings.minEffectiveBalance = 0
+	monitor.checkEffectiveBalance(2, activeOngoing, 30000000000, 30000000000)
+	assert.Len(t, am.all(), 3)
+}
@@ -1,15 +1,112 @@
 package eth2
 
 const (
-	NoValidatorsFoundError  = "no validator address or public index was found. Please check your configuration settings (file, enviroment variables, etc.)"
-	NoConsensusFoundError   = "no consensus client endpoint was found. Please check your configuration settings (file, enviroment variables, etc.)"
-	NoExecutionFoundError   = "no execution client endpoint was found. Please check your configuration settings (file, enviroment variables, etc.)"
-	ValidatorBalancesError  = "something went wrong while fetching validator balances. Skiping current checkpoint. Error: %v"
-	SQLiteCreationError     = "sqlite creation failed. Error %v"
-	ParseUintError          = "something went wrong while parsing uint. Skiping current validator. Error: %v"
-	ValidatorNotFoundError  = "validator not found. Skiping current validator. Error: %v"
-	MigrationError          = "failed to migrate database. Error: %v"
-	SetupError              = "an error occurred while configurating the monitor. Error: %v"
-	CheckingSyncStatusError = "got error while checking sync status of endpoint %s. Error: %v"
-	InvalidConfigKeyError   = "invalid configuration key %s. Valid keys values are %v"
+	NoValidatorsFoundError   = "no validator address or public index was found. Please check your configuration settings (file, enviroment variables, etc.)"
+	NoConsensusFoundError    = "no consensus client endpoint was found. Please check your configuration settings (file, enviroment variables, etc.)"
+	NoExecutionFoundError    = "no execution client endpoint was found. Please check your configuration settings (file, enviroment variables, etc.)"
+	ValidatorBalancesError   = "something went wrong while fetching validator balances. Skiping current checkpoint. Error: %v"
+	SQLiteCreationError      = "sqlite creation failed. Error %v"
+	ParseUintError           = "something went wrong while parsing uint. Skiping current validator. Error: %v"
+	ValidatorNotFoundError   = "validator not found. Skiping current validator. Error: %v"
+	MigrationError           = "failed to migrate database. Error: %v"
+	SetupError               = "an error occurred while configurating the monitor. Error: %v"
+	CheckingSyncStatusError  = "got error while checking sync status of endpoint %s. Error: %v"
+	InvalidConfigKeyError    = "invalid configuration key %s. Valid keys values are %v"
+	SendAlertError           = "failed to send alert. Error: %v"
+	PairingSyncStatusError   = "could not get sync status of %s"
+	NoExecutionPayloadError  = "head block of %s has no execution payload"
+	PairingCheckError        = "could not check pairing of %s. Error: %v"
+	ParseEventError          = "could not parse %s event from %s. Error: %v"
+	OrphanedBlockError       = "could not get orphaned block %s from %s. Error: %v"
+	SaveReorgError           = "failed to save chain reorg at slot %d. Error: %v"
+	InvalidSpecError         = "invalid chain spec. Seconds per slot: %d, slots per epoch: %d"
+	UnknownNetworkError      = "unknown network %s. Valid networks are %v"
+	NetworkMismatchError     = "endpoint %s has genesis time %s, but network %s has genesis time %d. Please check your network setting"
+	NodeClockError           = "could not get genesis and spec from %s. Error: %v"
+	NoClockError             = "could not get genesis and spec from any consensus endpoint %v"
+	DuplicatedValidatorError = "validator %s is configured more than once"
+	GroupNameError           = "validator group %v has no name"
+	EmptyGroupError          = "validator group %s has no validators"
+	ValidatorSourceError     = "could not read validators from %s. Error: %v"
+	ValidatorFileError       = "invalid validator in %s, line %d: %s"
+	InvalidRangeError        = "invalid validator index range %s"
+	ReloadValidatorsError    = "could not reload validators, keeping the current ones. Error: %v"
+	KeymanagerConfigError    = "invalid keymanager %v. Expected an URL or a map with url, token and token_file keys"
+	KeymanagerTokenError     = "could not read keymanager token of %s. Error: %v"
+	KeymanagerRequestError   = "could not get keys loaded in %s. Error: %v"
+	ResolveValidatorsError   = "could not get public keys of validators. Error: %v"
+	ReloadConfigError        = "could not reload configuration, keeping the current one. Error: %v"
+	SyncValidatorError       = "could not update validator %d in the repository. Error: %v"
+	UnknownConfigKeyError    = "unknown configuration key %s"
+	InvalidURLError          = "invalid %s URL %q. Expected an absolute http or https URL"
+	InvalidSettingError      = "invalid %s value %v"
+	InvalidSettingsError     = "invalid settings: %s"
+	SaveSnapshotError        = "failed to save balance of validator %d at epoch %d. Error: %v"
+	ProposerDutiesError      = "could not get block proposers of epoch %d. Error: %v"
+	ProposalBlockError       = "could not check block of validator %d at slot %d. Error: %v"
+	ExecutionRewardsError    = "could not get execution rewards of validator %d at slot %d. Error: %v"
+	SaveProposalError        = "failed to save proposal of validator %d at slot %d. Error: %v"
+	WithdrawalBlockError     = "could not get block at slot %s to check withdrawals. Error: %v"
+	SaveWithdrawalError      = "failed to save withdrawal %d of validator %d at slot %d. Error: %v"
+	LastScannedSlotError     = "could not get the last slot scanned for withdrawals. Error: %v"
+	InvalidReportBoundError  = "invalid report bound %q. Expected an epoch or a date in YYYY-MM-DD format"
+	ReportRangeError         = "report start epoch %d is after end epoch %d"
+	ReportFormatError        = "unknown report format %s. Valid formats are %v"
+	PriceFileError           = "invalid price in %s, line %d: %s. Expected a date in YYYY-MM-DD format and a price"
+	InvalidAddressError      = "invalid %s %s. Expected a 0x prefixed 20 bytes hex address"
+	FeeRecipientsError       = "could not get fee recipients registered in %s. Error: %v"
+	RelayPayloadsError       = "could not get payloads delivered by relay %s at slot %d. Error: %v"
+	BidValueError            = "invalid bid value %s delivered by relay %s at slot %d"
+	MevBoostStatusError      = "mev-boost %s is down. Error: %v"
+	EffectiveBalancesError   = "could not get effective balances of validators. Error: %v"
+	CommitteesError          = "could not get attester committees of epoch %d. Error: %v"
+	SlotBlockError           = "could not get block at slot %d. Error: %w"
+	AttestationBlockError    = "could not get blocks to score attestations of epoch %d. Error: %v"
+	AttestationBitsError     = "invalid bits in attestation included at slot %d. Error: %v"
+	SaveAttestationError     = "could not save attestation of validator %d at epoch %d. Error: %v"
+	LoadAttestationsError    = "could not get attestations from epoch %d to %d. Error: %v"
+	TotalActiveBalanceError  = "could not get the total active balance of the network. Error: %v"
+	FinalityClockError       = "could not get the clock of the network, finality and participation are not tracked. Error: %v"
+	FinalityCheckpointsError = "could not get finality checkpoints of the head state. Error: %v"
+	ParticipationError       = "could not get participation of epoch %d. Error: %v"
+	NoCommitteesError        = "no attester committees for epoch %d"
+	ValidatorIndexesError    = "could not get indexes of validators. Error: %v"
+	NoKnownValidatorsError   = "none of the configured validators is known to the consensus node"
+	LivenessError            = "could not get liveness of validators in epoch %d. Error: %v"
+	DoppelgangerBlocksError  = "could not get blocks to find attestations of epoch %d. Error: %v"
+	LowPeersWarning          = "endpoint %s has low peer count. Connected: %d, inbound: %d. Minimum connected: %d, minimum inbound: %d"
+)
+
+// Alert kinds and messages
+const (
+	NodeHealthAlert             = "node_health"
+	NodeStateTransitionMsg      = "%s node %s went from %s to %s (for %v)"
+	PairingAlert                = "el_pairing"
+	PairingMismatchMsg          = "consensus node %s is not following any configured execution node. Head payload: %d (%s), el_offline: %v"
+	PairingTransitionMsg        = "consensus node %s pairing went from %s to %s. Head payload: %d (%s), matching execution nodes: [%s]"
+	ReorgAlert                  = "chain_reorg"
+	ReorgMsg                    = "chain reorg of depth %d at slot %d seen by %s. Old head: %s, new head: %s"
+	OrphanedProposalAlert       = "orphaned_proposal"
+	OrphanedProposalMsg         = "block %s proposed by validator %d at slot %d was orphaned by a chain reorg"
+	MissedAttestationAlert      = "missed_attestation"
+	MissedAttestationMsg        = "validator %d missed %d attestations in a row"
+	KeyDriftAlert               = "key_drift"
+	KeyDriftMsg                 = "key %s of validator %s went from %s to %s (for %v). Validator clients: [%s]"
+	FeeRecipientAlert           = "fee_recipient"
+	FeeRecipientProposalMsg     = "block proposed by validator %d at slot %d paid %s instead of the expected fee recipient %s"
+	FeeRecipientRegistrationMsg = "validator %s is registered in consensus node %s with fee recipient %s instead of %s"
+	BuilderAlert                = "block_builder"
+	LocalBlockMsg               = "block proposed by validator %d at slot %d was built locally, no relay of [%s] delivered it"
+	BidValueMsg                 = "block proposed by validator %d at slot %d paid %d gwei, below the bid of %d gwei delivered by %s"
+	MevBoostAlert               = "mev_boost"
+	MevBoostTransitionMsg       = "mev-boost %s went from %s to %s (for %v)"
+	EffectiveBalanceAlert       = "effective_balance"
+	LowEffectiveBalanceMsg      = "effective balance of validator %d dropped to %s ETH, below %s ETH. Balance: %s ETH, a top-up of %s ETH restores it"
+	EffectiveBalanceRestoredMsg = "effective balance of validator %d is back to %s ETH"
+	MissedRewardsAlert          = "missed_rewards"
+	MissedRewardsMsg            = "attestations of epoch %d lost %s ETH against ideal rewards of %s ETH (%.1f%%). Validators with losses: %d"
+	GroupMissedRewardsMsg       = "group %s: %s"
+	NetworkHealthAlert          = "network_health"
+	NetworkTransitionMsg        = "network went from %s to %s (for %v). Epochs since finality: %d, participation: %s"
+	NetworkWideMsg              = ". Network-wide: the whole network is in %s state"
 )
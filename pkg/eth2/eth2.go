@@ -3,15 +3,18 @@ package eth2
 import (
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/NethermindEth/posmoni/configs"
+	"github.com/NethermindEth/posmoni/pkg/eth2/alerts"
 	"github.com/NethermindEth/posmoni/pkg/eth2/db"
 	net "github.com/NethermindEth/posmoni/pkg/eth2/networking"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 
 	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
 )
 
 // Middleware for ETH2 validators monitoring
@@ -22,10 +25,52 @@ type eth2Monitor struct {
 	beaconClient net.BeaconAPI
 	// Interface for ETH1 json-rpc API interaction
 	executionClient net.ExecutionAPI
+	// Interface for validator clients keymanager API interaction. Set up on demand when keymanagers are configured
+	keymanagerClient net.KeymanagerAPI
+	// Interface for MEV-boost relays and mev-boost interaction. Set up on demand when relays or mev-boost are configured
+	relayClient net.RelayAPI
 	// Configuration options for events subscriber
 	subscriberOpts net.SubscribeOpts
+	// Configuration options for head and chain reorg events subscriber. Reorgs are not tracked if it has no subscriber
+	eventOpts net.EventSubscribeOpts
 	// Configuration data for eth2Monitor
 	config eth2Config
+	// Validators to monitor, expanded from their sources
+	validators *validatorSet
+	// Thresholds used by the checks of eth2Monitor
+	settings monitorSettings
+	// Interface for alerts delivery
+	alerter alerts.Alerter
+	// True if alerter was built from the settings, so it is rebuilt when they change
+	settingsAlerter bool
+	// Options the monitor was set up with, used again on reloads
+	opts ConfigOpts
+	// Serializes reloads and guards running
+	reloadMu sync.Mutex
+	// Monitoring goroutines. Nil before Monitor is called
+	running *pipelines
+	// Time between checks of validator files for changes
+	sourcesInterval time.Duration
+	// Last slot scanned for withdrawals. Loaded from the last balance snapshot on the first balance check, so scans go on after a restart
+	withdrawalSlot uint64
+	// Wrong fee recipients alerted by consensus endpoint and validator, so they are alerted once
+	feeRecipientMismatches map[string]string
+	// Fee recipients registered in the consensus nodes by validator index, to recognize builder payments
+	registeredFeeRecipients map[string]map[string]bool
+	// Consensus endpoints that don't expose fee recipient registrations, so they are not asked again
+	noRegistrations map[string]bool
+	// Effective balances alerted as low by validator index, so they are alerted again only if they drop further
+	lowEffectiveBalances map[uint]uint64
+	// Total active balance of the network in gwei and the epoch it was fetched at, to compute ideal rewards. Zero before it is first fetched
+	totalActiveBalance uint64
+	totalActiveEpoch   uint64
+	// Health of the whole network. Guarded by networkMu, since alerts about losses of the validators read it
+	networkMu    sync.Mutex
+	networkState NetworkState
+	// Participation of the last epoch checked, unknown until one is, and the next epoch to check
+	participation      float64
+	participationKnown bool
+	participationEpoch uint64
 }
 
 /*
@@ -45,19 +90,23 @@ Error if any
 func DefaultEth2Monitor(opts ConfigOpts) (*eth2Monitor, error) {
 	// notest
 	// Setup database
-	ormdb, err := gorm.Open(sqlite.Open("eth2_monitor.db"), &gorm.Config{})
+	repository, err := DefaultRepository()
 	if err != nil {
-		return nil, fmt.Errorf(SQLiteCreationError, err)
+		return nil, err
 	}
 
 	monitor := &eth2Monitor{
-		repository:      &db.SQLiteRepository{DB: ormdb},
+		repository:      repository,
 		beaconClient:    &net.BeaconClient{RetryDuration: time.Minute},
 		executionClient: &net.ExecutionClient{RetryDuration: time.Minute},
 		subscriberOpts: net.SubscribeOpts{
 			StreamURL:  net.FinalizedCkptTopic,
 			Subscriber: &net.SSESubscriber{},
 		},
+		eventOpts: net.EventSubscribeOpts{
+			StreamURL:  net.ReorgTopics,
+			Subscriber: &net.SSESubscriber{},
+		},
 	}
 
 	err = monitor.setup(opts)
@@ -68,6 +117,28 @@ func DefaultEth2Monitor(opts ConfigOpts) (*eth2Monitor, error) {
 	return monitor, nil
 }
 
+/*
+DefaultRepository :
+Open the sqlite database of the configured path.
+
+params :-
+none
+
+returns :-
+a. *db.SQLiteRepository
+Repository of the monitor
+b. error
+Error if any
+*/
+func DefaultRepository() (*db.SQLiteRepository, error) {
+	// notest
+	ormdb, err := gorm.Open(sqlite.Open(dbPath()), &gorm.Config{})
+	if err != nil {
+		return nil, fmt.Errorf(SQLiteCreationError, err)
+	}
+	return &db.SQLiteRepository{DB: ormdb}, nil
+}
+
 /*
 NewEth2Monitor :
 Factory for eth2Monitor.
@@ -92,6 +163,7 @@ func NewEth2Monitor(r db.Repository, bc net.BeaconAPI, ex net.ExecutionAPI, so n
 		beaconClient:    bc,
 		executionClient: ex,
 		subscriberOpts:  so,
+		sourcesInterval: validatorSourcesInterval,
 	}
 
 	err := monitor.setup(opts)
@@ -120,6 +192,8 @@ func (e *eth2Monitor) setup(opts ConfigOpts) error {
 		configs.InitConfig()
 	}
 
+	e.opts = opts
+
 	// TODO: Handle empty opts for uses cases like TrackSync only
 	cfg, err := Init(opts.Checkers)
 	if err != nil {
@@ -127,9 +201,32 @@ func (e *eth2Monitor) setup(opts ConfigOpts) error {
 		return err
 	}
 	e.config = cfg
+	e.validators, err = newValidatorSet(cfg.validators, cfg.groups)
+	if err != nil {
+		return err
+	}
+	e.settings = loadSettings()
+	if err := e.settings.validate(); err != nil {
+		return err
+	}
+	if e.alerter == nil {
+		e.alerter = newAlerter(e.settings)
+		e.settingsAlerter = true
+	}
+	e.settings.keymanagers, err = loadKeymanagers()
+	if err != nil {
+		return err
+	}
+	if e.keymanagerClient == nil && len(e.settings.keymanagers) > 0 {
+		e.keymanagerClient = &net.KeymanagerClient{RetryDuration: time.Minute}
+	}
+	if e.relayClient == nil && (len(e.settings.relays) > 0 || len(e.settings.mevBoost) > 0) {
+		e.relayClient = &net.RelayClient{RetryDuration: time.Minute}
+	}
 
 	// setup beacon nodes endpoints
 	e.subscriberOpts.Endpoints = e.config.consensus
+	e.eventOpts.Endpoints = e.config.consensus
 	e.beaconClient.SetEndpoints(e.config.consensus)
 
 	if opts.handleLogs {
@@ -146,13 +243,29 @@ func (e *eth2Monitor) setup(opts ConfigOpts) error {
 	return nil
 }
 
+/*
+Endpoints :
+Get the consensus and execution endpoints the monitor is configured with.
+
+params :-
+none
+
+returns :-
+a. []string
+Consensus endpoints
+b. []string
+Execution endpoints
+*/
+func (e *eth2Monitor) Endpoints() ([]string, []string) {
+	return e.config.consensus, e.config.execution
+}
+
 /*
 Monitor :
-Pipeline and entrypoint for validator monitoring.
+Pipeline and entrypoint for validator monitoring. Configuration changes are applied live when the config file changes or Reload is called.
 
 params :-
-a. handleCfg bool
-True if configuration setup (configuration file setup or enviroment variables setup) should be handled
+none
 
 returns :-
 a. []chan struct{}
@@ -161,93 +274,291 @@ b. error
 Error if any
 */
 func (e *eth2Monitor) Monitor() ([]chan struct{}, error) {
-	subDone := make(chan struct{})
-	chkps := net.Subscribe(subDone, e.subscriberOpts)
+	e.reloadMu.Lock()
+	e.running = e.startPipelines()
+	e.reloadMu.Unlock()
 
-	go e.getValidatorBalance(chkps, e.config.validators)
-	go e.setupAlerts(chkps)
+	done := make(chan struct{})
+	go e.watchConfig(done, viper.ConfigFileUsed(), configWatchInterval)
 
-	return []chan struct{}{subDone}, nil
+	return []chan struct{}{done}, nil
+}
+
+/*
+startPipelines :
+Start the monitoring goroutines with the current configuration and settings.
+
+params :-
+none
+
+returns :-
+a. *pipelines
+Running goroutines
+*/
+func (e *eth2Monitor) startPipelines() *pipelines {
+	p := &pipelines{done: make(chan struct{})}
+
+	chkps := net.Subscribe(p.done, e.subscriberOpts)
+	p.run(func() { e.getValidatorBalance(chkps) })
+	p.run(func() { e.setupAlerts(chkps) })
+
+	health := e.TrackHealth(p.done, e.config.consensus, e.config.execution, e.settings.healthInterval)
+	p.run(func() {
+		e.alertHealthTransitions(health, newStateTracker(e.settings.healthGracePeriod, string(NodeHealthy)))
+	})
+
+	// Always watched, since a reload that only changes validators can add file sources without restarting the goroutines
+	p.run(func() { e.watchValidatorSources(p.done, e.sourcesInterval) })
+	if len(e.config.execution) > 0 {
+		tracker := newStateTracker(e.settings.healthGracePeriod, string(PairingOK))
+		p.run(func() {
+			e.TrackPairing(p.done, e.config.consensus, e.config.execution, e.settings.healthInterval, tracker)
+		})
+	}
+
+	if len(e.settings.keymanagers) > 0 {
+		tracker := newStateTracker(e.settings.healthGracePeriod, string(KeyLoaded))
+		p.run(func() { e.TrackKeymanagers(p.done, e.settings.healthInterval, tracker) })
+	}
+
+	if len(e.settings.mevBoost) > 0 {
+		tracker := newStateTracker(e.settings.healthGracePeriod, string(MevBoostUp))
+		p.run(func() { e.TrackMevBoost(p.done, e.settings.healthInterval, tracker) })
+	}
+
+	// Finality is polled since finalized checkpoint events stop arriving when it stalls
+	tracker := newStateTracker(e.settings.healthGracePeriod, string(NetworkHealthy))
+	p.run(func() {
+		clock, err := e.Clock()
+		if err != nil {
+			log.WithFields(log.Fields{configs.Component: "ETH2 Monitor", "Method": "startPipelines"}).Errorf(FinalityClockError, err)
+			return
+		}
+		e.TrackFinality(p.done, e.settings.healthInterval, tracker, clock)
+	})
+
+	if e.eventOpts.Subscriber != nil {
+		events := net.SubscribeEvents(p.done, e.eventOpts)
+		p.run(func() { e.TrackReorgs(events) })
+	}
+
+	return p
 }
 
 /*
 getValidatorBalance :
-Track validator balance and performance.
+Track validator balance and performance. Checkpoints of epochs already processed are skipped.
 
 params :-
 a. chkps <-chan networking.Checkpoint
 Channel to get new checkpoints from
-b. validatorsIdxs []string
-List of validator indexes to track
 
 returns :-
 none
 */
-func (e *eth2Monitor) getValidatorBalance(chkps <-chan net.Checkpoint, validatorsIdxs []string) {
+func (e *eth2Monitor) getValidatorBalance(chkps <-chan net.Checkpoint) {
 	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "getValidatorBalance"}
 
+	// Every consensus endpoint sends the checkpoints, so only the first checkpoint of an epoch is processed
+	var lastEpoch uint64
+	processed := false
 	for c := range chkps {
+		// Balances are also kept per epoch for reports. Snapshots are skipped if the epoch is invalid
+		epoch, epochErr := strconv.ParseUint(c.Epoch, 10, 64)
+		if epochErr == nil && processed && epoch <= lastEpoch {
+			log.WithFields(logFields).Debugf("Skipping checkpoint of epoch %d, already processed", epoch)
+			continue
+		}
 		log.WithFields(logFields).Infof("Got Checkpoint: %+v", c)
 
-		// New finalized checkpoint. Fetch validator balances
-		// Hardcoding head state for now
-		vbs, err := e.beaconClient.ValidatorBalances("head", validatorsIdxs)
+		if epochErr != nil {
+			log.WithFields(logFields).Errorf(ParseUintError, epochErr)
+		} else {
+			lastEpoch, processed = epoch, true
+			e.resolveValidators()
+			e.updateTotalActiveBalance(c.State, epoch)
+			e.trackProposals(epoch)
+			e.trackAttestations(c.State, epoch)
+		}
+		e.checkFeeRecipientRegistrations()
+
+		// New finalized checkpoint. Fetch validator balances at the slot scanned for withdrawals, so withdrawals are not taken as missed attestations.
+		// With an invalid epoch, withdrawals are left for the next checkpoint so they are recorded with a known epoch
+		stateID, withdrawn, skipped := "head", make(map[uint]uint64), false
+		if epochErr == nil {
+			stateID, withdrawn, skipped = e.trackWithdrawals(epoch)
+		}
+		// Zero if balances are read at head
+		slot, _ := strconv.ParseUint(stateID, 10, 64)
+		vbs, err := e.beaconClient.ValidatorBalances(stateID, e.validators.List())
 		if err != nil {
 			log.WithFields(logFields).Errorf(ValidatorBalancesError, err)
 			continue
 		}
+		registry := e.effectiveBalances(stateID)
 
 		for _, vb := range vbs {
-			log.WithFields(logFields).Debugf("Validator Balance fetched: %+v", vb)
+			vFields := e.validatorFields(logFields, vb.Index)
+			log.WithFields(vFields).Debugf("Validator Balance fetched: %+v", vb)
 
 			// Get validator index from response data
 			idx, err := parseUint(vb.Index)
 			if err != nil {
-				log.WithFields(logFields).Errorf(ParseUintError, err)
+				log.WithFields(vFields).Errorf(ParseUintError, err)
 				continue
 			}
 
 			// Get validator balance from response data
 			newBalance, err := strconv.ParseUint(vb.Balance, 10, 64)
 			if err != nil {
-				log.WithFields(logFields).Errorf(ParseUintError, err)
+				log.WithFields(vFields).Errorf(ParseUintError, err)
 				continue
 			}
 
+			group, _ := e.validators.Group(vb.Index)
+			labels := encodeLabels(group.Labels)
+
 			// Get validator from db
-			v, err := e.repository.FirstOrCreate(db.Validator{Idx: idx, Balance: newBalance})
+			v, err := e.repository.FirstOrCreate(db.Validator{Idx: idx, Balance: newBalance, Group: group.Name, Labels: labels})
 			if err != nil {
-				log.WithFields(logFields).Errorf(ValidatorNotFoundError, err)
+				log.WithFields(vFields).Errorf(ValidatorNotFoundError, err)
 				continue
 			}
 
-			if newBalance < v.Balance {
-				log.WithFields(logFields).Warnf("Attestation has been missed by %d, count: %d", v.Idx, v.MissedAtts+1)
+			// Withdrawals lower the balance without being a penalty. Drops can't be told apart from withdrawals if blocks were skipped,
+			// so they are not checked and consecutive missed attestations are kept
+			missed := epochErr == nil && !skipped && newBalance+withdrawn[idx] < v.Balance
+			missedAtts := uint(0)
+			if skipped {
+				missedAtts = v.MissedAtts
+			}
+
+			// Snapshots have no effective balance if the registry could not be fetched, validators keep the last known one
+			var effective uint64
+			lastEffective := v.EffectiveBalance
+			if info, ok := registry[idx]; ok {
+				effective, _ = strconv.ParseUint(info.Validator.EffectiveBalance, 10, 64)
+				lastEffective = effective
+				e.checkEffectiveBalance(idx, info.Status, newBalance, effective)
+			}
+
+			if epochErr == nil {
+				err := e.repository.SaveSnapshot(db.BalanceSnapshot{
+					Idx: idx, Epoch: epoch, Balance: newBalance, Missed: missed, Withdrawn: withdrawn[idx], EffectiveBalance: effective, Slot: slot,
+				})
+				if err != nil {
+					log.WithFields(vFields).Errorf(SaveSnapshotError, idx, epoch, err)
+				}
+			}
+
+			if missed {
+				log.WithFields(vFields).Warnf("Attestation has been missed by %d, count: %d", v.Idx, v.MissedAtts+1)
 				e.repository.Update(db.Validator{
-					Idx:             v.Idx,
-					Balance:         newBalance,
-					MissedAtts:      v.MissedAtts + 1,
-					MissedAttsTotal: v.MissedAttsTotal + 1,
+					Idx:              v.Idx,
+					Balance:          newBalance,
+					EffectiveBalance: lastEffective,
+					MissedAtts:       v.MissedAtts + 1,
+					MissedAttsTotal:  v.MissedAttsTotal + 1,
+					Group:            group.Name,
+					Labels:           labels,
 				})
+				if v.MissedAtts+1 == e.missedAttestationsThreshold(vb.Index) {
+					e.alertMissedAttestations(vb.Index, v.MissedAtts+1)
+				}
 			} else {
 				e.repository.Update(db.Validator{
-					Idx:             v.Idx,
-					Balance:         newBalance,
-					MissedAtts:      0,
-					MissedAttsTotal: v.MissedAttsTotal,
+					Idx:              v.Idx,
+					Balance:          newBalance,
+					EffectiveBalance: lastEffective,
+					MissedAtts:       missedAtts,
+					MissedAttsTotal:  v.MissedAttsTotal,
+					Group:            group.Name,
+					Labels:           labels,
 				})
 			}
 		}
 	}
 }
 
+/*
+alertMissedAttestations :
+Send an alert about consecutive missed attestations of a validator, labeled with its group. It is marked as network-wide while the whole network is degraded.
+
+params :-
+a. validator string
+Validator index
+b. missed uint
+Consecutive missed attestations
+
+returns :-
+none
+*/
+func (e *eth2Monitor) alertMissedAttestations(validator string, missed uint) {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "alertMissedAttestations"}
+	idx, _ := strconv.ParseUint(validator, 10, 64)
+
+	alert := alerts.Alert{
+		Kind:     MissedAttestationAlert,
+		Severity: alerts.Warning,
+		Source:   validator,
+		Message:  fmt.Sprintf(MissedAttestationMsg, idx, missed),
+		Labels:   e.groupLabels(map[string]string{"validator": validator}, validator),
+		Time:     time.Now(),
+	}
+	e.networkWide(&alert)
+	if err := e.alerter.Send(alert); err != nil {
+		log.WithFields(logFields).Errorf(SendAlertError, err)
+	}
+}
+
 func (e *eth2Monitor) setupAlerts(<-chan net.Checkpoint) {
 
 }
 
+/*
+TrackSync :
+Periodically check sync progress of consensus and execution nodes, estimating sync speed and time left between checks.
+
+params :-
+a. done <-chan struct{}
+Channel to get stop signal from
+b. beaconEndpoints []string
+Consensus endpoints to check
+c. executionEndpoints []string
+Execution endpoints to check
+d. wait time.Duration
+Time between checks
+
+returns :-
+a. <-chan EndpointSyncStatus
+Channel to get sync status of every endpoint after each check
+*/
 func (e *eth2Monitor) TrackSync(done <-chan struct{}, beaconEndpoints, executionEndpoints []string, wait time.Duration) <-chan EndpointSyncStatus {
+	return e.TrackSyncOnSchedule(done, beaconEndpoints, executionEndpoints, func(time.Time) time.Duration { return wait })
+}
+
+/*
+TrackSyncOnSchedule :
+Same as TrackSync, but the time between checks is given by a schedule, e.g. Clock.UntilNextEpoch to check at epoch boundaries.
+
+params :-
+a. done <-chan struct{}
+Channel to get stop signal from
+b. beaconEndpoints []string
+Consensus endpoints to check
+c. executionEndpoints []string
+Execution endpoints to check
+d. next func(time.Time) time.Duration
+Time to wait for the next check, given the time the previous check ended
+
+returns :-
+a. <-chan EndpointSyncStatus
+Channel to get sync status of every endpoint after each check
+*/
+func (e *eth2Monitor) TrackSyncOnSchedule(done <-chan struct{}, beaconEndpoints, executionEndpoints []string, next func(time.Time) time.Duration) <-chan EndpointSyncStatus {
 	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "TrackSync"}
 	c := make(chan EndpointSyncStatus, len(executionEndpoints)+len(beaconEndpoints))
+	progress := newSyncProgress()
 	var w time.Duration
 
 	go func() {
@@ -257,10 +568,6 @@ func (e *eth2Monitor) TrackSync(done <-chan struct{}, beaconEndpoints, execution
 				close(c)
 				return
 			case <-time.After(w):
-				if w == 0 {
-					// Don't wait the first time
-					w = wait
-				}
 				// TODO: Benchmark this and check what happens if the processing is longer than the wait
 				// Check sync progress of beacon nodes
 				log.WithFields(logFields).Info("Tracking sync progress of consensus nodes...")
@@ -268,36 +575,119 @@ func (e *eth2Monitor) TrackSync(done <-chan struct{}, beaconEndpoints, execution
 				for _, s := range bStatus {
 					if s.Error != nil {
 						log.WithFields(logFields).Errorf(CheckingSyncStatusError, s.Endpoint, s.Error)
-						c <- EndpointSyncStatus{Endpoint: s.Endpoint, Error: s.Error}
-					} else {
-						if s.IsSyncing {
-							log.WithFields(logFields).Infof("Endpoint %s is syncing", s.Endpoint)
-						} else {
-							log.WithFields(logFields).Infof("Endpoint %s is synced", s.Endpoint)
-						}
-						c <- EndpointSyncStatus{Endpoint: s.Endpoint, Synced: !s.IsSyncing}
+						c <- EndpointSyncStatus{Endpoint: s.Endpoint, Layer: ConsensusLayer, Error: s.Error}
+						continue
 					}
+
+					status := EndpointSyncStatus{Endpoint: s.Endpoint, Layer: ConsensusLayer, Synced: !s.IsSyncing}
+					// Zero values are fine if the node sends garbage, they only mean unknown progress
+					status.HeadSlot, _ = strconv.ParseUint(s.HeadSlot, 10, 64)
+					status.SyncDistance, _ = strconv.ParseUint(s.SyncDistance, 10, 64)
+					progress.update(&status, status.HeadSlot, status.SyncDistance, time.Now())
+					logSyncStatus(logFields, status)
+					c <- status
 				}
 
-				// Check sync progress of execution nodes. Rule of Three not acomplished yet, so no harm in repetition :)
+				// Check sync progress of execution nodes
 				log.WithFields(logFields).Info("Tracking sync progress of execution nodes...")
 				eStatus := e.executionClient.SyncStatus(executionEndpoints)
 				for _, s := range eStatus {
 					if s.Error != nil {
 						log.WithFields(logFields).Errorf(CheckingSyncStatusError, s.Endpoint, s.Error)
-						c <- EndpointSyncStatus{Endpoint: s.Endpoint, Error: s.Error}
-					} else {
-						if s.IsSyncing {
-							log.WithFields(logFields).Infof("Endpoint %s is syncing", s.Endpoint)
-						} else {
-							log.WithFields(logFields).Infof("Endpoint %s is synced", s.Endpoint)
-						}
-						c <- EndpointSyncStatus{Endpoint: s.Endpoint, Synced: !s.IsSyncing}
+						c <- EndpointSyncStatus{Endpoint: s.Endpoint, Layer: ExecutionLayer, Error: s.Error}
+						continue
 					}
+
+					status := EndpointSyncStatus{Endpoint: s.Endpoint, Layer: ExecutionLayer, Synced: !s.IsSyncing, CurrentBlock: s.CurrentBlock, HighestBlock: s.HighestBlock}
+					var remaining uint64
+					if status.HighestBlock > status.CurrentBlock {
+						remaining = status.HighestBlock - status.CurrentBlock
+					}
+					progress.update(&status, status.CurrentBlock, remaining, time.Now())
+					logSyncStatus(logFields, status)
+					c <- status
 				}
+
+				// Don't wait the first time, only after every check
+				w = next(time.Now())
 			}
 		}
 	}()
 
 	return c
 }
+
+/*
+logSyncStatus :
+Log sync progress of an endpoint.
+
+params :-
+a. logFields log.Fields
+Log fields to use
+b. s EndpointSyncStatus
+Sync status of the endpoint
+
+returns :-
+none
+*/
+func logSyncStatus(logFields log.Fields, s EndpointSyncStatus) {
+	if s.Synced {
+		log.WithFields(logFields).Infof("Endpoint %s is synced", s.Endpoint)
+		return
+	}
+
+	if s.ETA > 0 {
+		log.WithFields(logFields).Infof("Endpoint %s is syncing. Speed: %.2f/s, ETA: %v", s.Endpoint, s.Speed, s.ETA)
+	} else {
+		log.WithFields(logFields).Infof("Endpoint %s is syncing", s.Endpoint)
+	}
+}
+
+// syncSample : Struct Represent sync progress of an endpoint at a given time
+type syncSample struct {
+	progress uint64
+	at       time.Time
+}
+
+// syncProgress : Struct Keep the previous sync sample of endpoints to estimate sync speed
+type syncProgress struct {
+	samples map[string]syncSample
+}
+
+func newSyncProgress() *syncProgress {
+	return &syncProgress{samples: make(map[string]syncSample)}
+}
+
+/*
+update :
+Estimate sync speed and time left of an endpoint since its previous sample, and keep the new sample.
+
+params :-
+a. s *EndpointSyncStatus
+Sync status to fill with the estimations
+b. progress uint64
+Head slot or current block of the endpoint
+c. remaining uint64
+Slots or blocks left to sync
+d. now time.Time
+Time of the sample
+
+returns :-
+none
+*/
+func (p *syncProgress) update(s *EndpointSyncStatus, progress, remaining uint64, now time.Time) {
+	prev, ok := p.samples[s.Endpoint]
+	p.samples[s.Endpoint] = syncSample{progress: progress, at: now}
+
+	if !ok || s.Synced || progress <= prev.progress {
+		return
+	}
+
+	elapsed := now.Sub(prev.at).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+
+	s.Speed = float64(progress-prev.progress) / elapsed
+	s.ETA = time.Duration(float64(remaining) / s.Speed * float64(time.Second)).Round(time.Second)
+}
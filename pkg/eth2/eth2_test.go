@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -31,6 +33,28 @@ type TestBeaconClient struct {
 	endpoints []string
 	vbCall    validatorBalanceInfo
 	ssCall    bcSyncStatusInfo
+	niData    []net.NodeInfo
+	// blocks by endpoint and block ID
+	blocks map[string]map[string]net.BeaconBlock
+	// genesis and spec by endpoint
+	genesis map[string]net.Genesis
+	specs   map[string]net.Spec
+	// validator registry. Nil makes Validators fail
+	registry []net.ValidatorInfo
+	// proposer duties by epoch
+	duties map[string][]net.ProposerDuty
+	// fee recipient registrations by endpoint. Other endpoints don't expose them
+	preparations map[string][]net.ProposerPreparation
+	// calls to ProposerPreparations by endpoint
+	preparationCalls map[string]int
+	// attester committees by epoch. Other epochs make Committees fail
+	committees map[string][]net.Committee
+	// active validators of the network. Nil makes ActiveValidators fail
+	active []net.ValidatorInfo
+	// finality checkpoints of the head state. Nil makes FinalityCheckpoints fail
+	finality *net.FinalityCheckpoints
+	// liveness of validators by epoch. Other epochs make Liveness fail
+	liveness map[string][]net.ValidatorLiveness
 }
 
 func (tbc *TestBeaconClient) SetEndpoints(endpoints []string) {
@@ -64,6 +88,100 @@ func (tbc *TestBeaconClient) SyncStatus(endpoints []string) []net.BeaconSyncingS
 	return tbc.ssCall.returnData[tbc.ssCall.current-1]
 }
 
+func (tbc *TestBeaconClient) NodeInfo(endpoints []string) []net.NodeInfo {
+	return tbc.niData
+}
+
+func (tbc *TestBeaconClient) Genesis(endpoint string) (net.Genesis, error) {
+	g, ok := tbc.genesis[endpoint]
+	if !ok {
+		return net.Genesis{}, fmt.Errorf("Genesis not found")
+	}
+	return g, nil
+}
+
+func (tbc *TestBeaconClient) Spec(endpoint string) (net.Spec, error) {
+	s, ok := tbc.specs[endpoint]
+	if !ok {
+		return net.Spec{}, fmt.Errorf("Spec not found")
+	}
+	return s, nil
+}
+
+func (tbc *TestBeaconClient) Validators(stateID string, ids []string) ([]net.ValidatorInfo, error) {
+	if tbc.registry == nil {
+		return nil, fmt.Errorf("Intentional error")
+	}
+	validators := make([]net.ValidatorInfo, 0)
+	for _, v := range tbc.registry {
+		for _, id := range ids {
+			if id == v.Index || strings.EqualFold(id, v.Validator.Pubkey) {
+				validators = append(validators, v)
+			}
+		}
+	}
+	return validators, nil
+}
+
+func (tbc *TestBeaconClient) ActiveValidators(stateID string) ([]net.ValidatorInfo, error) {
+	if tbc.active == nil {
+		return nil, fmt.Errorf("Intentional error")
+	}
+	return tbc.active, nil
+}
+
+func (tbc *TestBeaconClient) FinalityCheckpoints(stateID string) (net.FinalityCheckpoints, error) {
+	if tbc.finality == nil {
+		return net.FinalityCheckpoints{}, fmt.Errorf("Intentional error")
+	}
+	return *tbc.finality, nil
+}
+
+func (tbc *TestBeaconClient) Block(endpoint, blockID string) (net.BeaconBlock, error) {
+	blocks, ok := tbc.blocks[endpoint]
+	if !ok {
+		return net.BeaconBlock{}, fmt.Errorf("Block not found")
+	}
+	b, ok := blocks[blockID]
+	if !ok {
+		// Known endpoints answer like a beacon node without the block
+		return net.BeaconBlock{}, &net.StatusError{URL: endpoint, Code: http.StatusNotFound}
+	}
+	return b, nil
+}
+
+func (tbc *TestBeaconClient) ProposerDuties(epoch string) ([]net.ProposerDuty, error) {
+	return tbc.duties[epoch], nil
+}
+
+func (tbc *TestBeaconClient) ProposerPreparations(endpoint string) ([]net.ProposerPreparation, error) {
+	if tbc.preparationCalls == nil {
+		tbc.preparationCalls = make(map[string]int)
+	}
+	tbc.preparationCalls[endpoint]++
+	p, ok := tbc.preparations[endpoint]
+	if !ok {
+		return nil, &net.StatusError{URL: endpoint, Code: http.StatusNotFound}
+	}
+	return p, nil
+}
+
+func (tbc *TestBeaconClient) Committees(stateID, epoch string) ([]net.Committee, error) {
+	c, ok := tbc.committees[epoch]
+	if !ok {
+		return nil, fmt.Errorf("Intentional error")
+	}
+	return c, nil
+}
+
+func (tbc *TestBeaconClient) Liveness(epoch string, indexes []string) ([]net.ValidatorLiveness, error) {
+	l, ok := tbc.liveness[epoch]
+	if !ok {
+		return nil, fmt.Errorf("Intentional error")
+	}
+	return l, nil
+}
+
 type exSyncStatusInfo struct {
 	returnData [][]net.ExecutionSyncingStatus
 	current    int
@@ -72,6 +190,13 @@ type exSyncStatusInfo struct {
 // Mock of ExecutionAPI
 type TestExecutionClient struct {
 	ssCall exSyncStatusInfo
+	// blocks by endpoint and number
+	blocks map[string]map[uint64]net.ExecutionBlock
+	// blocks with transactions and block receipts by endpoint and number
+	fullBlocks map[string]map[uint64]net.FullExecutionBlock
+	receipts   map[string]map[uint64][]net.Receipt
+	// transaction receipts by endpoint and hash
+	txReceipts map[string]map[string]net.Receipt
 }
 
 func (tec *TestExecutionClient) Call(endpoint, method string, params ...any) (json.RawMessage, error) {
@@ -87,6 +212,38 @@ func (tec *TestExecutionClient) SyncStatus(endpoints []string) []net.ExecutionSy
 	return tec.ssCall.returnData[tec.ssCall.current-1]
 }
 
+func (tec *TestExecutionClient) BlockByNumber(endpoint string, number uint64) (net.ExecutionBlock, error) {
+	b, ok := tec.blocks[endpoint][number]
+	if !ok {
+		return net.ExecutionBlock{}, fmt.Errorf("Block not found")
+	}
+	return b, nil
+}
+
+func (tec *TestExecutionClient) FullBlockByNumber(endpoint string, number uint64) (net.FullExecutionBlock, error) {
+	b, ok := tec.fullBlocks[endpoint][number]
+	if !ok {
+		return net.FullExecutionBlock{}, fmt.Errorf("Block not found")
+	}
+	return b, nil
+}
+
+func (tec *TestExecutionClient) BlockReceipts(endpoint string, number uint64) ([]net.Receipt, error) {
+	r, ok := tec.receipts[endpoint][number]
+	if !ok {
+		return nil, fmt.Errorf("Method not supported")
+	}
+	return r, nil
+}
+
+func (tec *TestExecutionClient) TransactionReceipt(endpoint, hash string) (net.Receipt, error) {
+	r, ok := tec.txReceipts[endpoint][hash]
+	if !ok {
+		return net.Receipt{}, fmt.Errorf("Receipt not found")
+	}
+	return r, nil
+}
+
 func newTestExecutionClient(ssData [][]net.ExecutionSyncingStatus) *TestExecutionClient {
 	return &TestExecutionClient{
 		ssCall: exSyncStatusInfo{
@@ -159,9 +316,13 @@ type testSubscriber struct {
 	data map[string][]net.Checkpoint
 }
 
-func (s testSubscriber) Listen(url string, ch chan<- net.Checkpoint) {
+func (s testSubscriber) Listen(done <-chan struct{}, url string, ch chan<- net.Checkpoint) {
 	for _, data := range s.data[url] {
-		ch <- data
+		select {
+		case ch <- data:
+		case <-done:
+			return
+		}
 		//sleep to simulate a delay
 		time.Sleep(time.Millisecond * 50)
 	}
@@ -174,10 +335,22 @@ func TestGetValidatorBalance(t *testing.T) {
 		subscriptionData []net.Checkpoint
 		// data that should be in the db before the test
 		existingData []db.Validator
+		// balance snapshots that should be in the db before the test
+		existingSnapshots []db.BalanceSnapshot
 		// ordered data returned by the beacon client
 		requestData [][]net.ValidatorBalance
+		// validator groups
+		groups []ValidatorGroup
 		// data to validate in test db
 		want []db.Validator
+		// balance snapshots to validate in test db, not checked if nil
+		wantSnapshots []db.BalanceSnapshot
+		// blocks by endpoint and block ID, to get withdrawals from
+		blocks map[string]map[string]net.BeaconBlock
+		// validator registry with effective balances, failing if nil
+		registry []net.ValidatorInfo
+		// withdrawals to validate in test db, not checked if nil
+		wantWithdrawals []db.Withdrawal
 	}{
 		{
 			name:             "Test case 1, Empty and closed channel, nothing should happen",
@@ -231,6 +404,9 @@ func TestGetValidatorBalance(t *testing.T) {
 			want: []db.Validator{
 				{Idx: 1, Balance: 32000136946, MissedAtts: 1, MissedAttsTotal: 1},
 			},
+			wantSnapshots: []db.BalanceSnapshot{
+				{Idx: 1, Epoch: 2, Balance: 32000136946, Missed: true},
+			},
 		},
 		{
 			name: "Test case 5, One entry in channel, one validator to update, negative balance change, existing missed atts",
@@ -320,8 +496,8 @@ func TestGetValidatorBalance(t *testing.T) {
 			name: "Test case 11, several entries in channel, mixed behavior",
 			subscriptionData: []net.Checkpoint{
 				{Block: "0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf", State: "0x600e852a08c1200654ddf11025f1ceacb3c2e74bdd5c630cde0838b2591b69f9", Epoch: "2"},
-				{Block: "0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf", State: "0x600e852a08c1200654ddf11025f1ceacb3c2e74bdd5c630cde0838b2591b69f9", Epoch: "2"},
-				{Block: "0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf", State: "0x600e852a08c1200654ddf11025f1ceacb3c2e74bdd5c630cde0838b2591b69f9", Epoch: "2"}},
+				{Block: "0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf", State: "0x600e852a08c1200654ddf11025f1ceacb3c2e74bdd5c630cde0838b2591b69f9", Epoch: "3"},
+				{Block: "0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf", State: "0x600e852a08c1200654ddf11025f1ceacb3c2e74bdd5c630cde0838b2591b69f9", Epoch: "4"}},
 			existingData: []db.Validator{
 				{Idx: 1, Balance: 32000136946, MissedAtts: 6, MissedAttsTotal: 400},
 				{Idx: 2, Balance: 33000136946, MissedAtts: 2, MissedAttsTotal: 30},
@@ -345,21 +521,233 @@ func TestGetValidatorBalance(t *testing.T) {
 				{Idx: 3, Balance: 36000136946, MissedAtts: 0, MissedAttsTotal: 0},
 			},
 		},
+		{
+			name: "Test case 12, grouped validators, group and labels persisted",
+			subscriptionData: []net.Checkpoint{
+				{Block: "0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf", State: "0x600e852a08c1200654ddf11025f1ceacb3c2e74bdd5c630cde0838b2591b69f9", Epoch: "2"}},
+			existingData: []db.Validator{
+				{Idx: 2, Balance: 33000136946, MissedAtts: 0, MissedAttsTotal: 0},
+			},
+			requestData: [][]net.ValidatorBalance{
+				{
+					{Index: "1", Balance: "32000136946"},
+					{Index: "2", Balance: "32000136946"},
+					{Index: "3", Balance: "32000136946"},
+				},
+			},
+			groups: []ValidatorGroup{
+				{Name: "acme", Labels: map[string]string{"customer": "acme", "client": "teku"}, Validators: []string{"1", "2"}},
+			},
+			want: []db.Validator{
+				{Idx: 1, Balance: 32000136946, Group: "acme", Labels: "client=teku,customer=acme"},
+				{Idx: 2, Balance: 32000136946, MissedAtts: 1, MissedAttsTotal: 1, Group: "acme", Labels: "client=teku,customer=acme"},
+				{Idx: 3, Balance: 32000136946},
+			},
+		},
+		{
+			name: "Test case 13, balance lowered by a withdrawal, no missed attestation",
+			subscriptionData: []net.Checkpoint{
+				{Block: "0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf", State: "0x600e852a08c1200654ddf11025f1ceacb3c2e74bdd5c630cde0838b2591b69f9", Epoch: "2"}},
+			existingData: []db.Validator{
+				{Idx: 1, Balance: 32000136946, MissedAtts: 1, MissedAttsTotal: 1},
+			},
+			requestData: [][]net.ValidatorBalance{
+				{
+					{Index: "1", Balance: "32000010000"},
+				},
+			},
+			blocks: map[string]map[string]net.BeaconBlock{"1": {
+				"head": {Slot: "95", Body: net.BeaconBlockBody{ExecutionPayload: net.ExecutionPayload{
+					Withdrawals: []net.Withdrawal{{Index: "1", ValidatorIndex: "1", Address: "0xaa", Amount: "136946"}},
+				}}},
+			}},
+			want: []db.Validator{
+				{Idx: 1, Balance: 32000010000, MissedAtts: 0, MissedAttsTotal: 1},
+			},
+			wantSnapshots: []db.BalanceSnapshot{
+				{Idx: 1, Epoch: 2, Balance: 32000010000, Withdrawn: 136946, Slot: 95},
+			},
+		},
+		{
+			name: "Test case 14, effective balances from the registry",
+			subscriptionData: []net.Checkpoint{
+				{Block: "0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf", State: "0x600e852a08c1200654ddf11025f1ceacb3c2e74bdd5c630cde0838b2591b69f9", Epoch: "2"}},
+			existingData: []db.Validator{
+				{Idx: 1, Balance: 31800000000, EffectiveBalance: 32000000000},
+			},
+			requestData: [][]net.ValidatorBalance{
+				{
+					{Index: "1", Balance: "31700000000"},
+				},
+			},
+			registry: []net.ValidatorInfo{
+				{Index: "1", Status: "active_ongoing", Validator: net.ValidatorData{EffectiveBalance: "31000000000"}},
+			},
+			want: []db.Validator{
+				{Idx: 1, Balance: 31700000000, EffectiveBalance: 31000000000, MissedAtts: 1, MissedAttsTotal: 1},
+			},
+			wantSnapshots: []db.BalanceSnapshot{
+				{Idx: 1, Epoch: 2, Balance: 31700000000, Missed: true, EffectiveBalance: 31000000000},
+			},
+		},
+		{
+			name: "Test case 15, registry failing, last effective balance kept",
+			subscriptionData: []net.Checkpoint{
+				{Block: "0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf", State: "0x600e852a08c1200654ddf11025f1ceacb3c2e74bdd5c630cde0838b2591b69f9", Epoch: "2"}},
+			existingData: []db.Validator{
+				{Idx: 1, Balance: 32000000000, EffectiveBalance: 32000000000},
+			},
+			requestData: [][]net.ValidatorBalance{
+				{
+					{Index: "1", Balance: "32000010000"},
+				},
+			},
+			want: []db.Validator{
+				{Idx: 1, Balance: 32000010000, EffectiveBalance: 32000000000},
+			},
+			wantSnapshots: []db.BalanceSnapshot{
+				{Idx: 1, Epoch: 2, Balance: 32000010000},
+			},
+		},
+		{
+			name: "Test case 16, invalid epoch, withdrawals left for the next checkpoint",
+			subscriptionData: []net.Checkpoint{
+				{Block: "0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf", State: "0x600e852a08c1200654ddf11025f1ceacb3c2e74bdd5c630cde0838b2591b69f9", Epoch: "two"}},
+			existingData: []db.Validator{
+				{Idx: 1, Balance: 32000136946, MissedAtts: 1, MissedAttsTotal: 1},
+			},
+			requestData: [][]net.ValidatorBalance{
+				{
+					{Index: "1", Balance: "32000010000"},
+				},
+			},
+			blocks: map[string]map[string]net.BeaconBlock{"1": {
+				"head": {Slot: "95", Body: net.BeaconBlockBody{ExecutionPayload: net.ExecutionPayload{
+					Withdrawals: []net.Withdrawal{{Index: "1", ValidatorIndex: "1", Address: "0xaa", Amount: "136946"}},
+				}}},
+			}},
+			want: []db.Validator{
+				{Idx: 1, Balance: 32000010000, MissedAtts: 0, MissedAttsTotal: 1},
+			},
+			wantSnapshots:   []db.BalanceSnapshot{},
+			wantWithdrawals: []db.Withdrawal{},
+		},
+		{
+			name: "Test case 17, checkpoints from several endpoints, every epoch processed once",
+			subscriptionData: []net.Checkpoint{
+				{Block: "0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf", State: "0x600e852a08c1200654ddf11025f1ceacb3c2e74bdd5c630cde0838b2591b69f9", Epoch: "2"},
+				{Block: "0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf", State: "0x600e852a08c1200654ddf11025f1ceacb3c2e74bdd5c630cde0838b2591b69f9", Epoch: "2"},
+				{Block: "0x8a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf", State: "0x500e852a08c1200654ddf11025f1ceacb3c2e74bdd5c630cde0838b2591b69f9", Epoch: "1"},
+				{Block: "0xaa2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf", State: "0x700e852a08c1200654ddf11025f1ceacb3c2e74bdd5c630cde0838b2591b69f9", Epoch: "3"}},
+			existingData: []db.Validator{
+				{Idx: 1, Balance: 32000000000},
+			},
+			requestData: [][]net.ValidatorBalance{
+				{
+					{Index: "1", Balance: "33000136946"},
+				},
+				{
+					{Index: "1", Balance: "32000136946"},
+				},
+			},
+			want: []db.Validator{
+				{Idx: 1, Balance: 32000136946, MissedAtts: 1, MissedAttsTotal: 1},
+			},
+			wantSnapshots: []db.BalanceSnapshot{
+				{Idx: 1, Epoch: 2, Balance: 33000136946},
+				{Idx: 1, Epoch: 3, Balance: 32000136946, Missed: true},
+			},
+		},
+		{
+			name: "Test case 18, restart, withdrawals since the slot of the last snapshot are scanned",
+			subscriptionData: []net.Checkpoint{
+				{Block: "0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf", State: "0x600e852a08c1200654ddf11025f1ceacb3c2e74bdd5c630cde0838b2591b69f9", Epoch: "2"}},
+			existingData: []db.Validator{
+				{Idx: 1, Balance: 32000136946, MissedAtts: 1, MissedAttsTotal: 1},
+			},
+			existingSnapshots: []db.BalanceSnapshot{
+				{Idx: 1, Epoch: 1, Balance: 32000136946, Slot: 93},
+			},
+			requestData: [][]net.ValidatorBalance{
+				{
+					{Index: "1", Balance: "32000010000"},
+				},
+			},
+			blocks: map[string]map[string]net.BeaconBlock{"1": {
+				"94": {Slot: "94", Body: net.BeaconBlockBody{ExecutionPayload: net.ExecutionPayload{
+					Withdrawals: []net.Withdrawal{{Index: "1", ValidatorIndex: "1", Address: "0xaa", Amount: "136946"}},
+				}}},
+				"head": {Slot: "95"},
+			}},
+			want: []db.Validator{
+				{Idx: 1, Balance: 32000010000, MissedAtts: 0, MissedAttsTotal: 1},
+			},
+			wantSnapshots: []db.BalanceSnapshot{
+				{Idx: 1, Epoch: 1, Balance: 32000136946, Slot: 93},
+				{Idx: 1, Epoch: 2, Balance: 32000010000, Withdrawn: 136946, Slot: 95},
+			},
+		},
+		{
+			name: "Test case 19, long pause, balance drop not checked since withdrawals were skipped",
+			subscriptionData: []net.Checkpoint{
+				{Block: "0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf", State: "0x600e852a08c1200654ddf11025f1ceacb3c2e74bdd5c630cde0838b2591b69f9", Epoch: "20"}},
+			existingData: []db.Validator{
+				{Idx: 1, Balance: 32000136946, MissedAtts: 1, MissedAttsTotal: 1},
+			},
+			existingSnapshots: []db.BalanceSnapshot{
+				{Idx: 1, Epoch: 1, Balance: 32000136946, Slot: 32},
+			},
+			requestData: [][]net.ValidatorBalance{
+				{
+					{Index: "1", Balance: "32000010000"},
+				},
+			},
+			blocks: map[string]map[string]net.BeaconBlock{"1": {
+				"head": {Slot: "640"},
+			}},
+			want: []db.Validator{
+				{Idx: 1, Balance: 32000010000, MissedAtts: 1, MissedAttsTotal: 1},
+			},
+			wantSnapshots: []db.BalanceSnapshot{
+				{Idx: 1, Epoch: 1, Balance: 32000136946, Slot: 32},
+				{Idx: 1, Epoch: 20, Balance: 32000010000, Slot: 640},
+			},
+		},
 	}
 
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
-			monitor, err := setup(tc.requestData, net.SubscribeOpts{}, ConfigOpts{Checkers: []CfgChecker{{Key: Consensus, ErrMsg: NoConsensusFoundError, Data: []string{"1", "2", "3"}}}})
+			monitor, err := setup(tc.requestData, net.SubscribeOpts{}, ConfigOpts{Checkers: []CfgChecker{
+				{Key: Validators, ErrMsg: NoValidatorsFoundError, Data: []string{"1", "2", "3"}},
+				{Key: Consensus, ErrMsg: NoConsensusFoundError, Data: []string{"1", "2", "3"}},
+			}})
 			if err != nil {
 				t.Fatalf("Setup failed. Error %v", err)
 			}
+			if tc.groups != nil {
+				entries := make([]string, 0)
+				for _, g := range tc.groups {
+					entries = append(entries, g.Validators...)
+				}
+				if monitor.validators, err = newValidatorSet(entries, tc.groups); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			monitor.beaconClient.(*TestBeaconClient).blocks = tc.blocks
+			monitor.beaconClient.(*TestBeaconClient).registry = tc.registry
 
 			err = populateDb(monitor.repository, tc.existingData)
 			if err != nil {
 				t.Fatalf("Populate db failed. Error %v", err)
 			}
+			for _, s := range tc.existingSnapshots {
+				if err := monitor.repository.SaveSnapshot(s); err != nil {
+					t.Fatal(err)
+				}
+			}
 			input := fillChannel(tc.subscriptionData)
-			monitor.getValidatorBalance(input, []string{})
+			monitor.getValidatorBalance(input)
 
 			for _, want := range tc.want {
 				got, err := monitor.repository.Validator(want.Idx)
@@ -368,6 +756,16 @@ func TestGetValidatorBalance(t *testing.T) {
 				}
 				assert.Equal(t, want, got, "Validator in db with index %v is not equal to the wanted one", want.Idx)
 			}
+			if tc.wantSnapshots != nil {
+				got, err := monitor.repository.Snapshots(0, 100)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantSnapshots, got)
+			}
+			if tc.wantWithdrawals != nil {
+				got, err := monitor.repository.Withdrawals(0, 100)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantWithdrawals, got)
+			}
 
 			if err = cleanup(monitor.repository); err != nil {
 				t.Fatalf("Cleanup failed. Error %v", err)
@@ -380,6 +778,56 @@ type repositoryMock struct {
 	migrationCalled        int
 	expectedMigrationCalls int
 	migrationError         bool
+	reorgs                 []db.Reorg
+}
+
+func (rm *repositoryMock) SetInactive(idx uint, inactive bool) error {
+	return nil
+}
+
+func (rm *repositoryMock) SaveReorg(r db.Reorg) error {
+	rm.reorgs = append(rm.reorgs, r)
+	return nil
+}
+
+func (rm *repositoryMock) SaveSnapshot(s db.BalanceSnapshot) error {
+	return nil
+}
+
+func (rm *repositoryMock) Snapshots(fromEpoch, toEpoch uint64) (s []db.BalanceSnapshot, err error) {
+	return
+}
+
+func (rm *repositoryMock) LastSnapshotSlot() (s uint64, err error) {
+	return
+}
+
+func (rm *repositoryMock) SaveProposal(p db.Proposal) error {
+	return nil
+}
+
+func (rm *repositoryMock) Proposals(fromEpoch, toEpoch uint64) (p []db.Proposal, err error) {
+	return
+}
+
+func (rm *repositoryMock) SaveWithdrawal(w db.Withdrawal) error {
+	return nil
+}
+
+func (rm *repositoryMock) Withdrawals(fromEpoch, toEpoch uint64) (w []db.Withdrawal, err error) {
+	return
+}
+
+func (rm *repositoryMock) SaveAttestation(a db.Attestation) error {
+	return nil
+}
+
+func (rm *repositoryMock) Attestations(fromEpoch, toEpoch uint64) (a []db.Attestation, err error) {
+	return
+}
+
+func (rm *repositoryMock) SetEffectiveness(idx uint, effectiveness float64) error {
+	return nil
 }
 
 func (rm *repositoryMock) FirstOrCreate(val db.Validator) (v db.Validator, err error) {
@@ -542,6 +990,28 @@ func TestSetup(t *testing.T) {
 			isErr: true,
 			mock:  repositoryMock{migrationError: true, expectedMigrationCalls: 1},
 		},
+		{
+			name: "Test case 8, invalid settings, prepared configuration data",
+			opts: ConfigOpts{
+				HandleCfg: false,
+				Checkers: []CfgChecker{
+					{Key: Validators, ErrMsg: NoValidatorsFoundError, Data: []string{"1", "2", "3"}},
+					{Key: Consensus, ErrMsg: NoConsensusFoundError, Data: []string{"Endpoint1"}},
+				},
+			},
+			want: &eth2Monitor{
+				config: eth2Config{
+					validators: []string{"1", "2", "3"},
+					consensus:  []string{"Endpoint1"},
+				},
+			},
+			env: map[string]string{
+				"PM_HEALTH_INTERVAL":               "0",
+				"PM_MISSED_ATTESTATIONS_THRESHOLD": "0",
+			},
+			isErr: true,
+			mock:  repositoryMock{migrationError: false, expectedMigrationCalls: 0},
+		},
 	}
 
 	for _, tc := range tcs {
@@ -618,8 +1088,8 @@ func TestMonitor(t *testing.T) {
 					data: map[string][]net.Checkpoint{
 						"Endpoint1": {
 							{Block: "0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf", State: "0x600e852a08c1200654ddf11025f1ceacb3c2e74bdd5c630cde0838b2591b69f9", Epoch: "2"},
-							{Block: "0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf", State: "0x600e852a08c1200654ddf11025f1ceacb3c2e74bdd5c630cde0838b2591b69f9", Epoch: "2"},
-							{Block: "0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf", State: "0x600e852a08c1200654ddf11025f1ceacb3c2e74bdd5c630cde0838b2591b69f9", Epoch: "2"},
+							{Block: "0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf", State: "0x600e852a08c1200654ddf11025f1ceacb3c2e74bdd5c630cde0838b2591b69f9", Epoch: "3"},
+							{Block: "0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf", State: "0x600e852a08c1200654ddf11025f1ceacb3c2e74bdd5c630cde0838b2591b69f9", Epoch: "4"},
 						},
 					},
 				},
@@ -713,7 +1183,7 @@ func TestTrackSync(t *testing.T) {
 				},
 				wait: time.Millisecond,
 			},
-			[]EndpointSyncStatus{{Endpoint: "1", Synced: true}},
+			[]EndpointSyncStatus{{Endpoint: "1", Layer: ConsensusLayer, Synced: true}},
 		},
 		{
 			"Test case 2, one consensus node, not synced",
@@ -729,7 +1199,7 @@ func TestTrackSync(t *testing.T) {
 				},
 				wait: time.Millisecond,
 			},
-			[]EndpointSyncStatus{{Endpoint: "1", Synced: false}},
+			[]EndpointSyncStatus{{Endpoint: "1", Layer: ConsensusLayer, Synced: false}},
 		},
 		{
 			"Test case 3, one execution node, synced",
@@ -745,7 +1215,7 @@ func TestTrackSync(t *testing.T) {
 				},
 				wait: time.Millisecond,
 			},
-			[]EndpointSyncStatus{{Endpoint: "1", Synced: true}},
+			[]EndpointSyncStatus{{Endpoint: "1", Layer: ExecutionLayer, Synced: true}},
 		},
 		{
 			"Test case 4, one execution node, not synced",
@@ -761,7 +1231,7 @@ func TestTrackSync(t *testing.T) {
 				},
 				wait: time.Millisecond,
 			},
-			[]EndpointSyncStatus{{Endpoint: "1", Synced: false}},
+			[]EndpointSyncStatus{{Endpoint: "1", Layer: ExecutionLayer, Synced: false}},
 		},
 		{
 			"Test case 5, two execution nodes, mixed sync status",
@@ -781,7 +1251,7 @@ func TestTrackSync(t *testing.T) {
 				},
 				wait: time.Millisecond,
 			},
-			[]EndpointSyncStatus{{Endpoint: "1", Synced: false}, {Endpoint: "2", Synced: true}},
+			[]EndpointSyncStatus{{Endpoint: "1", Layer: ExecutionLayer, Synced: false}, {Endpoint: "2", Layer: ExecutionLayer, Synced: true}},
 		},
 		{
 			"Test case 6, two mixed nodes, mixed sync status",
@@ -806,7 +1276,7 @@ func TestTrackSync(t *testing.T) {
 				},
 				wait: time.Millisecond,
 			},
-			[]EndpointSyncStatus{{Endpoint: "2", Synced: false}, {Endpoint: "1", Synced: true}},
+			[]EndpointSyncStatus{{Endpoint: "2", Layer: ConsensusLayer, Synced: false}, {Endpoint: "1", Layer: ExecutionLayer, Synced: true}},
 		},
 		{
 			"Test case 7, two mixed nodes, mixed sync status, one wait",
@@ -843,7 +1313,7 @@ func TestTrackSync(t *testing.T) {
 				},
 				wait: time.Millisecond,
 			},
-			[]EndpointSyncStatus{{Endpoint: "2", Synced: true}, {Endpoint: "1", Synced: false}, {Endpoint: "2", Synced: true}, {Endpoint: "1", Synced: true}},
+			[]EndpointSyncStatus{{Endpoint: "2", Layer: ConsensusLayer, Synced: true}, {Endpoint: "1", Layer: ExecutionLayer, Synced: false}, {Endpoint: "2", Layer: ConsensusLayer, Synced: true}, {Endpoint: "1", Layer: ExecutionLayer, Synced: true}},
 		},
 		{
 			"Test case 8, one node, no response",
@@ -869,7 +1339,7 @@ func TestTrackSync(t *testing.T) {
 				},
 				wait: time.Second,
 			},
-			[]EndpointSyncStatus{{Endpoint: "1", Error: errors.New("")}},
+			[]EndpointSyncStatus{{Endpoint: "1", Layer: ConsensusLayer, Error: errors.New("")}},
 		},
 		{
 			"Test case 10, one execution node, error",
@@ -886,7 +1356,40 @@ func TestTrackSync(t *testing.T) {
 				},
 				wait: time.Second,
 			},
-			[]EndpointSyncStatus{{Endpoint: "1", Error: errors.New("")}},
+			[]EndpointSyncStatus{{Endpoint: "1", Layer: ExecutionLayer, Error: errors.New("")}},
+		},
+		{
+			"Test case 11, two mixed nodes, syncing, progress values",
+			opts{
+				bcEndpoints: []string{"2"},
+				bcData: [][]net.BeaconSyncingStatus{
+					{
+						net.BeaconSyncingStatus{
+							Endpoint:     "2",
+							HeadSlot:     "100",
+							SyncDistance: "50",
+							IsSyncing:    true,
+						},
+					},
+				},
+				exEndpoints: []string{"1"},
+				exData: [][]net.ExecutionSyncingStatus{
+					{
+						net.ExecutionSyncingStatus{
+							Endpoint:      "1",
+							StartingBlock: 900,
+							CurrentBlock:  902,
+							HighestBlock:  1108,
+							IsSyncing:     true,
+						},
+					},
+				},
+				wait: time.Second,
+			},
+			[]EndpointSyncStatus{
+				{Endpoint: "2", Layer: ConsensusLayer, HeadSlot: 100, SyncDistance: 50},
+				{Endpoint: "1", Layer: ExecutionLayer, CurrentBlock: 902, HighestBlock: 1108},
+			},
 		},
 	}
 
@@ -926,4 +1429,70 @@ func TestTrackSync(t *testing.T) {
 	}
 }
 
+func TestSyncProgress(t *testing.T) {
+	t.Parallel()
+
+	type sample struct {
+		synced    bool
+		progress  uint64
+		remaining uint64
+		// seconds since start
+		at int
+	}
+
+	tcs := []struct {
+		name      string
+		samples   []sample
+		wantSpeed float64
+		wantETA   time.Duration
+	}{
+		{
+			"Test case 1, first sample, unknown speed",
+			[]sample{{false, 100, 1000, 0}},
+			0,
+			0,
+		},
+		{
+			"Test case 2, two samples, 10 per second",
+			[]sample{{false, 100, 1000, 0}, {false, 200, 900, 10}},
+			10,
+			90 * time.Second,
+		},
+		{
+			"Test case 3, no progress, unknown speed",
+			[]sample{{false, 100, 1000, 0}, {false, 100, 1000, 10}},
+			0,
+			0,
+		},
+		{
+			"Test case 4, synced, no estimations",
+			[]sample{{false, 100, 1000, 0}, {true, 1100, 0, 10}},
+			0,
+			0,
+		},
+		{
+			"Test case 5, speed uses only the previous sample",
+			[]sample{{false, 0, 36000, 0}, {false, 100, 35900, 100}, {false, 400, 35700, 200}},
+			3,
+			11900 * time.Second,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			p := newSyncProgress()
+			start := time.Now()
+
+			var got EndpointSyncStatus
+			for _, smp := range tc.samples {
+				got = EndpointSyncStatus{Endpoint: "1", Synced: smp.synced}
+				p.update(&got, smp.progress, smp.remaining, start.Add(time.Duration(smp.at)*time.Second))
+			}
+
+			assert.InDelta(t, tc.wantSpeed, got.Speed, 0.0001, "update() gave wrong speed")
+			assert.Equal(t, tc.wantETA, got.ETA, "update() gave wrong ETA")
+		})
+	}
+}
+
 // TODO: Test NewEth2Monitor
@@ -0,0 +1,153 @@
+package eth2
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NethermindEth/posmoni/configs"
+	"github.com/NethermindEth/posmoni/pkg/eth2/alerts"
+	net "github.com/NethermindEth/posmoni/pkg/eth2/networking"
+	log "github.com/sirupsen/logrus"
+)
+
+// checksFeeRecipients : Check if any expected fee recipient is configured, globally, per validator or per group
+func (e *eth2Monitor) checksFeeRecipients() bool {
+	if e.settings.feeRecipient != "" || len(e.settings.feeRecipients) > 0 {
+		return true
+	}
+	for _, g := range e.validators.Groups() {
+		if g.FeeRecipient != "" {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+checkProposalFeeRecipient :
+Alert if a block proposed by a validator paid its rewards to another fee recipient than the expected one.
+
+params :-
+a. validator string
+Validator public index
+b. slot uint64
+Slot of the block
+c. recipient string
+Address the execution rewards of the block were paid to
+
+returns :-
+none
+*/
+func (e *eth2Monitor) checkProposalFeeRecipient(validator string, slot uint64, recipient string) {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "checkProposalFeeRecipient"}
+
+	expected := e.expectedFeeRecipient(validator)
+	if expected == "" || recipient == "" || strings.EqualFold(recipient, expected) {
+		return
+	}
+
+	idx, _ := strconv.ParseUint(validator, 10, 64)
+	recipient = strings.ToLower(recipient)
+	err := e.alerter.Send(alerts.Alert{
+		Kind:     FeeRecipientAlert,
+		Severity: alerts.Critical,
+		Source:   validator,
+		Message:  fmt.Sprintf(FeeRecipientProposalMsg, idx, slot, recipient, expected),
+		Labels: e.groupLabels(map[string]string{
+			"validator": validator, "slot": strconv.FormatUint(slot, 10), "fee_recipient": recipient, "expected_fee_recipient": expected,
+		}, validator),
+		Time: time.Now(),
+	})
+	if err != nil {
+		log.WithFields(logFields).Errorf(SendAlertError, err)
+	}
+}
+
+/*
+checkFeeRecipientRegistrations :
+Compare the fee recipients registered by validator clients in the consensus nodes with the expected ones, for nodes exposing registrations. A wrong registration is alerted once per node until it changes. Registrations are kept to recognize builder payments of relayed blocks, so they are only fetched if expected fee recipients or relays are configured. Nodes answering that they don't expose registrations are not asked again.
+
+params :-
+none
+
+returns :-
+none
+*/
+func (e *eth2Monitor) checkFeeRecipientRegistrations() {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "checkFeeRecipientRegistrations"}
+	registrations := make(map[string]map[string]bool)
+	defer func() { e.registeredFeeRecipients = registrations }()
+	if !e.checksFeeRecipients() && len(e.settings.relays) == 0 {
+		return
+	}
+	if e.feeRecipientMismatches == nil {
+		e.feeRecipientMismatches = make(map[string]string)
+	}
+	if e.noRegistrations == nil {
+		e.noRegistrations = make(map[string]bool)
+	}
+
+	for _, endpoint := range e.config.consensus {
+		if e.noRegistrations[endpoint] {
+			continue
+		}
+		preparations, err := e.beaconClient.ProposerPreparations(endpoint)
+		if err != nil {
+			var statusErr *net.StatusError
+			if errors.As(err, &statusErr) && (statusErr.Code == http.StatusNotFound || statusErr.Code == http.StatusMethodNotAllowed) {
+				log.WithFields(logFields).Infof("Consensus node %s does not expose fee recipient registrations, they are not checked on it", endpoint)
+				e.noRegistrations[endpoint] = true
+			} else {
+				log.WithFields(logFields).Errorf(FeeRecipientsError, endpoint, err)
+			}
+			continue
+		}
+
+		for _, p := range preparations {
+			if !e.validators.Contains(p.ValidatorIndex) {
+				continue
+			}
+			registered := strings.ToLower(p.FeeRecipient)
+			if registrations[p.ValidatorIndex] == nil {
+				registrations[p.ValidatorIndex] = make(map[string]bool)
+			}
+			registrations[p.ValidatorIndex][registered] = true
+
+			expected := e.expectedFeeRecipient(p.ValidatorIndex)
+			if expected == "" {
+				continue
+			}
+
+			key := endpoint + "/" + p.ValidatorIndex
+			if registered == expected {
+				if _, ok := e.feeRecipientMismatches[key]; ok {
+					log.WithFields(e.validatorFields(logFields, p.ValidatorIndex)).Infof("Validator %s is now registered in %s with the expected fee recipient %s", p.ValidatorIndex, endpoint, expected)
+					delete(e.feeRecipientMismatches, key)
+				}
+				continue
+			}
+			if e.feeRecipientMismatches[key] == registered {
+				continue
+			}
+
+			e.feeRecipientMismatches[key] = registered
+			err := e.alerter.Send(alerts.Alert{
+				Kind:     FeeRecipientAlert,
+				Severity: alerts.Warning,
+				Source:   p.ValidatorIndex,
+				Message:  fmt.Sprintf(FeeRecipientRegistrationMsg, p.ValidatorIndex, endpoint, registered, expected),
+				Labels: e.groupLabels(map[string]string{
+					"validator": p.ValidatorIndex, "endpoint": endpoint, "fee_recipient": registered, "expected_fee_recipient": expected,
+				}, p.ValidatorIndex),
+				Time: time.Now(),
+			})
+			if err != nil {
+				log.WithFields(logFields).Errorf(SendAlertError, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package eth2
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/posmoni/pkg/eth2/alerts"
+	net "github.com/NethermindEth/posmoni/pkg/eth2/networking"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	ourRecipient   = "0xabcf8e0d4e9587369b2301d0790347320302cc09"
+	groupRecipient = "0x6d2e03b7effeae98bd302a9f836d0d6ab0002766"
+	otherRecipient = "0x388c818ca8b9251b393131c08a736a67ccb19297"
+)
+
+func feeRecipientMonitor(t *testing.T) *eth2Monitor {
+	groups := []ValidatorGroup{{Name: "acme", Validators: []string{"2", "3"}, FeeRecipient: "0x6D2E03B7EFFEAE98BD302A9F836D0D6AB0002766"}}
+	vs, err := newValidatorSet([]string{"1", "2", "3", "4"}, groups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &eth2Monitor{
+		validators: vs,
+		config:     eth2Config{consensus: []string{"cl1", "cl2"}},
+		settings:   monitorSettings{feeRecipient: ourRecipient, feeRecipients: map[string]string{"3": otherRecipient}},
+		alerter:    &alerterMock{},
+	}
+}
+
+func TestExpectedFeeRecipient(t *testing.T) {
+	t.Parallel()
+	monitor := feeRecipientMonitor(t)
+
+	// Global, group and validator fee recipients, in increasing priority
+	assert.Equal(t, ourRecipient, monitor.expectedFeeRecipient("1"))
+	assert.Equal(t, groupRecipient, monitor.expectedFeeRecipient("2"))
+	assert.Equal(t, otherRecipient, monitor.expectedFeeRecipient("3"))
+	assert.True(t, monitor.checksFeeRecipients())
+
+	monitor.settings = monitorSettings{}
+	vs, err := newValidatorSet([]string{"1", "2", "3", "4"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	monitor.validators = vs
+	assert.Equal(t, "", monitor.expectedFeeRecipient("1"))
+	assert.False(t, monitor.checksFeeRecipients())
+}
+
+func TestCheckProposalFeeRecipient(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name      string
+		validator string
+		recipient string
+		wantAlert bool
+	}{
+		{"Test case 1, expected fee recipient in another case", "1", "0xABCF8E0D4E9587369B2301D0790347320302CC09", false},
+		{"Test case 2, fee recipient of the group expected", "2", ourRecipient, true},
+		{"Test case 3, unknown fee recipient", "1", "", false},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			monitor := feeRecipientMonitor(t)
+
+			monitor.checkProposalFeeRecipient(tc.validator, 70, tc.recipient)
+
+			sent := monitor.alerter.(*alerterMock).all()
+			if !tc.wantAlert {
+				assert.Empty(t, sent)
+				return
+			}
+			if assert.Len(t, sent, 1) {
+				assert.Equal(t, FeeRecipientAlert, sent[0].Kind)
+				assert.Equal(t, alerts.Critical, sent[0].Severity)
+				assert.Equal(t, map[string]string{
+					"validator": tc.validator, "slot": "70", "fee_recipient": tc.recipient, "expected_fee_recipient": groupRecipient, "group": "acme",
+				}, sent[0].Labels)
+			}
+		})
+	}
+}
+
+func TestCheckFeeRecipientRegistrations(t *testing.T) {
+	t.Parallel()
+	monitor := feeRecipientMonitor(t)
+	// cl2 doesn't expose registrations
+	tbc := &TestBeaconClient{preparations: map[string][]net.ProposerPreparation{"cl1": {
+		{ValidatorIndex: "1", FeeRecipient: otherRecipient},
+		{ValidatorIndex: "2", FeeRecipient: groupRecipient},
+		// Not monitored
+		{ValidatorIndex: "9", FeeRecipient: otherRecipient},
+	}}}
+	monitor.beaconClient = tbc
+	am := monitor.alerter.(*alerterMock)
+
+	monitor.checkFeeRecipientRegistrations()
+	sent := am.all()
+	if assert.Len(t, sent, 1) {
+		assert.Equal(t, FeeRecipientAlert, sent[0].Kind)
+		assert.Equal(t, alerts.Warning, sent[0].Severity)
+		assert.Equal(t, "1", sent[0].Source)
+		assert.Equal(t, "cl1", sent[0].Labels["endpoint"])
+		assert.Equal(t, otherRecipient, sent[0].Labels["fee_recipient"])
+	}
+
+	// Registrations are builder payees of the validator, with the expected fee recipient and the one of the relay
+	assert.ElementsMatch(t, []string{ourRecipient, otherRecipient, groupRecipient}, monitor.builderPayees("1", relayDelivery{proposerFeeRecipient: groupRecipient}))
+	// Registrations of validators not monitored are ignored
+	assert.Equal(t, []string{ourRecipient}, monitor.builderPayees("9", relayDelivery{}))
+
+	// The same wrong registration is not alerted again
+	monitor.checkFeeRecipientRegistrations()
+	assert.Len(t, am.all(), 1)
+
+	// Fixed, then wrong again
+	tbc.preparations["cl1"][0].FeeRecipient = ourRecipient
+	monitor.checkFeeRecipientRegistrations()
+	assert.Len(t, am.all(), 1)
+	tbc.preparations["cl1"][0].FeeRecipient = groupRecipient
+	monitor.checkFeeRecipientRegistrations()
+	assert.Len(t, am.all(), 2)
+
+	// cl2 was asked once
+	assert.Equal(t, map[string]int{"cl1": 4, "cl2": 1}, tbc.preparationCalls)
+
+	// Not fetched without expected fee recipients or relays
+	vs, err := newValidatorSet([]string{"1", "2"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tbc.preparationCalls = nil
+	monitor.validators, monitor.settings = vs, monitorSettings{}
+	monitor.checkFeeRecipientRegistrations()
+	assert.Empty(t, tbc.preparationCalls)
+	assert.Empty(t, monitor.builderPayees("1", relayDelivery{}))
+	monitor.settings.relays = []string{"https://relay.ultrasound.money"}
+	monitor.checkFeeRecipientRegistrations()
+	assert.Equal(t, map[string]int{"cl1": 1}, tbc.preparationCalls)
+	assert.Equal(t, []string{groupRecipient}, monitor.builderPayees("1", relayDelivery{}))
+}
@@ -0,0 +1,288 @@
+package eth2
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NethermindEth/posmoni/configs"
+	"github.com/NethermindEth/posmoni/pkg/eth2/alerts"
+	log "github.com/sirupsen/logrus"
+)
+
+// Finality rules of the consensus specs, in epochs
+const (
+	// Delay of the finalized checkpoint behind the current epoch on a healthy network
+	normalFinalityDelay = 2
+	// Finality delay of the previous epoch after which inactive validators are penalized
+	minEpochsToInactivityPenalty = 4
+)
+
+// networkKey : Key of the network state in state trackers
+const networkKey = "network"
+
+/*
+TrackFinality :
+Periodically check the finality and participation of the whole network, and raise alerts on state changes. Finality is read from the head state, since finalized checkpoint events stop arriving when it stalls. Participation is checked once per epoch, for the epoch before the previous one, so blocks including its attestations are all known.
+
+params :-
+a. done <-chan struct{}
+Channel to get stop signal from
+b. wait time.Duration
+Time between checks
+c. tracker *stateTracker
+Tracker to debounce network states with
+d. clock *Clock
+Clock of the network, to get the current epoch
+
+returns :-
+none
+*/
+func (e *eth2Monitor) TrackFinality(done <-chan struct{}, wait time.Duration, tracker *stateTracker, clock *Clock) {
+	var w time.Duration
+	for {
+		select {
+		case <-done:
+			return
+		case <-time.After(w):
+			// Don't wait the first time
+			w = wait
+			e.checkFinality(time.Now(), tracker, clock)
+		}
+	}
+}
+
+/*
+checkFinality :
+Check the finality and participation of the whole network at a time, and alert network state changes.
+
+params :-
+a. now time.Time
+Time of the check
+b. tracker *stateTracker
+Tracker to debounce network states with
+c. clock *Clock
+Clock of the network, to get the current epoch
+
+returns :-
+none
+*/
+func (e *eth2Monitor) checkFinality(now time.Time, tracker *stateTracker, clock *Clock) {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "checkFinality"}
+	current := clock.EpochAt(now)
+
+	if current >= normalFinalityDelay && current-normalFinalityDelay >= e.participationEpoch {
+		epoch := current - normalFinalityDelay
+		// Not retried on errors, the next epoch is checked instead
+		e.participationEpoch = epoch + 1
+		participation, err := e.epochParticipation(epoch, clock.SlotsPerEpoch())
+		if err != nil {
+			log.WithFields(logFields).Errorf(ParticipationError, epoch, err)
+		} else {
+			e.participation, e.participationKnown = participation, true
+			log.WithFields(logFields).Infof("Participation of epoch %d: %.1f%%", epoch, participation*100)
+		}
+	}
+
+	checkpoints, err := e.beaconClient.FinalityCheckpoints("head")
+	if err != nil {
+		log.WithFields(logFields).Errorf(FinalityCheckpointsError, err)
+		return
+	}
+	finalized, err := strconv.ParseUint(checkpoints.Finalized.Epoch, 10, 64)
+	if err != nil {
+		log.WithFields(logFields).Errorf(ParseUintError, err)
+		return
+	}
+	var delay uint64
+	if current > finalized {
+		delay = current - finalized
+	}
+
+	state := e.networkHealth(delay)
+	e.networkMu.Lock()
+	e.networkState = state
+	e.networkMu.Unlock()
+	if state != NetworkHealthy {
+		log.WithFields(logFields).Warnf("Network is degraded: %s. Epochs since finality: %d, participation: %s", state, delay, e.formatParticipation())
+	}
+
+	tr, ok := tracker.observe(networkKey, string(state), now)
+	if !ok {
+		return
+	}
+	severity := alerts.Warning
+	switch NetworkState(tr.to) {
+	case NetworkHealthy:
+		severity = alerts.Info
+	case NetworkInactivityLeak:
+		severity = alerts.Critical
+	}
+	err = e.alerter.Send(alerts.Alert{
+		Kind:     NetworkHealthAlert,
+		Severity: severity,
+		Source:   networkKey,
+		Message:  fmt.Sprintf(NetworkTransitionMsg, tr.from, tr.to, tr.lasted, delay, e.formatParticipation()),
+		Labels: map[string]string{
+			"state": tr.to, "previous_state": tr.from, "finality_delay": strconv.FormatUint(delay, 10), "participation": e.formatParticipation(),
+		},
+		Time: now,
+	})
+	if err != nil {
+		log.WithFields(logFields).Errorf(SendAlertError, err)
+	}
+}
+
+/*
+networkHealth :
+Get the state of the network from its finality delay and the last participation checked.
+
+params :-
+a. delay uint64
+Epochs between the current epoch and the finalized checkpoint
+
+returns :-
+a. NetworkState
+State of the network. The inactivity leak comes first, then stalled finality and low participation
+*/
+func (e *eth2Monitor) networkHealth(delay uint64) NetworkState {
+	switch {
+	case delay > minEpochsToInactivityPenalty+1:
+		return NetworkInactivityLeak
+	case delay > normalFinalityDelay+e.settings.finalityStallEpochs:
+		return NetworkFinalityStalled
+	case e.participationKnown && e.settings.minParticipation > 0 && e.participation*100 < float64(e.settings.minParticipation):
+		return NetworkLowParticipation
+	default:
+		return NetworkHealthy
+	}
+}
+
+// formatParticipation : Format the last participation checked as a percentage, or unknown
+func (e *eth2Monitor) formatParticipation() string {
+	if !e.participationKnown {
+		return unknownState
+	}
+	return fmt.Sprintf("%.1f%%", e.participation*100)
+}
+
+/*
+epochParticipation :
+Get the share of the validators of the network whose attestations of an epoch voted for the correct target and were included. Committees are read from the state at the start of the next epoch, and the blocks of the epoch and the next one are scanned.
+
+params :-
+a. epoch uint64
+Epoch to check
+b. slotsPerEpoch uint64
+Slots of an epoch
+
+returns :-
+a. float64
+Participation from 0 to 1
+b. error
+Error if committees or blocks could not be fetched
+*/
+func (e *eth2Monitor) epochParticipation(epoch, slotsPerEpoch uint64) (float64, error) {
+	committees, err := e.beaconClient.Committees(strconv.FormatUint((epoch+1)*slotsPerEpoch, 10), strconv.FormatUint(epoch, 10))
+	if err != nil {
+		return 0, err
+	}
+
+	sizes := make(map[uint64]map[uint64]int)
+	total := 0
+	var start, end uint64 = math.MaxUint64, 0
+	for _, c := range committees {
+		slot, err := strconv.ParseUint(c.Slot, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		index, err := strconv.ParseUint(c.Index, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		if slot < start {
+			start = slot
+		}
+		if slot > end {
+			end = slot
+		}
+		if sizes[slot] == nil {
+			sizes[slot] = make(map[uint64]int)
+		}
+		sizes[slot][index] = len(c.Validators)
+		total += len(c.Validators)
+	}
+	if total == 0 {
+		return 0, fmt.Errorf(NoCommitteesError, epoch)
+	}
+
+	blocks, err := e.scanBlocks(start+1, end+slotsPerEpoch)
+	if err != nil {
+		return 0, err
+	}
+	target := rootAt(blocks, start)
+
+	// Validators can be in several aggregates, so their bits are only counted once
+	seen := make(map[uint64]map[uint64][]bool)
+	participants := 0
+	for _, b := range blocks {
+		for _, att := range b.block.Body.Attestations {
+			slot, err := strconv.ParseUint(att.Data.Slot, 10, 64)
+			if err != nil || sizes[slot] == nil || !strings.EqualFold(att.Data.Target.Root, target) {
+				continue
+			}
+			bits, err := decodeBits(att.AggregationBits)
+			if err != nil {
+				continue
+			}
+			committeeIdxs, err := attestationCommittees(att)
+			if err != nil {
+				continue
+			}
+
+			offset := 0
+			for _, c := range committeeIdxs {
+				size := sizes[slot][c]
+				if seen[slot] == nil {
+					seen[slot] = make(map[uint64][]bool)
+				}
+				if seen[slot][c] == nil {
+					seen[slot][c] = make([]bool, size)
+				}
+				for pos := 0; pos < size; pos++ {
+					if !seen[slot][c][pos] && bitSet(bits, offset+pos) {
+						seen[slot][c][pos] = true
+						participants++
+					}
+				}
+				offset += size
+			}
+		}
+	}
+	return float64(participants) / float64(total), nil
+}
+
+/*
+networkWide :
+Mark an alert about losses of the validators as network-wide if the whole network is degraded: it is labeled with the network state and lowered to info, so it is not paged as a problem of the validators.
+
+params :-
+a. a *alerts.Alert
+Alert to mark. Its labels should not be nil
+
+returns :-
+none
+*/
+func (e *eth2Monitor) networkWide(a *alerts.Alert) {
+	e.networkMu.Lock()
+	state := e.networkState
+	e.networkMu.Unlock()
+	if state == "" || state == NetworkHealthy {
+		return
+	}
+	a.Severity = alerts.Info
+	a.Labels["network_state"] = string(state)
+	a.Message += fmt.Sprintf(NetworkWideMsg, state)
+}
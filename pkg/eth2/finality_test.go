@@ -0,0 +1,134 @@
+package eth2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/posmoni/pkg/eth2/alerts"
+	net "github.com/NethermindEth/posmoni/pkg/eth2/networking"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkHealth(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name          string
+		delay         uint64
+		participation float64
+		known         bool
+		want          NetworkState
+	}{
+		{"Test case 1, usual finality delay", 2, 0.99, true, NetworkHealthy},
+		{"Test case 2, finality delay within the stall epochs", 4, 0.99, true, NetworkHealthy},
+		{"Test case 3, finality stalled", 5, 0.99, true, NetworkFinalityStalled},
+		{"Test case 4, inactivity leak", 6, 0.5, true, NetworkInactivityLeak},
+		{"Test case 5, low participation", 2, 0.75, true, NetworkLowParticipation},
+		{"Test case 6, participation unknown", 2, 0, false, NetworkHealthy},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			monitor := eth2Monitor{
+				settings:           monitorSettings{finalityStallEpochs: 2, minParticipation: 80},
+				participation:      tc.participation,
+				participationKnown: tc.known,
+			}
+			assert.Equal(t, tc.want, monitor.networkHealth(tc.delay))
+		})
+	}
+}
+
+func TestCheckFinality(t *testing.T) {
+	t.Parallel()
+
+	// Epochs of 4 slots. Participation of epoch 1 is checked at epoch 3, from slots 4 to 7
+	genesis := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock, err := NewClock(genesis, 12, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := net.AttestationCheckpoint{Epoch: "1", Root: "0xr4"}
+	tbc := &TestBeaconClient{
+		committees: map[string][]net.Committee{"1": {
+			{Slot: "4", Index: "0", Validators: []string{"1", "2"}},
+			{Slot: "5", Index: "0", Validators: []string{"3", "4"}},
+			{Slot: "6", Index: "0", Validators: []string{"5", "6"}},
+			{Slot: "7", Index: "0", Validators: []string{"7", "8"}},
+		}},
+		blocks: map[string]map[string]net.BeaconBlock{"cl1": {
+			"5": {Slot: "5", ParentRoot: "0xr4", Body: net.BeaconBlockBody{Attestations: []net.Attestation{
+				{AggregationBits: "0x07", Data: net.AttestationData{Slot: "4", Index: "0", Target: target}},
+			}}},
+			"6": {Slot: "6", ParentRoot: "0xr5", Body: net.BeaconBlockBody{Attestations: []net.Attestation{
+				// Already counted
+				{AggregationBits: "0x05", Data: net.AttestationData{Slot: "4", Index: "0", Target: target}},
+				{AggregationBits: "0x05", Data: net.AttestationData{Slot: "5", Index: "0", Target: target}},
+			}}},
+			"8": {Slot: "8", ParentRoot: "0xr6", Body: net.BeaconBlockBody{Attestations: []net.Attestation{
+				// Wrong target
+				{AggregationBits: "0x07", Data: net.AttestationData{Slot: "6", Index: "0", Target: net.AttestationCheckpoint{Epoch: "1", Root: "0xbad"}}},
+			}}},
+		}},
+		finality: &net.FinalityCheckpoints{Finalized: net.AttestationCheckpoint{Epoch: "1"}},
+	}
+	vs, err := newValidatorSet([]string{"1"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	am := &alerterMock{}
+	monitor := &eth2Monitor{
+		beaconClient: tbc,
+		config:       eth2Config{consensus: []string{"cl1"}},
+		settings:     monitorSettings{finalityStallEpochs: 2, minParticipation: 80},
+		alerter:      am,
+		validators:   vs,
+	}
+	tracker := newStateTracker(0, string(NetworkHealthy))
+
+	// 3 of 8 validators attested to the correct target
+	monitor.checkFinality(clock.EpochStart(3), tracker, clock)
+	assert.InDelta(t, 0.375, monitor.participation, 0.0001)
+	sent := am.all()
+	if assert.Len(t, sent, 1) {
+		assert.Equal(t, NetworkHealthAlert, sent[0].Kind)
+		assert.Equal(t, alerts.Warning, sent[0].Severity)
+		assert.Equal(t, map[string]string{"state": string(NetworkLowParticipation), "previous_state": unknownState, "finality_delay": "2", "participation": "37.5%"}, sent[0].Labels)
+	}
+
+	// No finality since epoch 1. Participation of epoch 7 is unavailable and the last one is kept
+	monitor.checkFinality(clock.EpochStart(9), tracker, clock)
+	sent = am.all()
+	if assert.Len(t, sent, 2) {
+		assert.Equal(t, alerts.Critical, sent[1].Severity)
+		assert.Equal(t, string(NetworkInactivityLeak), sent[1].Labels["state"])
+		assert.Equal(t, "37.5%", sent[1].Labels["participation"])
+	}
+
+	// Losses of the validators are network-wide
+	monitor.alertMissedAttestations("1", 3)
+	sent = am.all()
+	if assert.Len(t, sent, 3) {
+		assert.Equal(t, MissedAttestationAlert, sent[2].Kind)
+		assert.Equal(t, alerts.Info, sent[2].Severity)
+		assert.Equal(t, string(NetworkInactivityLeak), sent[2].Labels["network_state"])
+	}
+
+	// Finality back, participation no longer checked against a minimum
+	tbc.finality = &net.FinalityCheckpoints{Finalized: net.AttestationCheckpoint{Epoch: "8"}}
+	monitor.settings.minParticipation = 0
+	monitor.checkFinality(clock.EpochStart(10), tracker, clock)
+	monitor.alertMissedAttestations("1", 1)
+	sent = am.all()
+	if assert.Len(t, sent, 5) {
+		assert.Equal(t, alerts.Info, sent[3].Severity)
+		assert.Equal(t, string(NetworkHealthy), sent[3].Labels["state"])
+		assert.Equal(t, alerts.Warning, sent[4].Severity)
+		assert.NotContains(t, sent[4].Labels, "network_state")
+	}
+
+	// Finality checkpoints unavailable, nothing alerted
+	tbc.finality = nil
+	monitor.checkFinality(clock.EpochStart(11), tracker, clock)
+	assert.Len(t, am.all(), 5)
+}
@@ -0,0 +1,88 @@
+package eth2
+
+import (
+	"strings"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+/*
+BindFlags :
+Add a command line flag for every configuration key to a flag set, and bind them to the configuration. Values are taken from flags first, then from environment variables, config file and default values, in that order.
+
+params :-
+a. flags *pflag.FlagSet
+Flag set to add the flags to. Usually persistent flags of the root command, so every subcommand gets them
+
+returns :-
+a. error
+Error if any
+*/
+func BindFlags(flags *pflag.FlagSet) error {
+	flags.StringSlice(flagName(Validators), nil, "Validators to monitor: indexes, public keys, index ranges (1000-1999) or files. Example: 'posmoni ethereum --validators=269870,1000-1999,/etc/posmoni/validators.txt'")
+	flags.StringSlice(flagName(Consensus), nil, "Consensus endpoints. Example: 'posmoni ethereum --consensus=<endpoint1>,<endpoint2>'")
+	flags.StringSlice(flagName(Execution), nil, "Execution endpoints. Example: 'posmoni ethereum --execution=<endpoint1>,<endpoint2>'")
+	flags.String(flagName(Network), cast.ToString(settingDefaults[Network]), "Network preset: mainnet, sepolia, holesky, gnosis or custom")
+	flags.String(flagName(DBPath), defaultDBPath, "Sqlite database file")
+	flags.Uint64(flagName(MinPeers), cast.ToUint64(settingDefaults[MinPeers]), "Minimum connected peers of a consensus node")
+	flags.Uint64(flagName(MinInboundPeers), cast.ToUint64(settingDefaults[MinInboundPeers]), "Minimum inbound peers of a consensus node. Zero disables the check")
+	flags.Int64(flagName(HealthInterval), cast.ToInt64(settingDefaults[HealthInterval]), "Seconds between node health checks")
+	flags.Int64(flagName(HealthGracePeriod), cast.ToInt64(settingDefaults[HealthGracePeriod]), "Seconds a node health state should last before alerting about it")
+	flags.String(flagName(AlertsWebhook), cast.ToString(settingDefaults[AlertsWebhook]), "Webhook URL to post alerts to. Alerts are only logged if empty")
+	flags.Uint64(flagName(ReorgDepthThreshold), cast.ToUint64(settingDefaults[ReorgDepthThreshold]), "Chain reorgs deeper than this number of slots are alerted")
+	flags.Uint(flagName(MissedAttestationsThreshold), cast.ToUint(settingDefaults[MissedAttestationsThreshold]), "Consecutive missed attestations of a validator before alerting")
+	flags.Uint64(flagName(MinEffectiveBalance), cast.ToUint64(settingDefaults[MinEffectiveBalance]), "Effective balance in ether below which validators are alerted. Zero disables the check")
+	flags.Uint(flagName(MissedRewardsThreshold), cast.ToUint(settingDefaults[MissedRewardsThreshold]), "Percentage of the ideal attestation rewards of an epoch lost by the validators before alerting. Zero disables the check")
+	flags.Uint64(flagName(FinalityStallEpochs), cast.ToUint64(settingDefaults[FinalityStallEpochs]), "Epochs finality can fall behind its usual delay of 2 epochs before alerting")
+	flags.Uint(flagName(MinParticipation), cast.ToUint(settingDefaults[MinParticipation]), "Percentage of the network attesting to the correct target below which the network is degraded. Zero disables the check")
+	flags.StringSlice(flagName(Keymanagers), nil, "Keymanager API URLs of the validator clients. Tokens can only be given in the config file. Example: 'posmoni ethereum --keymanagers=<url1>,<url2>'")
+	flags.Bool(flagName(KeymanagerAutoMonitor), cast.ToBool(settingDefaults[KeymanagerAutoMonitor]), "Monitor keys loaded in the validator clients that are not configured")
+	flags.String(flagName(FeeRecipient), cast.ToString(settingDefaults[FeeRecipient]), "Expected fee recipient of every validator. Not checked if empty")
+	flags.StringToString(flagName(FeeRecipients), nil, "Expected fee recipients by validator index. Example: 'posmoni ethereum --fee-recipients=269870=<address1>,269871=<address2>'")
+	flags.StringSlice(flagName(Relays), nil, "MEV-boost relay URLs to check proposals against. Example: 'posmoni ethereum --relays=<relay1>,<relay2>'")
+	flags.StringSlice(flagName(MevBoost), nil, "mev-boost endpoints of the validator clients, alerted when down. Example: 'posmoni ethereum --mev-boost=http://127.0.0.1:18550'")
+	flags.String("log-level", "", "Log level: panic, fatal, error, warn, info, debug or trace")
+
+	for _, key := range []string{
+		Validators, Consensus, Execution, Network, DBPath, MinPeers, MinInboundPeers, HealthInterval, HealthGracePeriod,
+		AlertsWebhook, ReorgDepthThreshold, MissedAttestationsThreshold, MinEffectiveBalance, MissedRewardsThreshold, FinalityStallEpochs, MinParticipation, Keymanagers, KeymanagerAutoMonitor, FeeRecipient, FeeRecipients,
+		Relays, MevBoost,
+	} {
+		if err := viper.BindPFlag(key, flags.Lookup(flagName(key))); err != nil {
+			return err
+		}
+	}
+	return viper.BindPFlag(LogLevel, flags.Lookup("log-level"))
+}
+
+// flagName : Get the command line flag name of a configuration key, e.g. 'db-path' for DB_PATH
+func flagName(key string) string {
+	return strings.ReplaceAll(strings.ToLower(key), "_", "-")
+}
+
+/*
+DefaultCheckers :
+Get the configuration checkers of the monitor: validators and consensus endpoints are required, execution endpoints are checked only if configured.
+
+params :-
+none
+
+returns :-
+a. []CfgChecker
+Configuration checkers
+*/
+func DefaultCheckers() []CfgChecker {
+	viper.SetEnvPrefix("PM")
+	viper.BindEnv(Execution)
+
+	checkers := []CfgChecker{
+		{Key: Validators, ErrMsg: NoValidatorsFoundError},
+		{Key: Consensus, ErrMsg: NoConsensusFoundError},
+	}
+	if viper.IsSet(Execution) {
+		checkers = append(checkers, CfgChecker{Key: Execution, ErrMsg: NoExecutionFoundError})
+	}
+	return checkers
+}
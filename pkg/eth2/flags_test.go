@@ -0,0 +1,117 @@
+package eth2
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindFlags(t *testing.T) {
+	td := t.TempDir()
+
+	tcs := []struct {
+		name string
+		yml  string
+		env  map[string]string
+		args []string
+		want Config
+	}{
+		{
+			"Test case 1, defaults",
+			"",
+			nil,
+			nil,
+			Config{Validators: []string{}, Consensus: []string{}, Network: CustomNetwork, DBPath: defaultDBPath, MinPeers: 10, HealthInterval: 60, MinEffectiveBalance: 32, MissedRewardsThreshold: 10, FinalityStallEpochs: 2, MinParticipation: 80, KeymanagerAutoMonitor: true},
+		},
+		{
+			"Test case 2, config file over defaults",
+			`
+consensus: "http://file:5052"
+min_peers: 5
+db_path: "/tmp/file.db"
+logs:
+  logLevel: warn`,
+			nil,
+			nil,
+			Config{Validators: []string{}, Consensus: []string{"http://file:5052"}, Network: CustomNetwork, DBPath: "/tmp/file.db", MinPeers: 5, HealthInterval: 60, MinEffectiveBalance: 32, MissedRewardsThreshold: 10, FinalityStallEpochs: 2, MinParticipation: 80, KeymanagerAutoMonitor: true, LogLevel: "warn"},
+		},
+		{
+			"Test case 3, environment variables over config file",
+			`
+consensus: "http://file:5052"
+min_peers: 5
+db_path: "/tmp/file.db"`,
+			map[string]string{"PM_CONSENSUS": "http://env:5052", "PM_MIN_PEERS": "7"},
+			nil,
+			Config{Validators: []string{}, Consensus: []string{"http://env:5052"}, Network: CustomNetwork, DBPath: "/tmp/file.db", MinPeers: 7, HealthInterval: 60, MinEffectiveBalance: 32, MissedRewardsThreshold: 10, FinalityStallEpochs: 2, MinParticipation: 80, KeymanagerAutoMonitor: true},
+		},
+		{
+			"Test case 4, flags over environment variables and config file",
+			`
+consensus: "http://file:5052"
+min_peers: 5
+db_path: "/tmp/file.db"
+keymanager_auto_monitor: true`,
+			map[string]string{"PM_CONSENSUS": "http://env:5052", "PM_MIN_PEERS": "7"},
+			[]string{
+				"--validators=1,2-3", "--consensus=http://flag1:5052,http://flag2:5052", "--execution=http://flag:8545", "--min-peers=3",
+				"--db-path=/tmp/flag.db", "--network=mainnet", "--keymanagers=http://vc1:7500/", "--keymanager-auto-monitor=false", "--log-level=debug",
+				"--min-effective-balance=31", "--missed-rewards-threshold=0", "--finality-stall-epochs=4", "--min-participation=0",
+			},
+			Config{
+				Validators: []string{"1", "2-3"}, Consensus: []string{"http://flag1:5052", "http://flag2:5052"}, Execution: []string{"http://flag:8545"},
+				Network: "mainnet", DBPath: "/tmp/flag.db", MinPeers: 3, HealthInterval: 60, MinEffectiveBalance: 31, FinalityStallEpochs: 4, Keymanagers: []Keymanager{{URL: "http://vc1:7500"}}, LogLevel: "debug",
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			defer cleanInitTestCase()
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			if tc.yml != "" {
+				f, err := setupYML(td, tc.yml)
+				if err != nil {
+					t.Fatal(err)
+				}
+				viper.SetConfigFile(f)
+				if err := viper.ReadInConfig(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			if err := BindFlags(flags); err != nil {
+				t.Fatal(err)
+			}
+			if err := flags.Parse(tc.args); err != nil {
+				t.Fatal(err)
+			}
+
+			got, _ := LoadConfig()
+			// Only the settings under test are compared
+			got.HealthGracePeriod, got.ReorgDepthThreshold, got.MissedAttestationsThreshold = 0, 0, 0
+
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestDefaultCheckers(t *testing.T) {
+	defer cleanInitTestCase()
+
+	keys := func() []string {
+		ks := make([]string, 0)
+		for _, c := range DefaultCheckers() {
+			ks = append(ks, c.Key)
+		}
+		return ks
+	}
+
+	assert.Equal(t, []string{Validators, Consensus}, keys())
+	t.Setenv("PM_EXECUTION", "http://env:8545")
+	assert.Equal(t, []string{Validators, Consensus, Execution}, keys())
+}
@@ -0,0 +1,297 @@
+package eth2
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+)
+
+// GroupThresholds : Struct Represent alert thresholds of a validator group. Zero values fall back to the monitor settings
+type GroupThresholds struct {
+	// Consecutive missed attestations before alerting
+	MissedAttestations uint `yaml:"missed_attestations,omitempty"`
+	// Effective balance in ether below which validators are alerted
+	MinEffectiveBalance uint64 `yaml:"min_effective_balance,omitempty"`
+	// Percentage of the ideal attestation rewards of an epoch lost by the validators of the group before alerting. The group is then alerted on its own
+	MissedRewards uint `yaml:"missed_rewards,omitempty"`
+}
+
+// ValidatorGroup : Struct Represent a named group of validators sharing labels and alert thresholds
+type ValidatorGroup struct {
+	Name string `yaml:"name"`
+	// Arbitrary labels such as operator, client, datacenter or customer
+	Labels map[string]string `yaml:"labels,omitempty"`
+	// Validator addresses or public indexes of the group
+	Validators []string        `yaml:"validators"`
+	Thresholds GroupThresholds `yaml:"thresholds,omitempty"`
+	// Expected fee recipient of the validators, overriding the global one
+	FeeRecipient string `yaml:"fee_recipient,omitempty"`
+}
+
+/*
+checkValidators :
+Get validators from config file or enviroment variables. Besides a plain list, the validators key accepts a list mixing validators and named groups of validators with labels and thresholds.
+
+params :-
+a. errMsg string
+Error message to be returned if no validator is set
+
+returns :-
+a. []string
+Every validator, grouped or not
+b. []ValidatorGroup
+Validator groups. Nil if no group was configured
+c. error
+Error if any
+*/
+func checkValidators(errMsg string) ([]string, []ValidatorGroup, error) {
+	items, ok := viper.Get(Validators).([]any)
+	if !ok || !hasGroups(items) {
+		data, err := checkVariable(Validators, errMsg)
+		return data, nil, err
+	}
+
+	validators := make([]string, 0)
+	groups := make([]ValidatorGroup, 0)
+	seen := make(map[string]bool)
+	for _, item := range items {
+		raw, ok := asMap(item)
+		if !ok {
+			v := cast.ToString(item)
+			if seen[v] {
+				return nil, nil, fmt.Errorf(DuplicatedValidatorError, v)
+			}
+			seen[v] = true
+			validators = append(validators, v)
+			continue
+		}
+
+		g, err := parseGroup(raw)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, v := range g.Validators {
+			if seen[v] {
+				return nil, nil, fmt.Errorf(DuplicatedValidatorError, v)
+			}
+			seen[v] = true
+		}
+		validators = append(validators, g.Validators...)
+		groups = append(groups, g)
+	}
+
+	if len(validators) == 0 {
+		return nil, nil, fmt.Errorf(errMsg)
+	}
+	return validators, groups, nil
+}
+
+// hasGroups : Check if a list of validators has any group
+func hasGroups(items []any) bool {
+	for _, item := range items {
+		if _, ok := asMap(item); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// asMap : Get a config file item as a map. Depending on the decoder, maps come with string or any keys
+func asMap(item any) (map[string]any, bool) {
+	switch m := item.(type) {
+	case map[string]any:
+		return m, true
+	case map[any]any:
+		return cast.ToStringMap(m), true
+	default:
+		return nil, false
+	}
+}
+
+/*
+parseGroup :
+Decode a validator group from the config file. Expected keys are name, labels, validators, thresholds and fee_recipient.
+
+params :-
+a. raw map[string]any
+Group as read from the config file
+
+returns :-
+a. ValidatorGroup
+Validator group
+b. error
+Error if the group has no name or no validators
+*/
+func parseGroup(raw map[string]any) (ValidatorGroup, error) {
+	g := ValidatorGroup{
+		Name:   cast.ToString(raw["name"]),
+		Labels: cast.ToStringMapString(raw["labels"]),
+	}
+	if g.Name == "" {
+		return g, fmt.Errorf(GroupNameError, raw)
+	}
+
+	switch v := raw["validators"].(type) {
+	case string:
+		g.Validators = strings.Split(v, ",")
+	default:
+		g.Validators = cast.ToStringSlice(v)
+	}
+	if len(g.Validators) == 0 || g.Validators[0] == "" {
+		return g, fmt.Errorf(EmptyGroupError, g.Name)
+	}
+
+	thresholds := cast.ToStringMap(raw["thresholds"])
+	g.Thresholds.MissedAttestations = cast.ToUint(thresholds["missed_attestations"])
+	g.Thresholds.MinEffectiveBalance = cast.ToUint64(thresholds["min_effective_balance"])
+	g.Thresholds.MissedRewards = cast.ToUint(thresholds["missed_rewards"])
+	g.FeeRecipient = cast.ToString(raw["fee_recipient"])
+
+	return g, nil
+}
+
+/*
+groupLabels :
+Add the group name and labels of a validator to a set of labels. Labels already set are kept.
+
+params :-
+a. labels map[string]string
+Labels to add to. Can be nil
+b. validator string
+Validator address or public index
+
+returns :-
+a. map[string]string
+Labels with the group labels
+*/
+func (e *eth2Monitor) groupLabels(labels map[string]string, validator string) map[string]string {
+	g, ok := e.validators.Group(validator)
+	if !ok {
+		return labels
+	}
+
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	if _, set := labels["group"]; !set {
+		labels["group"] = g.Name
+	}
+	for k, v := range g.Labels {
+		if _, set := labels[k]; !set {
+			labels[k] = v
+		}
+	}
+	return labels
+}
+
+/*
+missedAttestationsThreshold :
+Get the consecutive missed attestations that should be alerted for a validator, using the threshold of its group if any.
+
+params :-
+a. validator string
+Validator address or public index
+
+returns :-
+a. uint
+Consecutive missed attestations threshold
+*/
+func (e *eth2Monitor) missedAttestationsThreshold(validator string) uint {
+	if g, ok := e.validators.Group(validator); ok && g.Thresholds.MissedAttestations > 0 {
+		return g.Thresholds.MissedAttestations
+	}
+	return e.settings.missedAttestationsThreshold
+}
+
+/*
+minEffectiveBalance :
+Get the effective balance below which a validator should be alerted, using the threshold of its group if any.
+
+params :-
+a. validator string
+Validator public index
+
+returns :-
+a. uint64
+Minimum effective balance in gwei. Zero if the check is disabled
+*/
+func (e *eth2Monitor) minEffectiveBalance(validator string) uint64 {
+	if g, ok := e.validators.Group(validator); ok && g.Thresholds.MinEffectiveBalance > 0 {
+		return g.Thresholds.MinEffectiveBalance * gweiPerEth
+	}
+	return e.settings.minEffectiveBalance
+}
+
+/*
+expectedFeeRecipient :
+Get the fee recipient a validator should use, from the validator fee recipients, the one of its group or the global one, in that order.
+
+params :-
+a. validator string
+Validator public index
+
+returns :-
+a. string
+Expected fee recipient in lower case. Empty if none is configured
+*/
+func (e *eth2Monitor) expectedFeeRecipient(validator string) string {
+	if r, ok := e.settings.feeRecipients[validator]; ok {
+		return r
+	}
+	if g, ok := e.validators.Group(validator); ok && g.FeeRecipient != "" {
+		return strings.ToLower(g.FeeRecipient)
+	}
+	return e.settings.feeRecipient
+}
+
+/*
+encodeLabels :
+Encode labels as sorted 'key=value' pairs separated by commas, to persist them.
+
+params :-
+a. labels map[string]string
+Labels to encode
+
+returns :-
+a. string
+Encoded labels
+*/
+func encodeLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+/*
+validatorFields :
+Add a validator, its group and its group labels to a set of log fields.
+
+params :-
+a. fields log.Fields
+Log fields to add to. They are not modified
+b. validator string
+Validator address or public index
+
+returns :-
+a. log.Fields
+Log fields with the validator and group fields
+*/
+func (e *eth2Monitor) validatorFields(fields log.Fields, validator string) log.Fields {
+	vFields := log.Fields{"Validator": validator}
+	for k, v := range fields {
+		vFields[k] = v
+	}
+	for k, v := range e.groupLabels(nil, validator) {
+		if _, set := vFields[k]; !set {
+			vFields[k] = v
+		}
+	}
+	return vFields
+}
@@ -0,0 +1,169 @@
+package eth2
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/NethermindEth/posmoni/internal/utils"
+	"github.com/NethermindEth/posmoni/pkg/eth2/alerts"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckValidators(t *testing.T) {
+	td := t.TempDir()
+
+	tcs := []struct {
+		name       string
+		yml        string
+		want       []string
+		wantGroups []ValidatorGroup
+		isError    bool
+	}{
+		{
+			"Test case 1, plain list",
+			`validators: [1, 2, "0x1414fa980b"]`,
+			[]string{"1", "2", "0x1414fa980b"},
+			nil,
+			false,
+		},
+		{
+			"Test case 2, groups and ungrouped validators",
+			`
+validators:
+  - 1
+  - name: acme
+    labels:
+      customer: acme
+      client: lighthouse
+    validators: [2, 3]
+    thresholds:
+      missed_attestations: 3
+      min_effective_balance: 31
+      missed_rewards: 5
+  - name: infra
+    validators: "4,5"
+    fee_recipient: "0xabcf8e0d4e9587369b2301d0790347320302cc09"`,
+			[]string{"1", "2", "3", "4", "5"},
+			[]ValidatorGroup{
+				{Name: "acme", Labels: map[string]string{"customer": "acme", "client": "lighthouse"}, Validators: []string{"2", "3"}, Thresholds: GroupThresholds{MissedAttestations: 3, MinEffectiveBalance: 31, MissedRewards: 5}},
+				{Name: "infra", Labels: map[string]string{}, Validators: []string{"4", "5"}, FeeRecipient: "0xabcf8e0d4e9587369b2301d0790347320302cc09"},
+			},
+			false,
+		},
+		{
+			"Test case 3, group without name",
+			`
+validators:
+  - validators: [2, 3]`,
+			nil,
+			nil,
+			true,
+		},
+		{
+			"Test case 4, group without validators",
+			`
+validators:
+  - name: acme`,
+			nil,
+			nil,
+			true,
+		},
+		{
+			"Test case 5, validator in two groups",
+			`
+validators:
+  - name: acme
+    validators: [2, 3]
+  - name: infra
+    validators: [3, 4]`,
+			nil,
+			nil,
+			true,
+		},
+		{
+			"Test case 6, grouped validator also ungrouped",
+			`
+validators:
+  - 3
+  - name: acme
+    validators: [2, 3]`,
+			nil,
+			nil,
+			true,
+		},
+		{
+			"Test case 7, no validators",
+			`consensus: "http://153.168.127.111:5052"`,
+			nil,
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := setupYML(td, tc.yml)
+			if err != nil {
+				t.Fatal(err)
+			}
+			viper.SetConfigFile(f)
+			if err := viper.ReadInConfig(); err != nil {
+				t.Fatal(err)
+			}
+			defer cleanInitTestCase()
+
+			got, gotGroups, err := checkValidators(NoValidatorsFoundError)
+
+			descr := fmt.Sprintf("checkValidators() with yml %s", tc.yml)
+			if err = utils.CheckErr(descr, tc.isError, err); err != nil {
+				t.Fatal(err)
+			}
+			if !tc.isError {
+				assert.Equal(t, tc.want, got, descr)
+				assert.Equal(t, tc.wantGroups, gotGroups, descr)
+			}
+		})
+	}
+}
+
+func TestGroupLabels(t *testing.T) {
+	t.Parallel()
+
+	groups := []ValidatorGroup{
+		{Name: "acme", Labels: map[string]string{"customer": "acme", "client": "teku"}, Validators: []string{"2", "3"}, Thresholds: GroupThresholds{MissedAttestations: 3, MinEffectiveBalance: 31}},
+		{Name: "infra", Validators: []string{"4"}},
+	}
+	vs, err := newValidatorSet([]string{"1", "2", "3", "4"}, groups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	monitor := eth2Monitor{
+		validators: vs,
+		settings:   monitorSettings{missedAttestationsThreshold: 1, minEffectiveBalance: 32 * gweiPerEth},
+		alerter:    &alerterMock{},
+	}
+
+	assert.Equal(t, map[string]string{"validator": "2", "group": "acme", "customer": "acme", "client": "teku"}, monitor.groupLabels(map[string]string{"validator": "2"}, "2"))
+	assert.Equal(t, map[string]string{"group": "infra"}, monitor.groupLabels(nil, "4"))
+	// Labels already set are kept
+	assert.Equal(t, map[string]string{"client": "nimbus", "group": "acme", "customer": "acme"}, monitor.groupLabels(map[string]string{"client": "nimbus"}, "3"))
+	// Ungrouped validators get no labels
+	assert.Nil(t, monitor.groupLabels(nil, "1"))
+
+	assert.Equal(t, uint(3), monitor.missedAttestationsThreshold("2"))
+	assert.Equal(t, uint(1), monitor.missedAttestationsThreshold("4"))
+	assert.Equal(t, uint(1), monitor.missedAttestationsThreshold("1"))
+	assert.Equal(t, uint64(31*gweiPerEth), monitor.minEffectiveBalance("2"))
+	assert.Equal(t, uint64(32*gweiPerEth), monitor.minEffectiveBalance("4"))
+
+	assert.Equal(t, "client=teku,customer=acme", encodeLabels(map[string]string{"customer": "acme", "client": "teku"}))
+	assert.Equal(t, "", encodeLabels(nil))
+
+	monitor.alertMissedAttestations("2", 3)
+	sent := monitor.alerter.(*alerterMock).all()
+	assert.Len(t, sent, 1)
+	assert.Equal(t, MissedAttestationAlert, sent[0].Kind)
+	assert.Equal(t, alerts.Warning, sent[0].Severity)
+	assert.Equal(t, "acme", sent[0].Labels["customer"])
+}
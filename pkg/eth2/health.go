@@ -0,0 +1,164 @@
+package eth2
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/NethermindEth/posmoni/configs"
+	"github.com/NethermindEth/posmoni/pkg/eth2/alerts"
+	log "github.com/sirupsen/logrus"
+)
+
+/*
+TrackHealth :
+Periodically check health of consensus and execution nodes.
+
+params :-
+a. done <-chan struct{}
+Channel to get stop signal from
+b. beaconEndpoints []string
+Consensus endpoints to check
+c. executionEndpoints []string
+Execution endpoints to check
+d. wait time.Duration
+Time between checks
+
+returns :-
+a. <-chan EndpointHealthStatus
+Channel to get health state of every endpoint after each check
+*/
+func (e *eth2Monitor) TrackHealth(done <-chan struct{}, beaconEndpoints, executionEndpoints []string, wait time.Duration) <-chan EndpointHealthStatus {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "TrackHealth"}
+	c := make(chan EndpointHealthStatus, len(executionEndpoints)+len(beaconEndpoints))
+	var w time.Duration
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				close(c)
+				return
+			case <-time.After(w):
+				// Don't wait the first time
+				w = wait
+
+				if len(beaconEndpoints) > 0 {
+					log.WithFields(logFields).Debug("Checking health of consensus nodes...")
+					for _, s := range e.NodesStatus(beaconEndpoints) {
+						c <- EndpointHealthStatus{Endpoint: s.Endpoint, Layer: ConsensusLayer, State: consensusState(s), Error: s.Error}
+					}
+				}
+
+				if len(executionEndpoints) > 0 {
+					log.WithFields(logFields).Debug("Checking health of execution nodes...")
+					for _, s := range e.executionClient.SyncStatus(executionEndpoints) {
+						state := NodeHealthy
+						if s.Error != nil {
+							state = NodeDown
+						} else if s.IsSyncing {
+							state = NodeSyncing
+						}
+						c <- EndpointHealthStatus{Endpoint: s.Endpoint, Layer: ExecutionLayer, State: state, Error: s.Error}
+					}
+				}
+			}
+		}
+	}()
+
+	return c
+}
+
+/*
+consensusState :
+Map the status of a consensus node to its health state.
+
+params :-
+a. s NodeStatus
+Status of the node
+
+returns :-
+a. NodeState
+Health state of the node
+*/
+func consensusState(s NodeStatus) NodeState {
+	switch {
+	case s.Syncing:
+		return NodeSyncing
+	case !s.Healthy:
+		return NodeDown
+	case s.LowPeers:
+		return NodeDegraded
+	default:
+		return NodeHealthy
+	}
+}
+
+/*
+healthAlert :
+Build the alert describing a health state transition of an endpoint.
+
+params :-
+a. s EndpointHealthStatus
+Health state of the endpoint
+b. tr transition
+State transition of the endpoint
+c. now time.Time
+Time of the health check
+
+returns :-
+a. alerts.Alert
+Alert describing the transition
+*/
+func healthAlert(s EndpointHealthStatus, tr transition, now time.Time) alerts.Alert {
+	severity := alerts.Warning
+	switch NodeState(tr.to) {
+	case NodeHealthy:
+		severity = alerts.Info
+	case NodeDown:
+		severity = alerts.Critical
+	}
+
+	msg := fmt.Sprintf(NodeStateTransitionMsg, s.Layer, s.Endpoint, tr.from, tr.to, tr.lasted)
+	if s.Error != nil {
+		msg = fmt.Sprintf("%s. Error: %v", msg, s.Error)
+	}
+
+	return alerts.Alert{
+		Kind:     NodeHealthAlert,
+		Severity: severity,
+		Source:   s.Endpoint,
+		Message:  msg,
+		Labels:   map[string]string{"layer": s.Layer, "state": tr.to, "previous_state": tr.from},
+		Time:     now,
+	}
+}
+
+/*
+alertHealthTransitions :
+Raise alerts on health state transitions of endpoints.
+
+params :-
+a. statuses <-chan EndpointHealthStatus
+Channel to get health states from
+b. tracker *stateTracker
+Tracker to debounce health states with
+
+returns :-
+none
+*/
+func (e *eth2Monitor) alertHealthTransitions(statuses <-chan EndpointHealthStatus, tracker *stateTracker) {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "alertHealthTransitions"}
+
+	for s := range statuses {
+		log.WithFields(logFields).Debugf("Endpoint %s (%s) is %s", s.Endpoint, s.Layer, s.State)
+
+		now := time.Now()
+		tr, ok := tracker.observe(s.Endpoint, string(s.State), now)
+		if !ok {
+			continue
+		}
+		if err := e.alerter.Send(healthAlert(s, tr, now)); err != nil {
+			log.WithFields(logFields).Errorf(SendAlertError, err)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package eth2
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/posmoni/pkg/eth2/alerts"
+	net "github.com/NethermindEth/posmoni/pkg/eth2/networking"
+	"github.com/stretchr/testify/assert"
+)
+
+// Mock of alerts.Alerter
+type alerterMock struct {
+	mu   sync.Mutex
+	sent []alerts.Alert
+}
+
+func (am *alerterMock) Send(alert alerts.Alert) error {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+	am.sent = append(am.sent, alert)
+	return nil
+}
+
+// Alerts sent so far
+func (am *alerterMock) all() []alerts.Alert {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+	return append([]alerts.Alert{}, am.sent...)
+}
+
+func TestHealthTracker(t *testing.T) {
+	t.Parallel()
+
+	type observation struct {
+		state NodeState
+		// seconds since start
+		at int
+	}
+
+	tcs := []struct {
+		name         string
+		grace        time.Duration
+		observations []observation
+		// wanted transitions as target states
+		want []NodeState
+	}{
+		{
+			"Test case 1, healthy from the start, no alerts",
+			time.Minute,
+			[]observation{{NodeHealthy, 0}, {NodeHealthy, 60}, {NodeHealthy, 120}},
+			[]NodeState{},
+		},
+		{
+			"Test case 2, down from the start, alert after grace period",
+			time.Minute,
+			[]observation{{NodeDown, 0}, {NodeDown, 30}, {NodeDown, 60}, {NodeDown, 90}},
+			[]NodeState{NodeDown},
+		},
+		{
+			"Test case 3, flapping shorter than grace period, no alerts",
+			time.Minute,
+			[]observation{{NodeHealthy, 0}, {NodeDown, 30}, {NodeHealthy, 60}, {NodeDown, 90}, {NodeHealthy, 120}},
+			[]NodeState{},
+		},
+		{
+			"Test case 4, healthy to syncing to down to healthy",
+			time.Minute,
+			[]observation{
+				{NodeHealthy, 0},
+				{NodeSyncing, 60}, {NodeSyncing, 120},
+				{NodeDown, 180}, {NodeDown, 240},
+				{NodeHealthy, 300}, {NodeHealthy, 360},
+			},
+			[]NodeState{NodeSyncing, NodeDown, NodeHealthy},
+		},
+		{
+			"Test case 5, no grace period, every change alerts",
+			0,
+			[]observation{{NodeHealthy, 0}, {NodeDegraded, 1}, {NodeHealthy, 2}},
+			[]NodeState{NodeDegraded, NodeHealthy},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			tracker := newStateTracker(tc.grace, string(NodeHealthy))
+			start := time.Now()
+
+			got := make([]NodeState, 0)
+			for _, o := range tc.observations {
+				now := start.Add(time.Duration(o.at) * time.Second)
+				s := EndpointHealthStatus{Endpoint: "1", Layer: ConsensusLayer, State: o.state}
+				tr, ok := tracker.observe(s.Endpoint, string(s.State), now)
+				if ok {
+					alert := healthAlert(s, tr, now)
+					assert.Equal(t, NodeHealthAlert, alert.Kind)
+					assert.Equal(t, "1", alert.Source)
+					got = append(got, NodeState(alert.Labels["state"]))
+				}
+			}
+
+			assert.Equal(t, tc.want, got, "observe() gave wrong transitions")
+		})
+	}
+}
+
+func TestTrackHealth(t *testing.T) {
+	t.Parallel()
+
+	tbc := newTestBeaconClient(nil, nil)
+	tbc.niData = []net.NodeInfo{
+		{Endpoint: "cl1", Healthy: true, PeerCount: net.PeerCount{Connected: "50"}},
+		{Endpoint: "cl2", Healthy: true, PeerCount: net.PeerCount{Connected: "1"}},
+		{Endpoint: "cl3", Syncing: true},
+		{Endpoint: "cl4", Error: errors.New("")},
+	}
+	monitor := eth2Monitor{
+		beaconClient: tbc,
+		executionClient: newTestExecutionClient([][]net.ExecutionSyncingStatus{
+			{
+				{Endpoint: "el1"},
+				{Endpoint: "el2", IsSyncing: true},
+				{Endpoint: "el3", Error: errors.New("")},
+			},
+		}),
+		settings: monitorSettings{minPeers: 10},
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	result := monitor.TrackHealth(done, []string{"cl1", "cl2", "cl3", "cl4"}, []string{"el1", "el2", "el3"}, time.Hour)
+
+	got := make([]EndpointHealthStatus, 0)
+	for i := 0; i < 7; i++ {
+		got = append(got, <-result)
+	}
+
+	want := []EndpointHealthStatus{
+		{Endpoint: "cl1", Layer: ConsensusLayer, State: NodeHealthy},
+		{Endpoint: "cl2", Layer: ConsensusLayer, State: NodeDegraded},
+		{Endpoint: "cl3", Layer: ConsensusLayer, State: NodeSyncing},
+		{Endpoint: "cl4", Layer: ConsensusLayer, State: NodeDown, Error: errors.New("")},
+		{Endpoint: "el1", Layer: ExecutionLayer, State: NodeHealthy},
+		{Endpoint: "el2", Layer: ExecutionLayer, State: NodeSyncing},
+		{Endpoint: "el3", Layer: ExecutionLayer, State: NodeDown, Error: errors.New("")},
+	}
+	assert.Equal(t, want, got, "TrackHealth() gave wrong results")
+}
+
+func TestAlertHealthTransitions(t *testing.T) {
+	t.Parallel()
+
+	am := &alerterMock{}
+	monitor := eth2Monitor{alerter: am}
+
+	statuses := make(chan EndpointHealthStatus, 3)
+	statuses <- EndpointHealthStatus{Endpoint: "1", Layer: ExecutionLayer, State: NodeHealthy}
+	statuses <- EndpointHealthStatus{Endpoint: "1", Layer: ExecutionLayer, State: NodeDown, Error: errors.New("connection refused")}
+	statuses <- EndpointHealthStatus{Endpoint: "2", Layer: ConsensusLayer, State: NodeHealthy}
+	close(statuses)
+
+	monitor.alertHealthTransitions(statuses, newStateTracker(0, string(NodeHealthy)))
+
+	sent := am.all()
+	assert.Len(t, sent, 1)
+	assert.Equal(t, alerts.Critical, sent[0].Severity)
+	assert.Equal(t, "1", sent[0].Source)
+	assert.Contains(t, sent[0].Message, "connection refused")
+}
@@ -15,6 +15,8 @@ type CfgChecker struct {
 	ErrMsg string
 	// Configuration data. Should be pre-populated when is not desired to use config file or enviroment variables to get configuration data for 'Key'.
 	Data []string
+	// Validator groups. Only used with 'Validators' key, pre-populated groups should have their validators in 'Data' too
+	Groups []ValidatorGroup
 }
 
 /*
@@ -29,7 +31,13 @@ a. error
 Error if any
 */
 func (cc *CfgChecker) checker() error {
-	if len(cc.Data) == 0 {
+	if len(cc.Data) == 0 && cc.Key == Validators {
+		d, g, err := checkValidators(cc.ErrMsg)
+		if err != nil {
+			return err
+		}
+		cc.Data, cc.Groups = d, g
+	} else if len(cc.Data) == 0 {
 		d, err := checkVariable(cc.Key, cc.ErrMsg)
 		if err != nil {
 			return err
@@ -70,6 +78,7 @@ func Init(checkers []CfgChecker) (cfg eth2Config, err error) {
 			cfg.consensus = c.Data
 		case Validators:
 			cfg.validators = c.Data
+			cfg.groups = c.Groups
 		default:
 			// execution should never go here, checker() should fail if an invalid key was provided
 			return cfg, fmt.Errorf(InvalidConfigKeyError, c.Key, []string{Execution, Consensus, Validators})
@@ -0,0 +1,334 @@
+package eth2
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/NethermindEth/posmoni/configs"
+	"github.com/NethermindEth/posmoni/pkg/eth2/alerts"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+)
+
+/*
+loadKeymanagers :
+Get the keymanager APIs of the validator clients from config file or enviroment variables. Keymanagers can be given as a list of URLs, or as a list of maps with url, token and token_file keys.
+
+params :-
+none
+
+returns :-
+a. []Keymanager
+Keymanager APIs. Empty if none is configured
+b. error
+Error if any keymanager has no URL
+*/
+func loadKeymanagers() ([]Keymanager, error) {
+	viper.BindEnv(Keymanagers)
+
+	var items []any
+	switch v := viper.Get(Keymanagers).(type) {
+	case nil:
+		return nil, nil
+	case string:
+		for _, url := range strings.Split(v, ",") {
+			if url = strings.TrimSpace(url); url != "" {
+				items = append(items, url)
+			}
+		}
+	case []any:
+		items = v
+	case []string:
+		// Given with command line flags
+		if len(v) == 0 {
+			return nil, nil
+		}
+		for _, url := range v {
+			items = append(items, url)
+		}
+	default:
+		return nil, fmt.Errorf(KeymanagerConfigError, v)
+	}
+
+	keymanagers := make([]Keymanager, 0, len(items))
+	for _, item := range items {
+		km := Keymanager{URL: cast.ToString(item)}
+		if raw, ok := asMap(item); ok {
+			km = Keymanager{
+				URL:       cast.ToString(raw["url"]),
+				Token:     cast.ToString(raw["token"]),
+				TokenFile: cast.ToString(raw["token_file"]),
+			}
+		}
+		if km.URL == "" {
+			return nil, fmt.Errorf(KeymanagerConfigError, item)
+		}
+		km.URL = strings.TrimSuffix(km.URL, "/")
+		keymanagers = append(keymanagers, km)
+	}
+	return keymanagers, nil
+}
+
+/*
+token :
+Get the bearer token of the keymanager API. Token files are read on every call, so rotated tokens are picked up.
+
+params :-
+none
+
+returns :-
+a. string
+Bearer token
+b. error
+Error if the token file could not be read
+*/
+func (k Keymanager) token() (string, error) {
+	if k.Token != "" || k.TokenFile == "" {
+		return k.Token, nil
+	}
+	data, err := os.ReadFile(k.TokenFile)
+	if err != nil {
+		return "", fmt.Errorf(KeymanagerTokenError, k.URL, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// normalizePubkey : Get a public key in lower case with 0x prefix
+func normalizePubkey(pubkey string) string {
+	return "0x" + strings.TrimPrefix(strings.ToLower(strings.TrimSpace(pubkey)), "0x")
+}
+
+/*
+loadedKeys :
+Get the keys loaded in the validator clients, both local keystores and remote signer keys.
+
+params :-
+none
+
+returns :-
+a. map[string][]string
+Keymanager URLs of the validator clients every public key is loaded in
+b. []error
+Errors of the keymanagers that could not be checked
+*/
+func (e *eth2Monitor) loadedKeys() (map[string][]string, []error) {
+	loaded := make(map[string][]string)
+	errs := make([]error, 0)
+
+	for _, km := range e.settings.keymanagers {
+		token, err := km.token()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		keystores, err := e.keymanagerClient.Keystores(km.URL, token)
+		if err != nil {
+			errs = append(errs, fmt.Errorf(KeymanagerRequestError, km.URL, err))
+			continue
+		}
+		remoteKeys, err := e.keymanagerClient.RemoteKeys(km.URL, token)
+		if err != nil {
+			errs = append(errs, fmt.Errorf(KeymanagerRequestError, km.URL, err))
+			continue
+		}
+
+		keys := make(map[string]bool)
+		for _, k := range keystores {
+			keys[normalizePubkey(k.ValidatingPubkey)] = true
+		}
+		for _, k := range remoteKeys {
+			keys[normalizePubkey(k.Pubkey)] = true
+		}
+		for k := range keys {
+			loaded[k] = append(loaded[k], km.URL)
+		}
+	}
+	return loaded, errs
+}
+
+/*
+configuredKeys :
+Get the public keys of the configured validators. Validator indexes are resolved with the consensus node, indexes unknown to it are skipped.
+
+params :-
+none
+
+returns :-
+a. map[string]string
+Configured validator of every public key
+b. error
+Error if the validator indexes could not be resolved
+*/
+func (e *eth2Monitor) configuredKeys() (map[string]string, error) {
+	keys := make(map[string]string)
+	idxs := make([]string, 0)
+	for _, v := range e.validators.Configured() {
+		if indexRegex.MatchString(v) {
+			idxs = append(idxs, v)
+		} else {
+			keys[normalizePubkey(v)] = v
+		}
+	}
+	if len(idxs) == 0 {
+		return keys, nil
+	}
+
+	infos, err := e.beaconClient.Validators("head", idxs)
+	if err != nil {
+		return nil, fmt.Errorf(ResolveValidatorsError, err)
+	}
+	for _, info := range infos {
+		keys[normalizePubkey(info.Validator.Pubkey)] = info.Index
+	}
+	return keys, nil
+}
+
+/*
+CheckKeymanagers :
+Compare the monitored validators against the keys loaded in the validator clients. Monitored keys that no validator client has loaded are only reported when every keymanager could be checked, and loaded keys are only reported as not monitored when the configured validators could be resolved.
+
+params :-
+none
+
+returns :-
+a. KeyReport
+State of every key, sorted by public key
+*/
+func (e *eth2Monitor) CheckKeymanagers() KeyReport {
+	loaded, errs := e.loadedKeys()
+	report := KeyReport{Keys: make([]KeyStatus, 0), Errors: errs}
+
+	configured, err := e.configuredKeys()
+	if err != nil {
+		report.Errors = append(report.Errors, err)
+	}
+
+	for pk, clients := range loaded {
+		v, ok := configured[pk]
+		switch {
+		case len(clients) > 1:
+			if !ok {
+				v = pk
+			}
+			report.Keys = append(report.Keys, KeyStatus{Pubkey: pk, Validator: v, State: KeyLoadedTwice, Clients: clients})
+		case ok:
+			report.Keys = append(report.Keys, KeyStatus{Pubkey: pk, Validator: v, State: KeyLoaded, Clients: clients})
+		case configured != nil:
+			report.Keys = append(report.Keys, KeyStatus{Pubkey: pk, Validator: pk, State: KeyNotMonitored, Clients: clients})
+		}
+	}
+
+	if len(errs) == 0 {
+		for pk, v := range configured {
+			if _, ok := loaded[pk]; !ok {
+				report.Keys = append(report.Keys, KeyStatus{Pubkey: pk, Validator: v, State: KeyNotLoaded, Clients: []string{}})
+			}
+		}
+	}
+
+	sort.Slice(report.Keys, func(i, j int) bool { return report.Keys[i].Pubkey < report.Keys[j].Pubkey })
+	return report
+}
+
+/*
+TrackKeymanagers :
+Periodically compare the monitored validators against the keys loaded in the validator clients, and raise alerts on key state changes. Keys loaded but not configured are monitored when the keymanager auto monitor setting is enabled.
+
+params :-
+a. done <-chan struct{}
+Channel to get stop signal from
+b. wait time.Duration
+Time between checks
+c. tracker *stateTracker
+Tracker to debounce key states with
+
+returns :-
+none
+*/
+func (e *eth2Monitor) TrackKeymanagers(done <-chan struct{}, wait time.Duration, tracker *stateTracker) {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "TrackKeymanagers"}
+
+	var w time.Duration
+	for {
+		select {
+		case <-done:
+			return
+		case <-time.After(w):
+			// Don't wait the first time
+			w = wait
+			report := e.CheckKeymanagers()
+			for _, err := range report.Errors {
+				log.WithFields(logFields).Error(err)
+			}
+
+			autoMonitored := make([]string, 0)
+			now := time.Now()
+			for _, k := range report.Keys {
+				state := k.State
+				if state == KeyNotMonitored && e.settings.keymanagerAutoMonitor {
+					autoMonitored = append(autoMonitored, k.Pubkey)
+					state = KeyLoaded
+				}
+
+				tr, ok := tracker.observe(k.Pubkey, string(state), now)
+				if !ok {
+					continue
+				}
+				if err := e.alerter.Send(e.keyDriftAlert(k, tr, now)); err != nil {
+					log.WithFields(logFields).Errorf(SendAlertError, err)
+				}
+			}
+
+			// Keep monitoring loaded keys while their keymanager can't be checked
+			if e.settings.keymanagerAutoMonitor && len(report.Errors) == 0 {
+				if len(autoMonitored) > 0 {
+					log.WithFields(logFields).Infof("Monitoring %d keys loaded in validator clients that are not configured", len(autoMonitored))
+				}
+				e.validators.SetLoaded(autoMonitored)
+			}
+		}
+	}
+}
+
+/*
+keyDriftAlert :
+Build the alert describing a state transition of a validator key, labeled with the group of the validator.
+
+params :-
+a. k KeyStatus
+State of the key
+b. tr transition
+State transition of the key
+c. now time.Time
+Time of the check
+
+returns :-
+a. alerts.Alert
+Alert describing the transition
+*/
+func (e *eth2Monitor) keyDriftAlert(k KeyStatus, tr transition, now time.Time) alerts.Alert {
+	severity := alerts.Critical
+	switch KeyState(tr.to) {
+	case KeyLoaded:
+		severity = alerts.Info
+	case KeyNotMonitored:
+		severity = alerts.Warning
+	}
+
+	labels := map[string]string{"pubkey": k.Pubkey, "state": tr.to, "previous_state": tr.from}
+	if k.Validator != k.Pubkey {
+		labels["validator"] = k.Validator
+	}
+	return alerts.Alert{
+		Kind:     KeyDriftAlert,
+		Severity: severity,
+		Source:   k.Pubkey,
+		Message:  fmt.Sprintf(KeyDriftMsg, k.Pubkey, k.Validator, tr.from, tr.to, tr.lasted, strings.Join(k.Clients, ",")),
+		Labels:   e.groupLabels(labels, k.Validator),
+		Time:     now,
+	}
+}
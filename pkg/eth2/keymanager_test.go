@@ -0,0 +1,268 @@
+package eth2
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/posmoni/internal/utils"
+	"github.com/NethermindEth/posmoni/pkg/eth2/alerts"
+	net "github.com/NethermindEth/posmoni/pkg/eth2/networking"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+type keymanagerMock struct {
+	mu sync.Mutex
+	// keystores and remote keys by endpoint. Missing endpoints fail
+	keystores  map[string][]net.Keystore
+	remoteKeys map[string][]net.RemoteKey
+	// tokens by endpoint. Requests with other tokens fail
+	tokens map[string]string
+}
+
+func (km *keymanagerMock) Keystores(endpoint, token string) ([]net.Keystore, error) {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+	ks, ok := km.keystores[endpoint]
+	if !ok || km.tokens[endpoint] != token {
+		return nil, fmt.Errorf("Intentional error")
+	}
+	return ks, nil
+}
+
+func (km *keymanagerMock) RemoteKeys(endpoint, token string) ([]net.RemoteKey, error) {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+	return km.remoteKeys[endpoint], nil
+}
+
+func (km *keymanagerMock) setKeystores(endpoint string, ks []net.Keystore) {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+	km.keystores[endpoint] = ks
+}
+
+func TestLoadKeymanagers(t *testing.T) {
+	td := t.TempDir()
+
+	tcs := []struct {
+		name    string
+		yml     string
+		want    []Keymanager
+		isError bool
+	}{
+		{
+			"Test case 1, no keymanagers",
+			`consensus: "http://153.168.127.111:5052"`,
+			nil,
+			false,
+		},
+		{
+			"Test case 2, comma separated URLs",
+			`keymanagers: "http://vc1:7500/, http://vc2:7500"`,
+			[]Keymanager{{URL: "http://vc1:7500"}, {URL: "http://vc2:7500"}},
+			false,
+		},
+		{
+			"Test case 3, URLs and maps",
+			`
+keymanagers:
+  - http://vc1:7500
+  - url: http://vc2:7500
+    token: api-token-0x1234
+  - url: http://vc3:7500
+    token_file: /var/lib/teku/validator/key-manager/token`,
+			[]Keymanager{
+				{URL: "http://vc1:7500"},
+				{URL: "http://vc2:7500", Token: "api-token-0x1234"},
+				{URL: "http://vc3:7500", TokenFile: "/var/lib/teku/validator/key-manager/token"},
+			},
+			false,
+		},
+		{
+			"Test case 4, keymanager without URL",
+			`
+keymanagers:
+  - token: api-token-0x1234`,
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := setupYML(td, tc.yml)
+			if err != nil {
+				t.Fatal(err)
+			}
+			viper.SetConfigFile(f)
+			if err := viper.ReadInConfig(); err != nil {
+				t.Fatal(err)
+			}
+			defer cleanInitTestCase()
+
+			got, err := loadKeymanagers()
+
+			descr := fmt.Sprintf("loadKeymanagers() with yml %s", tc.yml)
+			if err = utils.CheckErr(descr, tc.isError, err); err != nil {
+				t.Fatal(err)
+			}
+			if !tc.isError {
+				assert.Equal(t, tc.want, got, descr)
+			}
+		})
+	}
+}
+
+func TestKeymanagerToken(t *testing.T) {
+	t.Parallel()
+	td := t.TempDir()
+	path := writeFile(t, td, "api-token.txt", "api-token-0x1234\n")
+
+	token, err := Keymanager{URL: "http://vc1:7500", TokenFile: path}.token()
+	assert.NoError(t, err)
+	assert.Equal(t, "api-token-0x1234", token)
+
+	token, err = Keymanager{URL: "http://vc1:7500", Token: "inline", TokenFile: path}.token()
+	assert.NoError(t, err)
+	assert.Equal(t, "inline", token)
+
+	_, err = Keymanager{URL: "http://vc1:7500", TokenFile: filepath.Join(td, "missing.txt")}.token()
+	assert.Error(t, err)
+}
+
+func TestCheckKeymanagers(t *testing.T) {
+	t.Parallel()
+
+	registry := []net.ValidatorInfo{
+		{Index: "1", Validator: net.ValidatorData{Pubkey: "0xaa01"}},
+		{Index: "2", Validator: net.ValidatorData{Pubkey: "0xaa02"}},
+	}
+	keymanagers := []Keymanager{{URL: "vc1", Token: "t1"}, {URL: "vc2", Token: "t2"}}
+	tokens := map[string]string{"vc1": "t1", "vc2": "t2"}
+
+	tcs := []struct {
+		name       string
+		validators []string
+		registry   []net.ValidatorInfo
+		keystores  map[string][]net.Keystore
+		remoteKeys map[string][]net.RemoteKey
+		want       []KeyStatus
+		wantErrors int
+	}{
+		{
+			"Test case 1, every key loaded once",
+			[]string{"1", "0xAA03"},
+			registry,
+			map[string][]net.Keystore{"vc1": {{ValidatingPubkey: "0xaa01"}}, "vc2": {}},
+			map[string][]net.RemoteKey{"vc2": {{Pubkey: "aa03"}}},
+			[]KeyStatus{
+				{Pubkey: "0xaa01", Validator: "1", State: KeyLoaded, Clients: []string{"vc1"}},
+				{Pubkey: "0xaa03", Validator: "0xAA03", State: KeyLoaded, Clients: []string{"vc2"}},
+			},
+			0,
+		},
+		{
+			"Test case 2, drift",
+			[]string{"1", "2"},
+			registry,
+			map[string][]net.Keystore{"vc1": {{ValidatingPubkey: "0xaa01"}, {ValidatingPubkey: "0xaa04"}}, "vc2": {{ValidatingPubkey: "0xaa01"}}},
+			nil,
+			[]KeyStatus{
+				{Pubkey: "0xaa01", Validator: "1", State: KeyLoadedTwice, Clients: []string{"vc1", "vc2"}},
+				{Pubkey: "0xaa02", Validator: "2", State: KeyNotLoaded, Clients: []string{}},
+				{Pubkey: "0xaa04", Validator: "0xaa04", State: KeyNotMonitored, Clients: []string{"vc1"}},
+			},
+			0,
+		},
+		{
+			"Test case 3, keymanager down, no key reported as not loaded",
+			[]string{"1", "2"},
+			registry,
+			map[string][]net.Keystore{"vc1": {{ValidatingPubkey: "0xaa01"}}},
+			nil,
+			[]KeyStatus{
+				{Pubkey: "0xaa01", Validator: "1", State: KeyLoaded, Clients: []string{"vc1"}},
+			},
+			1,
+		},
+		{
+			"Test case 4, indexes not resolved, no key reported as not monitored",
+			[]string{"1", "2"},
+			nil,
+			map[string][]net.Keystore{"vc1": {{ValidatingPubkey: "0xaa01"}}, "vc2": {{ValidatingPubkey: "0xaa01"}, {ValidatingPubkey: "0xaa04"}}},
+			nil,
+			[]KeyStatus{
+				{Pubkey: "0xaa01", Validator: "0xaa01", State: KeyLoadedTwice, Clients: []string{"vc1", "vc2"}},
+			},
+			1,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			vs, err := newValidatorSet(tc.validators, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tbc := newTestBeaconClient(nil, nil)
+			tbc.registry = tc.registry
+			monitor := eth2Monitor{
+				beaconClient:     tbc,
+				keymanagerClient: &keymanagerMock{keystores: tc.keystores, remoteKeys: tc.remoteKeys, tokens: tokens},
+				validators:       vs,
+				settings:         monitorSettings{keymanagers: keymanagers},
+			}
+
+			got := monitor.CheckKeymanagers()
+
+			assert.Equal(t, tc.want, got.Keys)
+			assert.Len(t, got.Errors, tc.wantErrors)
+		})
+	}
+}
+
+func TestTrackKeymanagers(t *testing.T) {
+	t.Parallel()
+
+	vs, err := newValidatorSet([]string{"0xaa01"}, []ValidatorGroup{{Name: "acme", Validators: []string{"0xaa01"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	km := &keymanagerMock{
+		keystores: map[string][]net.Keystore{"vc1": {{ValidatingPubkey: "0xaa01"}, {ValidatingPubkey: "0xaa02"}}},
+		tokens:    map[string]string{"vc1": ""},
+	}
+	am := &alerterMock{}
+	monitor := eth2Monitor{
+		beaconClient:     newTestBeaconClient(nil, nil),
+		keymanagerClient: km,
+		validators:       vs,
+		settings:         monitorSettings{keymanagers: []Keymanager{{URL: "vc1"}}, keymanagerAutoMonitor: true},
+		alerter:          am,
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go monitor.TrackKeymanagers(done, time.Millisecond*10, newStateTracker(0, string(KeyLoaded)))
+
+	time.Sleep(time.Millisecond * 50)
+	// Not configured key is monitored without alerting
+	assert.Equal(t, []string{"0xaa01", "0xaa02"}, vs.List())
+	assert.Empty(t, am.all())
+
+	// Key removed from the validator client
+	km.setKeystores("vc1", []net.Keystore{{ValidatingPubkey: "0xaa02"}})
+	time.Sleep(time.Millisecond * 50)
+	sent := am.all()
+	if assert.Len(t, sent, 1) {
+		assert.Equal(t, KeyDriftAlert, sent[0].Kind)
+		assert.Equal(t, alerts.Critical, sent[0].Severity)
+		assert.Equal(t, "0xaa01", sent[0].Source)
+		assert.Equal(t, string(KeyNotLoaded), sent[0].Labels["state"])
+		assert.Equal(t, "acme", sent[0].Labels["group"])
+	}
+}
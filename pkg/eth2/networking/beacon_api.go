@@ -39,7 +39,7 @@ func (bc *BeaconClient) SetEndpoints(endpoints []string) {
 
 /*
 ValidatorBalances :
-Get the validator balances for the given checkpoint.
+Get the validator balances for the given checkpoint. Validators are requested in chunks to keep URLs short.
 
 params :-
 a. stateID string
@@ -54,33 +54,17 @@ b. error
 Error if any
 */
 func (bc *BeaconClient) ValidatorBalances(stateID string, validatorIdxs []string) ([]ValidatorBalance, error) {
-	// notest
-	idxs := strings.Join(validatorIdxs, ",")
-	// http://<endpoint>/eth/v1/beacon/states/<stateID>/validator_balances?id=1,2,3
-	url := fmt.Sprintf("%s%s%s%s?id=%s", bc.Endpoint, "/eth/v1/beacon/states/", stateID, "/validator_balances", idxs)
-
-	resp, err := utils.GetRequest(url, bc.RetryDuration)
-	if err != nil {
-		return nil, fmt.Errorf(RequestFailedError, url, err)
-	}
-
-	defer resp.Body.Close()
-	contents, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf(ReadBodyError, err)
-	}
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf(BadResponseError, url, resp.StatusCode, string(contents))
-	}
-
-	var balances ValidatorBalanceList
-	balances, err = unmarshalData(contents, balances)
-	if err != nil {
-		return nil, err
+	balances := make([]ValidatorBalance, 0, len(validatorIdxs))
+	for _, chunk := range chunkIDs(validatorIdxs) {
+		// http://<endpoint>/eth/v1/beacon/states/<stateID>/validator_balances?id=1,2,3
+		url := fmt.Sprintf("%s/eth/v1/beacon/states/%s/validator_balances?id=%s", bc.Endpoint, stateID, strings.Join(chunk, ","))
+		resp, err := getData(url, bc.RetryDuration, ValidatorBalanceList{})
+		if err != nil {
+			return nil, err
+		}
+		balances = append(balances, resp.Data...)
 	}
-
-	return balances.Data, nil
+	return balances, nil
 }
 
 /*
@@ -200,3 +184,338 @@ func (bc *BeaconClient) SyncStatus(endpoints []string) []BeaconSyncingStatus {
 
 	return responses
 }
+
+/*
+NodeInfo :
+Gather diagnostics of the given endpoints using the API methods '/eth/v1/node/health', '/eth/v1/node/peer_count', '/eth/v1/node/peers', '/eth/v1/node/version' and '/eth/v1/node/identity'.
+
+params :-
+a. endpoints []string
+Endpoints to check
+
+returns :-
+a. []NodeInfo
+Diagnostics of the given endpoints
+*/
+func (bc *BeaconClient) NodeInfo(endpoints []string) []NodeInfo {
+	logFields := log.Fields{configs.Component: "BeaconClient", "Method": "NodeInfo"}
+	if len(endpoints) == 0 {
+		log.WithFields(logFields).Warn("No endpoints provided for node diagnostics")
+		return nil
+	}
+
+	ch := make(chan NodeInfo, len(endpoints))
+	defer close(ch)
+
+	for _, endpoint := range endpoints {
+		go func(endpoint string) {
+			ch <- bc.nodeInfo(endpoint)
+		}(endpoint)
+	}
+
+	responses := make([]NodeInfo, 0)
+	for i := 0; i < len(endpoints); i++ {
+		responses = append(responses, <-ch)
+	}
+
+	return responses
+}
+
+/*
+nodeInfo :
+Gather diagnostics of a single endpoint. Stops at the health check if the node is unreachable, otherwise the first error found is kept and the remaining calls are still done.
+
+params :-
+a. endpoint string
+Endpoint to check
+
+returns :-
+a. NodeInfo
+Diagnostics of the given endpoint
+*/
+func (bc *BeaconClient) nodeInfo(endpoint string) NodeInfo {
+	info := NodeInfo{Endpoint: endpoint}
+
+	url := fmt.Sprintf("%s%s", endpoint, "/eth/v1/node/health")
+	resp, err := utils.GetRequest(url, bc.RetryDuration)
+	if err != nil {
+		info.Error = fmt.Errorf(RequestFailedError, url, err)
+		return info
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case 200:
+		info.Healthy = true
+	case 206:
+		info.Syncing = true
+	default:
+		info.Error = fmt.Errorf(BadResponseError, url, resp.StatusCode, "Node not initialized or having issues")
+	}
+
+	keepErr := func(e error) {
+		if info.Error == nil {
+			info.Error = e
+		}
+	}
+
+	pc, err := getData(fmt.Sprintf("%s%s", endpoint, "/eth/v1/node/peer_count"), bc.RetryDuration, PeerCountResponse{})
+	if err != nil {
+		keepErr(err)
+	}
+	info.PeerCount = pc.Data
+
+	peers, err := getData(fmt.Sprintf("%s%s", endpoint, "/eth/v1/node/peers?state=connected"), bc.RetryDuration, PeersResponse{})
+	if err != nil {
+		keepErr(err)
+	}
+	info.Peers = peers.Data
+
+	version, err := getData(fmt.Sprintf("%s%s", endpoint, "/eth/v1/node/version"), bc.RetryDuration, NodeVersionResponse{})
+	if err != nil {
+		keepErr(err)
+	}
+	info.Version = version.Data.Version
+
+	identity, err := getData(fmt.Sprintf("%s%s", endpoint, "/eth/v1/node/identity"), bc.RetryDuration, NodeIdentityResponse{})
+	if err != nil {
+		keepErr(err)
+	}
+	info.Identity = identity.Data
+
+	return info
+}
+
+/*
+Block :
+Get a beacon block using the API method '/eth/v2/beacon/blocks/<blockID>'.
+
+params :-
+a. endpoint string
+Endpoint to get the block from
+b. blockID string
+Block identifier. Can be 'head', 'genesis', 'finalized', a slot or a hex encoded block root
+
+returns :-
+a. BeaconBlock
+Beacon block message
+b. error
+Error if any
+*/
+func (bc *BeaconClient) Block(endpoint, blockID string) (BeaconBlock, error) {
+	url := fmt.Sprintf("%s%s%s", endpoint, "/eth/v2/beacon/blocks/", blockID)
+	resp, err := getData(url, bc.RetryDuration, BeaconBlockResponse{})
+	if err != nil {
+		return BeaconBlock{}, err
+	}
+	return resp.Data.Message, nil
+}
+
+/*
+Genesis :
+Get genesis details of the beacon chain using the API method '/eth/v1/beacon/genesis'.
+
+params :-
+a. endpoint string
+Endpoint to get the genesis from
+
+returns :-
+a. Genesis
+Genesis details
+b. error
+Error if any
+*/
+func (bc *BeaconClient) Genesis(endpoint string) (Genesis, error) {
+	resp, err := getData(endpoint+"/eth/v1/beacon/genesis", bc.RetryDuration, GenesisResponse{})
+	if err != nil {
+		return Genesis{}, err
+	}
+	return resp.Data, nil
+}
+
+/*
+Spec :
+Get the chain specification of a beacon node using the API method '/eth/v1/config/spec'.
+
+params :-
+a. endpoint string
+Endpoint to get the specification from
+
+returns :-
+a. Spec
+Chain specification
+b. error
+Error if any
+*/
+func (bc *BeaconClient) Spec(endpoint string) (Spec, error) {
+	resp, err := getData(endpoint+"/eth/v1/config/spec", bc.RetryDuration, SpecResponse{})
+	if err != nil {
+		return Spec{}, err
+	}
+	return resp.Data, nil
+}
+
+/*
+Validators :
+Get the registry records of the given validators using the API method '/eth/v1/beacon/states/<stateID>/validators'. Validators are requested in chunks to keep URLs short.
+
+params :-
+a. stateID string
+Blockchain state ID from when to get the validators
+b. ids []string
+Validator indexes or public keys. Unknown validators are not returned
+
+returns :-
+a. []ValidatorInfo
+Validators fetched from the beacon node
+b. error
+Error if any
+*/
+func (bc *BeaconClient) Validators(stateID string, ids []string) ([]ValidatorInfo, error) {
+	validators := make([]ValidatorInfo, 0, len(ids))
+	for _, chunk := range chunkIDs(ids) {
+		url := fmt.Sprintf("%s/eth/v1/beacon/states/%s/validators?id=%s", bc.Endpoint, stateID, strings.Join(chunk, ","))
+		resp, err := getData(url, bc.RetryDuration, ValidatorListResponse{})
+		if err != nil {
+			return nil, err
+		}
+		validators = append(validators, resp.Data...)
+	}
+	return validators, nil
+}
+
+/*
+ActiveValidators :
+Get the registry records of every active validator using the API method '/eth/v1/beacon/states/<stateID>/validators'. The response is large on mainnet, so it should be requested sparingly.
+
+params :-
+a. stateID string
+Blockchain state ID from when to get the validators
+
+returns :-
+a. []ValidatorInfo
+Active validators, including exiting and slashed ones
+b. error
+Error if any
+*/
+func (bc *BeaconClient) ActiveValidators(stateID string) ([]ValidatorInfo, error) {
+	resp, err := getData(fmt.Sprintf("%s/eth/v1/beacon/states/%s/validators?status=active", bc.Endpoint, stateID), bc.RetryDuration, ValidatorListResponse{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+/*
+FinalityCheckpoints :
+Get the justified and finalized checkpoints of a state using the API method '/eth/v1/beacon/states/<stateID>/finality_checkpoints'.
+
+params :-
+a. stateID string
+Blockchain state ID, usually 'head'
+
+returns :-
+a. FinalityCheckpoints
+Checkpoints of the state
+b. error
+Error if any
+*/
+func (bc *BeaconClient) FinalityCheckpoints(stateID string) (FinalityCheckpoints, error) {
+	resp, err := getData(fmt.Sprintf("%s/eth/v1/beacon/states/%s/finality_checkpoints", bc.Endpoint, stateID), bc.RetryDuration, FinalityCheckpointsResponse{})
+	if err != nil {
+		return FinalityCheckpoints{}, err
+	}
+	return resp.Data, nil
+}
+
+/*
+Committees :
+Get the attester committees of an epoch using the API method '/eth/v1/beacon/states/<stateID>/committees'.
+
+params :-
+a. stateID string
+Blockchain state ID from when to get the committees. Its epoch should be close to the requested one
+b. epoch string
+Epoch to get the committees for
+
+returns :-
+a. []Committee
+Committees of every slot of the epoch
+b. error
+Error if any
+*/
+func (bc *BeaconClient) Committees(stateID, epoch string) ([]Committee, error) {
+	resp, err := getData(fmt.Sprintf("%s/eth/v1/beacon/states/%s/committees?epoch=%s", bc.Endpoint, stateID, epoch), bc.RetryDuration, CommitteesResponse{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+/*
+ProposerDuties :
+Get the block proposer of every slot of an epoch using the API method '/eth/v1/validator/duties/proposer/<epoch>'.
+
+params :-
+a. epoch string
+Epoch to get the proposers for
+
+returns :-
+a. []ProposerDuty
+Proposer of every slot of the epoch
+b. error
+Error if any
+*/
+func (bc *BeaconClient) ProposerDuties(epoch string) ([]ProposerDuty, error) {
+	resp, err := getData(fmt.Sprintf("%s/eth/v1/validator/duties/proposer/%s", bc.Endpoint, epoch), bc.RetryDuration, ProposerDutiesResponse{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+/*
+Liveness :
+Check which validators were seen active in an epoch, by their attestations or blocks, using the API method '/eth/v1/validator/liveness/<epoch>'. Nodes usually only answer for the current and previous epochs.
+
+params :-
+a. epoch string
+Epoch to check
+b. indexes []string
+Validator indexes to check
+
+returns :-
+a. []ValidatorLiveness
+Liveness of every validator requested
+b. error
+Error if any
+*/
+func (bc *BeaconClient) Liveness(epoch string, indexes []string) ([]ValidatorLiveness, error) {
+	resp, err := postData(fmt.Sprintf("%s/eth/v1/validator/liveness/%s", bc.Endpoint, epoch), indexes, bc.RetryDuration, LivenessResponse{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+/*
+ProposerPreparations :
+Get the fee recipients registered by validator clients with the API method '/eth/v1/validator/prepare_beacon_proposer'. The standard API only accepts registrations with POST, so only nodes listing them with GET answer, others give 404 or 405.
+
+params :-
+a. endpoint string
+Consensus endpoint to get the registrations from
+
+returns :-
+a. []ProposerPreparation
+Fee recipients registered by validator
+b. error
+Error if any. A StatusError with code 404 or 405 if the node doesn't expose registrations
+*/
+func (bc *BeaconClient) ProposerPreparations(endpoint string) ([]ProposerPreparation, error) {
+	resp, err := getData(endpoint+"/eth/v1/validator/prepare_beacon_proposer", bc.RetryDuration, ProposerPreparationsResponse{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
@@ -2,10 +2,16 @@ package networking
 
 import (
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 type handler func(rw http.ResponseWriter, req *http.Request)
@@ -299,3 +305,785 @@ func TestSyncStatus(t *testing.T) {
 		})
 	}
 }
+
+func nodeHandler(healthCode int, bodies map[string]string) handler {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/eth/v1/node/health" {
+			rw.WriteHeader(healthCode)
+			return
+		}
+
+		body, ok := bodies[req.URL.Path]
+		if !ok {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		rw.WriteHeader(http.StatusOK)
+		rw.Write([]byte(body))
+	}
+}
+
+func TestNodeInfo(t *testing.T) {
+	t.Parallel()
+
+	goodBodies := map[string]string{
+		"/eth/v1/node/peer_count": `{"data":{"disconnected":"12","connecting":"1","connected":"56","disconnecting":"0"}}`,
+		"/eth/v1/node/peers": `{"data":[
+			{"peer_id":"QmYyQSo1c1Ym7orWxLYvCrM2EmxFTANf8wXmmE7DWjhx5N","enr":"","last_seen_p2p_address":"/ip4/7.7.7.7/tcp/4242/p2p/QmYyQSo1c1Ym7orWxLYvCrM2EmxFTANf8wXmmE7DWjhx5N","state":"connected","direction":"inbound"},
+			{"peer_id":"QmYyQSo1c1Ym7orWxLYvCrM2EmxFTANf8wXmmE7DWjhx5M","enr":"","last_seen_p2p_address":"/ip4/7.7.7.8/tcp/4242/p2p/QmYyQSo1c1Ym7orWxLYvCrM2EmxFTANf8wXmmE7DWjhx5M","state":"connected","direction":"outbound"}
+		],"meta":{"count":2}}`,
+		"/eth/v1/node/version":  `{"data":{"version":"Lighthouse/v0.1.5 (Linux x86_64)"}}`,
+		"/eth/v1/node/identity": `{"data":{"peer_id":"QmYyQSo1c1Ym7orWxLYvCrM2EmxFTANf8wXmmE7DWjhx5N","enr":"enr:-IS4QHCYrYZbAKWCBRlAy5zzaDZXJBGkcnh4MHcBFZntXNFrdvJjX04jRzjzCBOonrkTfj499SZuOh8R33Ls8RRcy5wBgmlkgnY0gmlwhH8AAAGJc2VjcDI1NmsxoQPKY0yuDUmstAHYpMa2_oxVtw0RW_QAdpzBQA8yWM0xOIN1ZHCCdl8","p2p_addresses":["/ip4/7.7.7.7/tcp/4242/p2p/QmYyQSo1c1Ym7orWxLYvCrM2EmxFTANf8wXmmE7DWjhx5N"],"discovery_addresses":["/ip4/7.7.7.7/udp/30303/p2p/QmYyQSo1c1Ym7orWxLYvCrM2EmxFTANf8wXmmE7DWjhx5N"],"metadata":{"seq_number":"1","attnets":"0x0000000000000000","syncnets":"0x0f"}}}`,
+	}
+
+	tcs := []struct {
+		name     string
+		handlers []handler
+		want     []NodeInfo
+	}{
+		{
+			"Test Case 1, no endpoints, empty",
+			nil,
+			nil,
+		},
+		{
+			"Test Case 2, empty endpoint, request failed",
+			[]handler{nil},
+			[]NodeInfo{{Error: errors.New("")}},
+		},
+		{
+			"Test Case 3, healthy node, all diagnostics",
+			[]handler{nodeHandler(http.StatusOK, goodBodies)},
+			[]NodeInfo{{Healthy: true, Version: "Lighthouse/v0.1.5 (Linux x86_64)"}},
+		},
+		{
+			"Test Case 4, syncing node, all diagnostics",
+			[]handler{nodeHandler(http.StatusPartialContent, goodBodies)},
+			[]NodeInfo{{Syncing: true, Version: "Lighthouse/v0.1.5 (Linux x86_64)"}},
+		},
+		{
+			"Test Case 5, node having issues",
+			[]handler{nodeHandler(http.StatusServiceUnavailable, goodBodies)},
+			[]NodeInfo{{Version: "Lighthouse/v0.1.5 (Linux x86_64)", Error: errors.New("")}},
+		},
+		{
+			"Test Case 6, healthy node, missing diagnostics",
+			[]handler{nodeHandler(http.StatusOK, map[string]string{"/eth/v1/node/version": goodBodies["/eth/v1/node/version"]})},
+			[]NodeInfo{{Healthy: true, Version: "Lighthouse/v0.1.5 (Linux x86_64)", Error: errors.New("")}},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			endpoints := make([]string, 0)
+
+			for _, handler := range tc.handlers {
+				srv := setupServer(handler)
+				defer srv.Close()
+				endpoints = append(endpoints, srv.URL)
+			}
+
+			client := BeaconClient{
+				RetryDuration: time.Millisecond * 100,
+			}
+
+			got := client.NodeInfo(endpoints)
+			if tc.want == nil {
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.Len(t, got, len(tc.want))
+			for i, w := range tc.want {
+				assert.Equal(t, w.Healthy, got[i].Healthy)
+				assert.Equal(t, w.Syncing, got[i].Syncing)
+				assert.Equal(t, w.Version, got[i].Version)
+				assert.Equal(t, w.Error != nil, got[i].Error != nil, "Got error %v", got[i].Error)
+			}
+		})
+	}
+}
+
+func TestNodeInfoContents(t *testing.T) {
+	t.Parallel()
+
+	srv := setupServer(nodeHandler(http.StatusOK, map[string]string{
+		"/eth/v1/node/peer_count": `{"data":{"disconnected":"12","connecting":"1","connected":"2","disconnecting":"0"}}`,
+		"/eth/v1/node/peers":      `{"data":[{"peer_id":"a","state":"connected","direction":"inbound"},{"peer_id":"b","state":"connected","direction":"outbound"}]}`,
+		"/eth/v1/node/version":    `{"data":{"version":"teku/v23.1.0"}}`,
+		"/eth/v1/node/identity":   `{"data":{"peer_id":"c","metadata":{"seq_number":"4","attnets":"0x01","syncnets":"0x00"}}}`,
+	}))
+	defer srv.Close()
+
+	client := BeaconClient{RetryDuration: time.Millisecond * 100}
+	got := client.NodeInfo([]string{srv.URL})
+
+	assert.Len(t, got, 1)
+	assert.Nil(t, got[0].Error)
+	assert.Equal(t, srv.URL, got[0].Endpoint)
+	assert.Equal(t, PeerCount{Disconnected: "12", Connecting: "1", Connected: "2", Disconnecting: "0"}, got[0].PeerCount)
+	assert.Equal(t, []Peer{{PeerID: "a", State: "connected", Direction: "inbound"}, {PeerID: "b", State: "connected", Direction: "outbound"}}, got[0].Peers)
+	assert.Equal(t, "c", got[0].Identity.PeerID)
+	assert.Equal(t, "0x01", got[0].Identity.Metadata.Attnets)
+}
+
+func TestBlock(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		handler handler
+		want    BeaconBlock
+		isError bool
+	}{
+		{
+			"Test Case 1, request failed",
+			nil,
+			BeaconBlock{},
+			true,
+		},
+		{
+			"Test Case 2, block not found, 404 response",
+			func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusNotFound)
+				rw.Write([]byte(`{"code":404,"message":"Block not found"}`))
+			},
+			BeaconBlock{},
+			true,
+		},
+		{
+			"Test Case 3, bad json",
+			func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte("{"))
+			},
+			BeaconBlock{},
+			true,
+		},
+		{
+			"Test Case 4, good block",
+			func(rw http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != "/eth/v2/beacon/blocks/head" {
+					t.Errorf("Unexpected path %s", req.URL.Path)
+				}
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(`{"version":"capella","execution_optimistic":false,"finalized":false,"data":{"message":{
+					"slot":"5000","proposer_index":"42","parent_root":"0xaa","state_root":"0xbb",
+					"body":{"attestations":[{"aggregation_bits":"0x0d","committee_bits":"0x0300000000000000",
+						"data":{"slot":"4999","index":"0","beacon_block_root":"0xaa","source":{"epoch":"154","root":"0x01"},"target":{"epoch":"156","root":"0x02"}},"signature":"0x00"}],
+						"execution_payload":{"block_hash":"0xcc","block_number":"1234","fee_recipient":"0xdd",
+						"withdrawals":[{"index":"7","validator_index":"42","address":"0xee","amount":"15000"}]}}
+				},"signature":"0x00"}}`))
+			},
+			BeaconBlock{
+				Slot:          "5000",
+				ProposerIndex: "42",
+				ParentRoot:    "0xaa",
+				StateRoot:     "0xbb",
+				Body: BeaconBlockBody{
+					Attestations: []Attestation{{
+						AggregationBits: "0x0d", CommitteeBits: "0x0300000000000000",
+						Data: AttestationData{
+							Slot: "4999", Index: "0", BeaconBlockRoot: "0xaa",
+							Source: AttestationCheckpoint{Epoch: "154", Root: "0x01"}, Target: AttestationCheckpoint{Epoch: "156", Root: "0x02"},
+						},
+					}},
+					ExecutionPayload: ExecutionPayload{
+						BlockHash: "0xcc", BlockNumber: "1234", FeeRecipient: "0xdd",
+						Withdrawals: []Withdrawal{{Index: "7", ValidatorIndex: "42", Address: "0xee", Amount: "15000"}},
+					},
+				},
+			},
+			false,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := setupServer(tc.handler)
+			defer srv.Close()
+
+			client := BeaconClient{RetryDuration: time.Millisecond * 100}
+			got, err := client.Block(srv.URL, "head")
+
+			assert.Equal(t, tc.isError, err != nil, "Block() gave unexpected error %v", err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestGenesis(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		handler handler
+		want    Genesis
+		isError bool
+	}{
+		{
+			"Test Case 1, request failed",
+			nil,
+			Genesis{},
+			true,
+		},
+		{
+			"Test Case 2, bad json",
+			func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte("{"))
+			},
+			Genesis{},
+			true,
+		},
+		{
+			"Test Case 3, good genesis",
+			func(rw http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != "/eth/v1/beacon/genesis" {
+					t.Errorf("Unexpected path %s", req.URL.Path)
+				}
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(`{"data":{"genesis_time":"1606824023","genesis_validators_root":"0x4b36","genesis_fork_version":"0x00000000"}}`))
+			},
+			Genesis{GenesisTime: "1606824023", GenesisValidatorsRoot: "0x4b36", GenesisForkVersion: "0x00000000"},
+			false,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := setupServer(tc.handler)
+			defer srv.Close()
+
+			client := BeaconClient{RetryDuration: time.Millisecond * 100}
+			got, err := client.Genesis(srv.URL)
+
+			assert.Equal(t, tc.isError, err != nil, "Genesis() gave unexpected error %v", err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestSpec(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		handler handler
+		want    Spec
+		isError bool
+	}{
+		{
+			"Test Case 1, request failed",
+			nil,
+			Spec{},
+			true,
+		},
+		{
+			"Test Case 2, server error",
+			func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusInternalServerError)
+			},
+			Spec{},
+			true,
+		},
+		{
+			"Test Case 3, good spec with unknown and non string values",
+			func(rw http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != "/eth/v1/config/spec" {
+					t.Errorf("Unexpected path %s", req.URL.Path)
+				}
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(`{"data":{"CONFIG_NAME":"gnosis","PRESET_BASE":"gnosis","SECONDS_PER_SLOT":"5","SLOTS_PER_EPOCH":"16","DEPOSIT_CHAIN_ID":"100","MAX_EFFECTIVE_BALANCE":"32000000000","BLOB_SCHEDULE":[{"EPOCH":"1","MAX_BLOBS_PER_BLOCK":"6"}]}}`))
+			},
+			Spec{ConfigName: "gnosis", PresetBase: "gnosis", SecondsPerSlot: "5", SlotsPerEpoch: "16", DepositChainID: "100"},
+			false,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := setupServer(tc.handler)
+			defer srv.Close()
+
+			client := BeaconClient{RetryDuration: time.Millisecond * 100}
+			got, err := client.Spec(srv.URL)
+
+			assert.Equal(t, tc.isError, err != nil, "Spec() gave unexpected error %v", err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestValidators(t *testing.T) {
+	t.Parallel()
+
+	ids := make([]string, 150)
+	for i := range ids {
+		ids[i] = fmt.Sprint(i)
+	}
+	pubkeys := make([]string, 100)
+	for i := range pubkeys {
+		pubkeys[i] = fmt.Sprintf("0x%096x", i)
+	}
+
+	tcs := []struct {
+		name      string
+		ids       []string
+		handler   handler
+		want      []ValidatorInfo
+		wantCalls int
+		isError   bool
+	}{
+		{
+			"Test Case 1, no validators",
+			nil,
+			func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusOK)
+			},
+			[]ValidatorInfo{},
+			0,
+			false,
+		},
+		{
+			"Test Case 2, server error",
+			[]string{"1"},
+			func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusInternalServerError)
+			},
+			nil,
+			1,
+			true,
+		},
+		{
+			"Test Case 3, validators in two chunks",
+			ids,
+			func(rw http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != "/eth/v1/beacon/states/head/validators" {
+					t.Errorf("Unexpected path %s", req.URL.Path)
+				}
+				first := strings.Split(req.URL.Query().Get("id"), ",")[0]
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(fmt.Sprintf(`{"data":[{"index":"%s","balance":"32000000000","status":"active_ongoing","validator":{"pubkey":"0xa1","slashed":false}}]}`, first)))
+			},
+			[]ValidatorInfo{
+				{Index: "0", Balance: "32000000000", Status: "active_ongoing", Validator: ValidatorData{Pubkey: "0xa1"}},
+				{Index: "100", Balance: "32000000000", Status: "active_ongoing", Validator: ValidatorData{Pubkey: "0xa1"}},
+			},
+			2,
+			false,
+		},
+		{
+			"Test Case 4, public keys in chunks with short URLs",
+			pubkeys,
+			func(rw http.ResponseWriter, req *http.Request) {
+				if n := len(req.URL.RequestURI()); n > 8192 {
+					t.Errorf("Request URL of %d bytes", n)
+				}
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(`{"data":[]}`))
+			},
+			[]ValidatorInfo{},
+			3,
+			false,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int32
+			srv := setupServer(func(rw http.ResponseWriter, req *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				tc.handler(rw, req)
+			})
+			defer srv.Close()
+
+			client := BeaconClient{Endpoint: srv.URL, RetryDuration: time.Millisecond * 100}
+			got, err := client.Validators("head", tc.ids)
+
+			assert.Equal(t, tc.isError, err != nil, "Validators() gave unexpected error %v", err)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.wantCalls, int(atomic.LoadInt32(&calls)))
+		})
+	}
+}
+
+func TestValidatorBalances(t *testing.T) {
+	t.Parallel()
+
+	ids := make([]string, 250)
+	for i := range ids {
+		ids[i] = fmt.Sprint(i)
+	}
+
+	tcs := []struct {
+		name      string
+		ids       []string
+		handler   handler
+		want      []ValidatorBalance
+		wantCalls int
+		isError   bool
+	}{
+		{
+			"Test Case 1, no validators",
+			nil,
+			func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusOK)
+			},
+			[]ValidatorBalance{},
+			0,
+			false,
+		},
+		{
+			"Test Case 2, server error",
+			[]string{"1"},
+			func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusInternalServerError)
+			},
+			nil,
+			1,
+			true,
+		},
+		{
+			"Test Case 3, validators in three chunks",
+			ids,
+			func(rw http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != "/eth/v1/beacon/states/finalized/validator_balances" {
+					t.Errorf("Unexpected path %s", req.URL.Path)
+				}
+				requested := strings.Split(req.URL.Query().Get("id"), ",")
+				if len(requested) > validatorsChunkSize {
+					t.Errorf("Requested %d validators at once", len(requested))
+				}
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(fmt.Sprintf(`{"data":[{"index":"%s","balance":"32000000000"}]}`, requested[0])))
+			},
+			[]ValidatorBalance{
+				{Index: "0", Balance: "32000000000"},
+				{Index: "100", Balance: "32000000000"},
+				{Index: "200", Balance: "32000000000"},
+			},
+			3,
+			false,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int32
+			srv := setupServer(func(rw http.ResponseWriter, req *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				tc.handler(rw, req)
+			})
+			defer srv.Close()
+
+			client := BeaconClient{Endpoint: srv.URL, RetryDuration: time.Millisecond * 100}
+			got, err := client.ValidatorBalances("finalized", tc.ids)
+
+			assert.Equal(t, tc.isError, err != nil, "ValidatorBalances() gave unexpected error %v", err)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.wantCalls, int(atomic.LoadInt32(&calls)))
+		})
+	}
+}
+
+func TestProposerDuties(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		handler handler
+		want    []ProposerDuty
+		isError bool
+	}{
+		{
+			"Test Case 1, server error",
+			func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusServiceUnavailable)
+			},
+			nil,
+			true,
+		},
+		{
+			"Test Case 2, proposers of the epoch",
+			func(rw http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != "/eth/v1/validator/duties/proposer/2" {
+					t.Errorf("Unexpected path %s", req.URL.Path)
+				}
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(`{"dependent_root":"0xcf8e","data":[{"pubkey":"0xa1","validator_index":"1","slot":"64"},{"pubkey":"0xa2","validator_index":"2","slot":"65"}]}`))
+			},
+			[]ProposerDuty{
+				{Pubkey: "0xa1", ValidatorIndex: "1", Slot: "64"},
+				{Pubkey: "0xa2", ValidatorIndex: "2", Slot: "65"},
+			},
+			false,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := setupServer(tc.handler)
+			defer srv.Close()
+
+			client := BeaconClient{Endpoint: srv.URL, RetryDuration: time.Millisecond * 100}
+			got, err := client.ProposerDuties("2")
+
+			assert.Equal(t, tc.isError, err != nil, "ProposerDuties() gave unexpected error %v", err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestActiveValidators(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		handler handler
+		want    []ValidatorInfo
+		isError bool
+	}{
+		{
+			"Test Case 1, server error",
+			func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusInternalServerError)
+			},
+			nil,
+			true,
+		},
+		{
+			"Test Case 2, active validators",
+			func(rw http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != "/eth/v1/beacon/states/finalized/validators" || req.URL.Query().Get("status") != "active" {
+					t.Errorf("Unexpected request %s", req.URL)
+				}
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(`{"data":[{"index":"1","balance":"32000000000","status":"active_ongoing","validator":{"pubkey":"0xa1","effective_balance":"32000000000"}},{"index":"2","balance":"31000000000","status":"active_exiting","validator":{"pubkey":"0xa2","effective_balance":"31000000000"}}]}`))
+			},
+			[]ValidatorInfo{
+				{Index: "1", Balance: "32000000000", Status: "active_ongoing", Validator: ValidatorData{Pubkey: "0xa1", EffectiveBalance: "32000000000"}},
+				{Index: "2", Balance: "31000000000", Status: "active_exiting", Validator: ValidatorData{Pubkey: "0xa2", EffectiveBalance: "31000000000"}},
+			},
+			false,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := setupServer(tc.handler)
+			defer srv.Close()
+
+			client := BeaconClient{Endpoint: srv.URL, RetryDuration: time.Millisecond * 100}
+			got, err := client.ActiveValidators("finalized")
+
+			assert.Equal(t, tc.isError, err != nil, "ActiveValidators() gave unexpected error %v", err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestFinalityCheckpoints(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		handler handler
+		want    FinalityCheckpoints
+		isError bool
+	}{
+		{
+			"Test Case 1, server error",
+			func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusNotFound)
+			},
+			FinalityCheckpoints{},
+			true,
+		},
+		{
+			"Test Case 2, checkpoints of the head state",
+			func(rw http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != "/eth/v1/beacon/states/head/finality_checkpoints" {
+					t.Errorf("Unexpected request %s", req.URL)
+				}
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(`{"execution_optimistic":false,"finalized":false,"data":{"previous_justified":{"epoch":"9","root":"0x09"},"current_justified":{"epoch":"10","root":"0x0a"},"finalized":{"epoch":"8","root":"0x08"}}}`))
+			},
+			FinalityCheckpoints{
+				PreviousJustified: AttestationCheckpoint{Epoch: "9", Root: "0x09"},
+				CurrentJustified:  AttestationCheckpoint{Epoch: "10", Root: "0x0a"},
+				Finalized:         AttestationCheckpoint{Epoch: "8", Root: "0x08"},
+			},
+			false,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := setupServer(tc.handler)
+			defer srv.Close()
+
+			client := BeaconClient{Endpoint: srv.URL, RetryDuration: time.Millisecond * 100}
+			got, err := client.FinalityCheckpoints("head")
+
+			assert.Equal(t, tc.isError, err != nil, "FinalityCheckpoints() gave unexpected error %v", err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestLiveness(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		handler handler
+		want    []ValidatorLiveness
+		isError bool
+	}{
+		{
+			"Test Case 1, server error",
+			func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusBadRequest)
+			},
+			nil,
+			true,
+		},
+		{
+			"Test Case 2, liveness of the requested validators",
+			func(rw http.ResponseWriter, req *http.Request) {
+				body, _ := io.ReadAll(req.Body)
+				if req.Method != http.MethodPost || req.URL.Path != "/eth/v1/validator/liveness/10" || string(body) != `["1","2"]` {
+					t.Errorf("Unexpected request %s %s %s", req.Method, req.URL, body)
+				}
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(`{"data":[{"index":"1","is_live":true},{"index":"2","is_live":false}]}`))
+			},
+			[]ValidatorLiveness{{Index: "1", IsLive: true}, {Index: "2", IsLive: false}},
+			false,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := setupServer(tc.handler)
+			defer srv.Close()
+
+			client := BeaconClient{Endpoint: srv.URL, RetryDuration: time.Millisecond * 100}
+			got, err := client.Liveness("10", []string{"1", "2"})
+
+			assert.Equal(t, tc.isError, err != nil, "Liveness() gave unexpected error %v", err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestCommittees(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		handler handler
+		want    []Committee
+		isError bool
+	}{
+		{
+			"Test Case 1, server error",
+			func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusBadRequest)
+			},
+			nil,
+			true,
+		},
+		{
+			"Test Case 2, committees of the epoch",
+			func(rw http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != "/eth/v1/beacon/states/0x6d2e/committees" || req.URL.Query().Get("epoch") != "2" {
+					t.Errorf("Unexpected request %s", req.URL)
+				}
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(`{"execution_optimistic":false,"finalized":true,"data":[{"index":"0","slot":"64","validators":["5","1","9"]},{"index":"1","slot":"64","validators":["2","7"]}]}`))
+			},
+			[]Committee{
+				{Index: "0", Slot: "64", Validators: []string{"5", "1", "9"}},
+				{Index: "1", Slot: "64", Validators: []string{"2", "7"}},
+			},
+			false,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := setupServer(tc.handler)
+			defer srv.Close()
+
+			client := BeaconClient{Endpoint: srv.URL, RetryDuration: time.Millisecond * 100}
+			got, err := client.Committees("0x6d2e", "2")
+
+			assert.Equal(t, tc.isError, err != nil, "Committees() gave unexpected error %v", err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestProposerPreparations(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		handler handler
+		want    []ProposerPreparation
+		// status code of the returned error, if any
+		wantCode int
+		isError  bool
+	}{
+		{
+			"Test Case 1, request failed",
+			nil,
+			nil,
+			0,
+			true,
+		},
+		{
+			"Test Case 2, registrations not exposed",
+			func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusMethodNotAllowed)
+			},
+			nil,
+			http.StatusMethodNotAllowed,
+			true,
+		},
+		{
+			"Test Case 3, good registrations",
+			func(rw http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != "/eth/v1/validator/prepare_beacon_proposer" {
+					t.Errorf("Unexpected path %s", req.URL.Path)
+				}
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(`{"data":[{"validator_index":"1","fee_recipient":"0xabcf8e0d4e9587369b2301d0790347320302cc09"}]}`))
+			},
+			[]ProposerPreparation{{ValidatorIndex: "1", FeeRecipient: "0xabcf8e0d4e9587369b2301d0790347320302cc09"}},
+			0,
+			false,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := setupServer(tc.handler)
+			defer srv.Close()
+
+			client := BeaconClient{RetryDuration: time.Millisecond * 100}
+			got, err := client.ProposerPreparations(srv.URL)
+
+			assert.Equal(t, tc.isError, err != nil, "ProposerPreparations() gave unexpected error %v", err)
+			assert.Equal(t, tc.want, got)
+			if tc.wantCode != 0 {
+				var statusErr *StatusError
+				if assert.ErrorAs(t, err, &statusErr) {
+					assert.Equal(t, tc.wantCode, statusErr.Code)
+				}
+			}
+		})
+	}
+}
@@ -2,4 +2,17 @@ package networking
 
 const (
 	FinalizedCkptTopic = "/eth/v1/events?topics=finalized_checkpoint"
+	ReorgTopics        = "/eth/v1/events?topics=head,chain_reorg"
 )
+
+// Beacon chain event names
+const (
+	HeadEvent       = "head"
+	ChainReorgEvent = "chain_reorg"
+)
+
+// validatorsChunkSize : Maximum validators requested at once to the validators API method
+const validatorsChunkSize = 100
+
+// maxIDsLength : Maximum length of the validator IDs of a request, keeping URLs under the 8 KB request line limit of many servers and proxies
+const maxIDsLength = 4000
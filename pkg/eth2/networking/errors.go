@@ -1,8 +1,25 @@
 package networking
 
+import "fmt"
+
 const (
-	parseDataError     = "Could not parse event data: %v"
-	RequestFailedError = "GET %s failed. Error: %v"
-	ReadBodyError      = "read contents of response failed. Error: %v"
-	BadResponseError   = "GET %s failed. Status code: %d. Body: %s"
+	parseDataError       = "Could not parse event data: %v"
+	RequestFailedError   = "GET %s failed. Error: %v"
+	ReadBodyError        = "read contents of response failed. Error: %v"
+	BadResponseError     = "GET %s failed. Status code: %d. Body: %s"
+	QuantityError        = "invalid hex quantity %s"
+	SyncingResultError   = "unexpected eth_syncing result %s"
+	BlockNotFoundError   = "block %d not found in %s"
+	ReceiptNotFoundError = "receipt of transaction %s not found in %s"
 )
+
+// StatusError : Struct Represent a non 200 response of an API
+type StatusError struct {
+	URL  string
+	Code int
+	Body string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf(BadResponseError, e.URL, e.Code, e.Body)
+}
@@ -110,15 +110,11 @@ func (ec *ExecutionClient) SyncStatus(endpoints []string) []ExecutionSyncingStat
 			}
 			log.WithFields(logFields).Debugf("Result: %s", string(result))
 
-			var ess ExecutionSyncingStatus
-			ess, err = unmarshalData(result, ess)
-			if err != nil && err.Error() != "json: cannot unmarshal bool into Go value of type networking.ExecutionSyncingStatus" {
+			ess, err := decodeSyncing(result)
+			if err != nil {
 				ch <- ExecutionSyncingStatus{Endpoint: endpoint, Error: err}
 				return
 			}
-
-			// If it is not syncing (it is synced), result is 'false'
-			ess.IsSyncing = ess.CurrentBlock != ""
 			ess.Endpoint = endpoint
 
 			ch <- ess
@@ -132,3 +128,120 @@ func (ec *ExecutionClient) SyncStatus(endpoints []string) []ExecutionSyncingStat
 
 	return responses
 }
+
+/*
+BlockByNumber :
+Get a block header using the json-rpc API method 'eth_getBlockByNumber'.
+
+params :-
+a. endpoint string
+Endpoint to get the block from
+b. number uint64
+Block number
+
+returns :-
+a. ExecutionBlock
+Block header
+b. error
+Error if any
+*/
+func (ec *ExecutionClient) BlockByNumber(endpoint string, number uint64) (ExecutionBlock, error) {
+	result, err := ec.Call(endpoint, "eth_getBlockByNumber", fmt.Sprintf("0x%x", number), false)
+	if err != nil {
+		return ExecutionBlock{}, err
+	}
+
+	// Result is 'null' if the node doesn't have the block yet
+	if string(result) == "null" || len(result) == 0 {
+		return ExecutionBlock{}, fmt.Errorf(BlockNotFoundError, number, endpoint)
+	}
+
+	return unmarshalData(result, ExecutionBlock{})
+}
+
+/*
+FullBlockByNumber :
+Get a block with full transaction objects using the json-rpc API method 'eth_getBlockByNumber'.
+
+params :-
+a. endpoint string
+Endpoint to get the block from
+b. number uint64
+Block number
+
+returns :-
+a. FullExecutionBlock
+Block header and transactions
+b. error
+Error if any
+*/
+func (ec *ExecutionClient) FullBlockByNumber(endpoint string, number uint64) (FullExecutionBlock, error) {
+	result, err := ec.Call(endpoint, "eth_getBlockByNumber", fmt.Sprintf("0x%x", number), true)
+	if err != nil {
+		return FullExecutionBlock{}, err
+	}
+
+	if string(result) == "null" || len(result) == 0 {
+		return FullExecutionBlock{}, fmt.Errorf(BlockNotFoundError, number, endpoint)
+	}
+
+	return unmarshalData(result, FullExecutionBlock{})
+}
+
+/*
+BlockReceipts :
+Get the receipts of every transaction of a block using the json-rpc API method 'eth_getBlockReceipts'. Not every client supports it.
+
+params :-
+a. endpoint string
+Endpoint to get the receipts from
+b. number uint64
+Block number
+
+returns :-
+a. []Receipt
+Receipts in transaction order
+b. error
+Error if any
+*/
+func (ec *ExecutionClient) BlockReceipts(endpoint string, number uint64) ([]Receipt, error) {
+	result, err := ec.Call(endpoint, "eth_getBlockReceipts", fmt.Sprintf("0x%x", number))
+	if err != nil {
+		return nil, err
+	}
+
+	if string(result) == "null" || len(result) == 0 {
+		return nil, fmt.Errorf(BlockNotFoundError, number, endpoint)
+	}
+
+	return unmarshalData(result, []Receipt{})
+}
+
+/*
+TransactionReceipt :
+Get the receipt of a transaction using the json-rpc API method 'eth_getTransactionReceipt'.
+
+params :-
+a. endpoint string
+Endpoint to get the receipt from
+b. hash string
+Transaction hash
+
+returns :-
+a. Receipt
+Transaction receipt
+b. error
+Error if any
+*/
+func (ec *ExecutionClient) TransactionReceipt(endpoint, hash string) (Receipt, error) {
+	result, err := ec.Call(endpoint, "eth_getTransactionReceipt", hash)
+	if err != nil {
+		return Receipt{}, err
+	}
+
+	if string(result) == "null" || len(result) == 0 {
+		return Receipt{}, fmt.Errorf(ReceiptNotFoundError, hash, endpoint)
+	}
+
+	return unmarshalData(result, Receipt{})
+}
@@ -331,3 +331,263 @@ func TestETH1SyncStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestBlockByNumber(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		handler handler
+		want    ExecutionBlock
+		isError bool
+	}{
+		{
+			"Test case 1, good call",
+			func(rw http.ResponseWriter, req *http.Request) {
+				if err := validateReq(req, "eth_getBlockByNumber"); err != nil {
+					t.Fatalf("Request validation failed. Error: %v", err)
+				}
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"hash":"0xcc","number":"0x4d2","parentHash":"0xee","miner":"0xdd","timestamp":"0x64"}}`))
+			},
+			ExecutionBlock{Hash: "0xcc", Number: 1234, ParentHash: "0xee", Miner: "0xdd", Timestamp: 100},
+			false,
+		},
+		{
+			"Test case 2, block not found",
+			func(rw http.ResponseWriter, req *http.Request) {
+				if err := validateReq(req, "eth_getBlockByNumber"); err != nil {
+					t.Fatalf("Request validation failed. Error: %v", err)
+				}
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":null}`))
+			},
+			ExecutionBlock{},
+			true,
+		},
+		{
+			"Test case 3, response error",
+			func(rw http.ResponseWriter, req *http.Request) {
+				if err := validateReq(req, "eth_getBlockByNumber"); err != nil {
+					t.Fatalf("Request validation failed. Error: %v", err)
+				}
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(`{"jsonrpc":"2.0","error":{"code":-32000,"message":"Internal error"},"id":1}`))
+			},
+			ExecutionBlock{},
+			true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := setupServer(tc.handler)
+			defer srv.Close()
+
+			client := ExecutionClient{RetryDuration: time.Millisecond * 100}
+			got, err := client.BlockByNumber(srv.URL, 1234)
+
+			descr := "BlockByNumber(1234)"
+			if err = utils.CheckErr(descr, tc.isError, err); err != nil {
+				t.Error(err)
+			}
+			assert.Equal(t, tc.want, got, descr)
+		})
+	}
+}
+
+func TestFullBlockByNumber(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		handler handler
+		// block without transactions and transaction values in wei
+		want    ExecutionBlock
+		values  []string
+		isError bool
+	}{
+		{
+			"Test case 1, good call",
+			func(rw http.ResponseWriter, req *http.Request) {
+				if err := validateReq(req, "eth_getBlockByNumber"); err != nil {
+					t.Fatalf("Request validation failed. Error: %v", err)
+				}
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"hash":"0xcc","number":"0x4d2","parentHash":"0xee","miner":"0xdd","timestamp":"0x64","baseFeePerGas":"0x3b9aca00",
+					"transactions":[{"hash":"0x01","from":"0xaa","to":"0xbb","value":"0x0"},{"hash":"0x02","from":"0xdd","to":"0xff","value":"0x1bc16d674ec800000"}]}}`))
+			},
+			ExecutionBlock{Hash: "0xcc", Number: 1234, ParentHash: "0xee", Miner: "0xdd", Timestamp: 100, BaseFeePerGas: 1000000000},
+			[]string{"0", "32000000000000000000"},
+			false,
+		},
+		{
+			"Test case 2, block not found",
+			func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":null}`))
+			},
+			ExecutionBlock{},
+			[]string{},
+			true,
+		},
+		{
+			"Test case 3, invalid value",
+			func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"hash":"0xcc","transactions":[{"hash":"0x01","value":"12"}]}}`))
+			},
+			ExecutionBlock{Hash: "0xcc"},
+			[]string{},
+			true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := setupServer(tc.handler)
+			defer srv.Close()
+
+			client := ExecutionClient{RetryDuration: time.Millisecond * 100}
+			got, err := client.FullBlockByNumber(srv.URL, 1234)
+
+			descr := "FullBlockByNumber(1234)"
+			if err = utils.CheckErr(descr, tc.isError, err); err != nil {
+				t.Error(err)
+			}
+			if tc.isError {
+				return
+			}
+			assert.Equal(t, tc.want, got.ExecutionBlock, descr)
+			values := make([]string, len(got.Transactions))
+			for i, tx := range got.Transactions {
+				values[i] = tx.Value.String()
+			}
+			assert.Equal(t, tc.values, values, descr)
+		})
+	}
+}
+
+func TestBlockReceipts(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		handler handler
+		// gas used and effective gas prices in wei of the receipts
+		gasUsed []Quantity
+		prices  []string
+		isError bool
+	}{
+		{
+			"Test case 1, good call",
+			func(rw http.ResponseWriter, req *http.Request) {
+				if err := validateReq(req, "eth_getBlockReceipts"); err != nil {
+					t.Fatalf("Request validation failed. Error: %v", err)
+				}
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":[{"transactionHash":"0x01","gasUsed":"0x5208","effectiveGasPrice":"0x3b9aca00"},{"transactionHash":"0x02","gasUsed":"0x5208","effectiveGasPrice":"0x77359400"}]}`))
+			},
+			[]Quantity{21000, 21000},
+			[]string{"1000000000", "2000000000"},
+			false,
+		},
+		{
+			"Test case 2, method not supported",
+			func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(`{"jsonrpc":"2.0","error":{"code":-32601,"message":"the method eth_getBlockReceipts does not exist/is not available"},"id":1}`))
+			},
+			nil,
+			nil,
+			true,
+		},
+		{
+			"Test case 3, block not found",
+			func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":null}`))
+			},
+			nil,
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := setupServer(tc.handler)
+			defer srv.Close()
+
+			client := ExecutionClient{RetryDuration: time.Millisecond * 100}
+			got, err := client.BlockReceipts(srv.URL, 1234)
+
+			descr := "BlockReceipts(1234)"
+			if err = utils.CheckErr(descr, tc.isError, err); err != nil {
+				t.Error(err)
+			}
+			if tc.isError {
+				return
+			}
+			if assert.Len(t, got, len(tc.gasUsed), descr) {
+				for i, r := range got {
+					assert.Equal(t, tc.gasUsed[i], r.GasUsed, descr)
+					assert.Equal(t, tc.prices[i], r.EffectiveGasPrice.String(), descr)
+				}
+			}
+		})
+	}
+}
+
+func TestTransactionReceipt(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		handler handler
+		gasUsed Quantity
+		price   string
+		isError bool
+	}{
+		{
+			"Test case 1, good call",
+			func(rw http.ResponseWriter, req *http.Request) {
+				if err := validateReq(req, "eth_getTransactionReceipt"); err != nil {
+					t.Fatalf("Request validation failed. Error: %v", err)
+				}
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"transactionHash":"0x01","gasUsed":"0x5208","effectiveGasPrice":"0x3b9aca00"}}`))
+			},
+			21000,
+			"1000000000",
+			false,
+		},
+		{
+			"Test case 2, receipt not found",
+			func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":null}`))
+			},
+			0,
+			"0",
+			true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := setupServer(tc.handler)
+			defer srv.Close()
+
+			client := ExecutionClient{RetryDuration: time.Millisecond * 100}
+			got, err := client.TransactionReceipt(srv.URL, "0x01")
+
+			descr := "TransactionReceipt(0x01)"
+			if err = utils.CheckErr(descr, tc.isError, err); err != nil {
+				t.Error(err)
+			}
+			assert.Equal(t, tc.gasUsed, got.GasUsed, descr)
+			assert.Equal(t, tc.price, got.EffectiveGasPrice.String(), descr)
+		})
+	}
+}
@@ -4,7 +4,12 @@ import "encoding/json"
 
 // Subscriber : Interface Represents a subscriber for a given topic
 type Subscriber interface {
-	Listen(url string, ch chan<- Checkpoint)
+	Listen(done <-chan struct{}, url string, ch chan<- Checkpoint)
+}
+
+// EventSubscriber : Interface Represents a subscriber for several beacon chain event topics
+type EventSubscriber interface {
+	ListenEvents(done <-chan struct{}, url string, ch chan<- Event)
 }
 
 // BeaconAPI : Interface for Beacon chain HTTP API
@@ -13,10 +18,37 @@ type BeaconAPI interface {
 	ValidatorBalances(stateID string, validatorIdxs []string) ([]ValidatorBalance, error)
 	Health(endpoints []string) []HealthResponse
 	SyncStatus(endpoints []string) []BeaconSyncingStatus
+	NodeInfo(endpoints []string) []NodeInfo
+	Block(endpoint, blockID string) (BeaconBlock, error)
+	Genesis(endpoint string) (Genesis, error)
+	Spec(endpoint string) (Spec, error)
+	Validators(stateID string, ids []string) ([]ValidatorInfo, error)
+	ActiveValidators(stateID string) ([]ValidatorInfo, error)
+	ProposerDuties(epoch string) ([]ProposerDuty, error)
+	Committees(stateID, epoch string) ([]Committee, error)
+	FinalityCheckpoints(stateID string) (FinalityCheckpoints, error)
+	Liveness(epoch string, indexes []string) ([]ValidatorLiveness, error)
+	ProposerPreparations(endpoint string) ([]ProposerPreparation, error)
 }
 
 // ExecutionAPI : Interface for ETH1 JSON RPC API
 type ExecutionAPI interface {
 	Call(endpoint, method string, params ...any) (json.RawMessage, error)
 	SyncStatus(endpoints []string) []ExecutionSyncingStatus
+	BlockByNumber(endpoint string, number uint64) (ExecutionBlock, error)
+	FullBlockByNumber(endpoint string, number uint64) (FullExecutionBlock, error)
+	BlockReceipts(endpoint string, number uint64) ([]Receipt, error)
+	TransactionReceipt(endpoint, hash string) (Receipt, error)
+}
+
+// KeymanagerAPI : Interface for validator client keymanager API
+type KeymanagerAPI interface {
+	Keystores(endpoint, token string) ([]Keystore, error)
+	RemoteKeys(endpoint, token string) ([]RemoteKey, error)
+}
+
+// RelayAPI : Interface for MEV-boost relays data API and mev-boost builder API
+type RelayAPI interface {
+	DeliveredPayloads(relay string, slot uint64) ([]BidTrace, error)
+	BuilderStatus(endpoint string) error
 }
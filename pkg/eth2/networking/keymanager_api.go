@@ -0,0 +1,73 @@
+package networking
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// KeymanagerClient : Struct KeymanagerAPI interface implementation
+type KeymanagerClient struct {
+	// Time between retries when a request fails
+	RetryDuration time.Duration
+}
+
+/*
+Keystores :
+Get the keystores loaded in a validator client using the keymanager API method '/eth/v1/keystores'.
+
+params :-
+a. endpoint string
+Keymanager API endpoint of the validator client
+b. token string
+Bearer token of the keymanager API
+
+returns :-
+a. []Keystore
+Keystores loaded in the validator client
+b. error
+Error if any
+*/
+func (kc *KeymanagerClient) Keystores(endpoint, token string) ([]Keystore, error) {
+	resp, err := getDataWithHeaders(endpoint+"/eth/v1/keystores", authHeaders(token), kc.RetryDuration, KeystoresResponse{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+/*
+RemoteKeys :
+Get the remote signer keys loaded in a validator client using the keymanager API method '/eth/v1/remotekeys'. Validator clients without remote signer support give no keys.
+
+params :-
+a. endpoint string
+Keymanager API endpoint of the validator client
+b. token string
+Bearer token of the keymanager API
+
+returns :-
+a. []RemoteKey
+Remote keys loaded in the validator client
+b. error
+Error if any
+*/
+func (kc *KeymanagerClient) RemoteKeys(endpoint, token string) ([]RemoteKey, error) {
+	resp, err := getDataWithHeaders(endpoint+"/eth/v1/remotekeys", authHeaders(token), kc.RetryDuration, RemoteKeysResponse{})
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.Code == http.StatusNotFound {
+		return []RemoteKey{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// authHeaders : Get the headers to authenticate against the keymanager API
+func authHeaders(token string) map[string]string {
+	if token == "" {
+		return nil
+	}
+	return map[string]string{"Authorization": "Bearer " + token}
+}
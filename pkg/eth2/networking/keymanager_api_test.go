@@ -0,0 +1,114 @@
+package networking
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeystores(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		token   string
+		handler handler
+		want    []Keystore
+		isError bool
+	}{
+		{
+			"Test Case 1, unauthorized",
+			"bad",
+			func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusUnauthorized)
+			},
+			nil,
+			true,
+		},
+		{
+			"Test Case 2, good keystores",
+			"api-token-0x1234",
+			func(rw http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != "/eth/v1/keystores" {
+					t.Errorf("Unexpected path %s", req.URL.Path)
+				}
+				if req.Header.Get("Authorization") != "Bearer api-token-0x1234" {
+					rw.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(`{"data":[{"validating_pubkey":"0xa1","derivation_path":"m/12381/3600/0/0/0","readonly":false}]}`))
+			},
+			[]Keystore{{ValidatingPubkey: "0xa1", DerivationPath: "m/12381/3600/0/0/0"}},
+			false,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := setupServer(tc.handler)
+			defer srv.Close()
+
+			client := KeymanagerClient{RetryDuration: time.Millisecond * 100}
+			got, err := client.Keystores(srv.URL, tc.token)
+
+			assert.Equal(t, tc.isError, err != nil, "Keystores() gave unexpected error %v", err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestRemoteKeys(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		handler handler
+		want    []RemoteKey
+		isError bool
+	}{
+		{
+			"Test Case 1, remote keys not supported",
+			func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusNotFound)
+			},
+			[]RemoteKey{},
+			false,
+		},
+		{
+			"Test Case 2, server error",
+			func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusInternalServerError)
+			},
+			nil,
+			true,
+		},
+		{
+			"Test Case 3, good remote keys",
+			func(rw http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != "/eth/v1/remotekeys" {
+					t.Errorf("Unexpected path %s", req.URL.Path)
+				}
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(`{"data":[{"pubkey":"0xb2","url":"https://signer:9000","readonly":true}]}`))
+			},
+			[]RemoteKey{{Pubkey: "0xb2", URL: "https://signer:9000", Readonly: true}},
+			false,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := setupServer(tc.handler)
+			defer srv.Close()
+
+			client := KeymanagerClient{RetryDuration: time.Millisecond * 100}
+			got, err := client.RemoteKeys(srv.URL, "token")
+
+			assert.Equal(t, tc.isError, err != nil, "RemoteKeys() gave unexpected error %v", err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package networking
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/NethermindEth/posmoni/internal/utils"
+)
+
+// RelayClient : Struct RelayAPI interface implementation
+type RelayClient struct {
+	// Time between retries when a request fails
+	RetryDuration time.Duration
+}
+
+/*
+DeliveredPayloads :
+Get the payloads delivered by a relay to proposers at a slot using the relay data API method '/relay/v1/data/bidtraces/proposer_payload_delivered'.
+
+params :-
+a. relay string
+Relay URL, without the relay public key
+b. slot uint64
+Slot of the payloads
+
+returns :-
+a. []BidTrace
+Payloads delivered at the slot. Empty if the relay delivered none
+b. error
+Error if any
+*/
+func (rc *RelayClient) DeliveredPayloads(relay string, slot uint64) ([]BidTrace, error) {
+	url := fmt.Sprintf("%s/relay/v1/data/bidtraces/proposer_payload_delivered?slot=%d", relay, slot)
+	return getData(url, rc.RetryDuration, []BidTrace{})
+}
+
+/*
+BuilderStatus :
+Check if mev-boost is up and connected to at least one relay using the builder API method '/eth/v1/builder/status'.
+
+params :-
+a. endpoint string
+mev-boost endpoint
+
+returns :-
+a. error
+Nil if mev-boost is up, a *StatusError if it has no relay available, or the request error
+*/
+func (rc *RelayClient) BuilderStatus(endpoint string) error {
+	url := endpoint + "/eth/v1/builder/status"
+	resp, err := utils.GetRequest(url, rc.RetryDuration)
+	if err != nil {
+		return fmt.Errorf(RequestFailedError, url, err)
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return &StatusError{URL: url, Code: resp.StatusCode, Body: string(body)}
+	}
+	return nil
+}
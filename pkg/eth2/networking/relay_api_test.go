@@ -0,0 +1,111 @@
+package networking
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeliveredPayloads(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		handler handler
+		want    []BidTrace
+		isError bool
+	}{
+		{
+			"Test Case 1, relay error",
+			func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusInternalServerError)
+			},
+			[]BidTrace{},
+			true,
+		},
+		{
+			"Test Case 2, no payload delivered",
+			func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(`[]`))
+			},
+			[]BidTrace{},
+			false,
+		},
+		{
+			"Test Case 3, payload delivered",
+			func(rw http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != "/relay/v1/data/bidtraces/proposer_payload_delivered" || req.URL.Query().Get("slot") != "7000000" {
+					t.Errorf("Unexpected request %s", req.URL)
+				}
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(`[{"slot":"7000000","parent_hash":"0x01","block_hash":"0x02","builder_pubkey":"0xb1","proposer_pubkey":"0xa1","proposer_fee_recipient":"0xfe","gas_limit":"30000000","gas_used":"12000000","value":"51234567890123456","block_number":"17800000","num_tx":"150"}]`))
+			},
+			[]BidTrace{{
+				Slot: "7000000", ParentHash: "0x01", BlockHash: "0x02", BuilderPubkey: "0xb1", ProposerPubkey: "0xa1", ProposerFeeRecipient: "0xfe",
+				GasLimit: "30000000", GasUsed: "12000000", Value: "51234567890123456", BlockNumber: "17800000", NumTx: "150",
+			}},
+			false,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := setupServer(tc.handler)
+			defer srv.Close()
+
+			client := RelayClient{RetryDuration: time.Millisecond * 100}
+			got, err := client.DeliveredPayloads(srv.URL, 7000000)
+
+			assert.Equal(t, tc.isError, err != nil, "DeliveredPayloads() gave unexpected error %v", err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestBuilderStatus(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		handler handler
+		isError bool
+	}{
+		{
+			"Test Case 1, no relay available",
+			func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusServiceUnavailable)
+			},
+			true,
+		},
+		{
+			"Test Case 2, mev-boost up",
+			func(rw http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != "/eth/v1/builder/status" {
+					t.Errorf("Unexpected path %s", req.URL.Path)
+				}
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(`{}`))
+			},
+			false,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := setupServer(tc.handler)
+			defer srv.Close()
+
+			client := RelayClient{RetryDuration: time.Millisecond * 100}
+			err := client.BuilderStatus(srv.URL)
+
+			assert.Equal(t, tc.isError, err != nil, "BuilderStatus() gave unexpected error %v", err)
+		})
+	}
+
+	// mev-boost down
+	client := RelayClient{RetryDuration: time.Millisecond * 100}
+	assert.Error(t, client.BuilderStatus("http://127.0.0.1:1"))
+}
@@ -1,6 +1,9 @@
 package networking
 
 import (
+	"context"
+	"sync"
+
 	"github.com/NethermindEth/posmoni/configs"
 	sse "github.com/r3labs/sse/v2"
 	log "github.com/sirupsen/logrus"
@@ -12,24 +15,28 @@ type SSESubscriber struct {
 
 /*
 Listen :
-Subscribe to beacon chain SSE events and listen for new beacon chain checkpoints.
+Subscribe to beacon chain SSE events and listen for new beacon chain checkpoints, until done is closed. The connection is closed when done is closed.
 
 params :-
-a. url string
+a. done <-chan struct{}
+Channel to get stop signal from
+b. url string
 URL to subscribe to
-b. ch chan<- Checkpoint
+c. ch chan<- Checkpoint
 Channel to send new checkpoints to
 
 returns :-
 none
 */
-func (s SSESubscriber) Listen(url string, ch chan<- Checkpoint) {
+func (s SSESubscriber) Listen(done <-chan struct{}, url string, ch chan<- Checkpoint) {
 	// notest
 	logFields := log.Fields{configs.Component: "SSESubscriber", "Method": "Listen"}
 	log.WithFields(logFields).Info("Subscribing to: ", url)
 
+	ctx, cancel := contextUntil(done)
+	defer cancel()
 	client := sse.NewClient(url)
-	client.SubscribeRaw(func(msg *sse.Event) {
+	client.SubscribeRawWithContext(ctx, func(msg *sse.Event) {
 		if len(msg.Data) == 0 {
 			log.WithFields(logFields).Debug("Got empty event")
 			return
@@ -40,10 +47,65 @@ func (s SSESubscriber) Listen(url string, ch chan<- Checkpoint) {
 		chkp, err := unmarshalData(msg.Data, Checkpoint{})
 		if err != nil {
 			log.WithFields(logFields).Errorf(parseDataError, err)
-		} else {
-			ch <- chkp
+			return
+		}
+		select {
+		case ch <- chkp:
+		case <-done:
 		}
 	})
+	log.WithFields(logFields).Info("Unsubscribed from: ", url)
+}
+
+/*
+ListenEvents :
+Subscribe to beacon chain SSE events of several topics and forward them without decoding, until done is closed. The connection is closed when done is closed.
+
+params :-
+a. done <-chan struct{}
+Channel to get stop signal from
+b. url string
+URL to subscribe to
+c. ch chan<- Event
+Channel to send new events to
+
+returns :-
+none
+*/
+func (s SSESubscriber) ListenEvents(done <-chan struct{}, url string, ch chan<- Event) {
+	// notest
+	logFields := log.Fields{configs.Component: "SSESubscriber", "Method": "ListenEvents"}
+	log.WithFields(logFields).Info("Subscribing to: ", url)
+
+	ctx, cancel := contextUntil(done)
+	defer cancel()
+	client := sse.NewClient(url)
+	client.SubscribeRawWithContext(ctx, func(msg *sse.Event) {
+		if len(msg.Data) == 0 {
+			log.WithFields(logFields).Debug("Got empty event")
+			return
+		}
+
+		log.WithFields(logFields).Debugf("Got %s event data: %v", string(msg.Event), string(msg.Data))
+		select {
+		case ch <- Event{Topic: string(msg.Event), Data: msg.Data}:
+		case <-done:
+		}
+	})
+	log.WithFields(logFields).Info("Unsubscribed from: ", url)
+}
+
+// contextUntil : Get a context cancelled when done is closed, to close SSE connections with it
+func contextUntil(done <-chan struct{}) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case <-done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
 }
 
 /*
@@ -63,15 +125,92 @@ Channel to get new checkpoints from
 func Subscribe(done <-chan struct{}, sub SubscribeOpts) <-chan Checkpoint {
 	logFields := log.Fields{"Method": "Subscribe"}
 	c := make(chan Checkpoint)
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+
+	//TODO: Add support for multiple endpoints. This only works well for one endpoint. Probably consistency checks are needed.
+	for _, endpoint := range sub.Endpoints {
+		// Listeners stop with the forwarders, so their channel is never closed
+		in := make(chan Checkpoint)
+		go sub.Subscriber.Listen(stop, endpoint+sub.StreamURL, in)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				case chkp := <-in:
+					select {
+					case c <- chkp:
+					case <-stop:
+						return
+					}
+				}
+			}
+		}()
+	}
 
 	go func() {
-		//TODO: Add support for multiple endpoints. This only works well for one endpoint. Probably consistency checks are needed.
-		for _, endpoint := range sub.Endpoints {
-			url := endpoint + sub.StreamURL
-			go sub.Subscriber.Listen(url, c)
-		}
+		<-done
+		close(stop)
+		// Wait for forwarders to stop before closing, so no checkpoint is sent on a closed channel
+		wg.Wait()
+		log.WithFields(logFields).Info("Subscription to ", sub.StreamURL, " ended")
+		close(c)
+	}()
+
+	return c
+}
 
+/*
+SubscribeEvents :
+Setup subscriptions to several beacon chain event topics using several beacon node endpoints. Events are tagged with the endpoint they come from.
+
+params :-
+a. done <- chan struct{}
+Channel to get stop listening signal from
+b. sub EventSubscribeOpts
+Subscription data and handlers
+
+returns :-
+a. <-chan Event
+Channel to get new events from
+*/
+func SubscribeEvents(done <-chan struct{}, sub EventSubscribeOpts) <-chan Event {
+	logFields := log.Fields{"Method": "SubscribeEvents"}
+	c := make(chan Event)
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+
+	for _, endpoint := range sub.Endpoints {
+		// Listeners stop with the forwarders, so their channel is never closed
+		in := make(chan Event)
+		go sub.Subscriber.ListenEvents(stop, endpoint+sub.StreamURL, in)
+		wg.Add(1)
+		go func(endpoint string) {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				case ev := <-in:
+					ev.Endpoint = endpoint
+					select {
+					case c <- ev:
+					case <-stop:
+						return
+					}
+				}
+			}
+		}(endpoint)
+	}
+
+	go func() {
 		<-done
+		close(stop)
+		// Wait for forwarders to stop before closing, so no event is sent on a closed channel
+		wg.Wait()
 		log.WithFields(logFields).Info("Subscription to ", sub.StreamURL, " ended")
 		close(c)
 	}()
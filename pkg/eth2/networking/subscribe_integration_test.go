@@ -15,7 +15,8 @@ func TestListen(t *testing.T) {
 
 	sub := SSESubscriber{}
 	ch := make(chan Checkpoint)
-	defer close(ch)
+	done := make(chan struct{})
+	defer close(done)
 
 	raw, exists := os.LookupEnv("PM_BC_ENDPOINTS")
 	if !exists {
@@ -23,7 +24,7 @@ func TestListen(t *testing.T) {
 	}
 	endpoint := strings.Split(raw, ",")[0]
 
-	go sub.Listen(endpoint+FinalizedCkptTopic, ch)
+	go sub.Listen(done, endpoint+FinalizedCkptTopic, ch)
 
 	for event := range ch {
 		t.Logf("Checkpoint received: %+v", event)
@@ -11,9 +11,13 @@ type testSubscriber struct {
 	data map[string][]Checkpoint
 }
 
-func (s testSubscriber) Listen(url string, ch chan<- Checkpoint) {
+func (s testSubscriber) Listen(done <-chan struct{}, url string, ch chan<- Checkpoint) {
 	for _, data := range s.data[url] {
-		ch <- data
+		select {
+		case ch <- data:
+		case <-done:
+			return
+		}
 		//sleep to simulate a delay
 		time.Sleep(time.Millisecond * 50)
 	}
@@ -82,3 +86,133 @@ func TestSubscribe(t *testing.T) {
 		})
 	}
 }
+
+type testEventSubscriber struct {
+	data map[string][]Event
+}
+
+func (s testEventSubscriber) ListenEvents(done <-chan struct{}, url string, ch chan<- Event) {
+	for _, data := range s.data[url] {
+		select {
+		case ch <- data:
+		case <-done:
+			return
+		}
+	}
+}
+
+func TestSubscribeEvents(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name      string
+		endpoints []string
+		messages  map[string][]Event
+		want      []Event
+	}{
+		{
+			"Case 1 - No endpoints",
+			[]string{},
+			map[string][]Event{},
+			[]Event{},
+		},
+		{
+			"Case 2 - 1 endpoint, several topics",
+			[]string{"Endpoint1"},
+			map[string][]Event{
+				"Endpoint1" + ReorgTopics: {
+					{Topic: HeadEvent, Data: []byte(`{"slot":"10"}`)},
+					{Topic: ChainReorgEvent, Data: []byte(`{"slot":"10","depth":"1"}`)},
+				},
+			},
+			[]Event{
+				{Endpoint: "Endpoint1", Topic: HeadEvent, Data: []byte(`{"slot":"10"}`)},
+				{Endpoint: "Endpoint1", Topic: ChainReorgEvent, Data: []byte(`{"slot":"10","depth":"1"}`)},
+			},
+		},
+		{
+			"Case 3 - 2 endpoints",
+			[]string{"Endpoint1", "Endpoint2"},
+			map[string][]Event{
+				"Endpoint1" + ReorgTopics: {
+					{Topic: HeadEvent, Data: []byte(`{"slot":"10"}`)},
+				},
+				"Endpoint2" + ReorgTopics: {
+					{Topic: HeadEvent, Data: []byte(`{"slot":"11"}`)},
+				},
+			},
+			[]Event{
+				{Endpoint: "Endpoint1", Topic: HeadEvent, Data: []byte(`{"slot":"10"}`)},
+				{Endpoint: "Endpoint2", Topic: HeadEvent, Data: []byte(`{"slot":"11"}`)},
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			done := make(chan struct{})
+			sub := EventSubscribeOpts{
+				Endpoints:  tc.endpoints,
+				StreamURL:  ReorgTopics,
+				Subscriber: testEventSubscriber{data: tc.messages},
+			}
+			ch := SubscribeEvents(done, sub)
+
+			got := make([]Event, 0)
+			for i := 0; i < len(tc.want); i++ {
+				select {
+				case ev := <-ch:
+					got = append(got, ev)
+				case <-time.After(time.Second):
+					t.Fatal("SubscribeEvents() timed out")
+				}
+			}
+			close(done)
+
+			// Events of different endpoints may arrive in any order
+			assert.ElementsMatch(t, tc.want, got)
+			// Channel should be closed after done
+			for range ch {
+			}
+		})
+	}
+}
+
+// blockingSubscriber : Listener that holds its connection until it is stopped, and reports when it is
+type blockingSubscriber struct {
+	stopped chan string
+}
+
+func (s blockingSubscriber) Listen(done <-chan struct{}, url string, ch chan<- Checkpoint) {
+	<-done
+	s.stopped <- url
+}
+
+func (s blockingSubscriber) ListenEvents(done <-chan struct{}, url string, ch chan<- Event) {
+	<-done
+	s.stopped <- url
+}
+
+func TestSubscribeStopsListeners(t *testing.T) {
+	t.Parallel()
+
+	sub := blockingSubscriber{stopped: make(chan string, 4)}
+	done := make(chan struct{})
+	Subscribe(done, SubscribeOpts{Endpoints: []string{"Endpoint1", "Endpoint2"}, StreamURL: FinalizedCkptTopic, Subscriber: sub})
+	SubscribeEvents(done, EventSubscribeOpts{Endpoints: []string{"Endpoint1", "Endpoint2"}, StreamURL: ReorgTopics, Subscriber: sub})
+	close(done)
+
+	got := make([]string, 0)
+	for i := 0; i < 4; i++ {
+		select {
+		case url := <-sub.stopped:
+			got = append(got, url)
+		case <-time.After(time.Second):
+			t.Fatal("listeners were not stopped")
+		}
+	}
+	assert.ElementsMatch(t, []string{
+		"Endpoint1" + FinalizedCkptTopic, "Endpoint2" + FinalizedCkptTopic,
+		"Endpoint1" + ReorgTopics, "Endpoint2" + ReorgTopics,
+	}, got)
+}
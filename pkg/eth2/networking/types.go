@@ -2,6 +2,7 @@ package networking
 
 import (
 	"encoding/json"
+	"math/big"
 )
 
 // Checkpoint : Struct Represent event data from beacon chain
@@ -21,6 +22,47 @@ type SubscribeOpts struct {
 	Subscriber Subscriber
 }
 
+// EventSubscribeOpts : Struct Represent subscription data and handlers for several event topics
+type EventSubscribeOpts struct {
+	// Endpoints exposing beacon chain API
+	Endpoints []string
+	// URL and topics to subscribe to within an endpoint
+	StreamURL string
+	// Interface with ListenEvents implementation to subscribe to beacon chain events
+	Subscriber EventSubscriber
+}
+
+// Event : Struct Represent a beacon chain event of any topic
+type Event struct {
+	// Endpoint the event was received from
+	Endpoint string
+	// Event name, e.g. head or chain_reorg
+	Topic string
+	// Raw event data
+	Data []byte
+}
+
+// Head : Struct Represent data of a head event
+type Head struct {
+	Slot                string `json:"slot"`
+	Block               string `json:"block"`
+	State               string `json:"state"`
+	EpochTransition     bool   `json:"epoch_transition"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+}
+
+// ChainReorg : Struct Represent data of a chain_reorg event
+type ChainReorg struct {
+	Slot                string `json:"slot"`
+	Depth               string `json:"depth"`
+	OldHeadBlock        string `json:"old_head_block"`
+	NewHeadBlock        string `json:"new_head_block"`
+	OldHeadState        string `json:"old_head_state"`
+	NewHeadState        string `json:"new_head_state"`
+	Epoch               string `json:"epoch"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+}
+
 // ValidatorBalanceList : Struct Represent response data from 'http://<endpoint>/eth/v1/beacon/states/<stateID>/validator_balances' API call
 type ValidatorBalanceList struct {
 	Data []ValidatorBalance `json:"data"`
@@ -49,8 +91,11 @@ type BeaconSyncingStatus struct {
 	HeadSlot     string `json:"head_slot"`
 	SyncDistance string `json:"sync_distance"`
 	IsSyncing    bool   `json:"is_syncing"`
-	Error        error
-	Endpoint     string
+	IsOptimistic bool   `json:"is_optimistic"`
+	// True if the consensus node can't reach its execution node
+	ElOffline bool `json:"el_offline"`
+	Error     error
+	Endpoint  string
 }
 
 // eth1Request : Struct Represent a ETH1 json-rpc method call body
@@ -71,10 +116,349 @@ type eth1Response struct {
 
 // ExecutionSyncingStatus : Struct Represent response data from 'eth_syncing' json-rpc API call
 type ExecutionSyncingStatus struct {
-	StartingBlock string `json:"startingBloc"`
-	CurrentBlock  string `json:"currentBlock"`
-	HighestBlock  string `json:"highestBlock"`
-	IsSyncing     bool
-	Error         error
-	Endpoint      string
+	StartingBlock uint64
+	CurrentBlock  uint64
+	HighestBlock  uint64
+	// Geth only. State trie entries downloaded
+	PulledStates uint64
+	// Geth only. State trie entries known
+	KnownStates uint64
+	// Nethermind only. Current sync stage, e.g. 'SnapSync'
+	SyncMode  string
+	IsSyncing bool
+	Error     error
+	Endpoint  string
+}
+
+// Quantity : Represent a hex encoded unsigned integer from the json-rpc API, e.g. "0x1b4"
+type Quantity uint64
+
+// BigQuantity : Represent a hex encoded unsigned integer from the json-rpc API that can overflow uint64, like amounts in wei
+type BigQuantity struct {
+	big.Int
+}
+
+// ethSyncingResult : Struct Represent result object of 'eth_syncing' json-rpc API call when the node is syncing
+type ethSyncingResult struct {
+	StartingBlock Quantity        `json:"startingBlock"`
+	CurrentBlock  Quantity        `json:"currentBlock"`
+	HighestBlock  Quantity        `json:"highestBlock"`
+	PulledStates  Quantity        `json:"pulledStates"`
+	KnownStates   Quantity        `json:"knownStates"`
+	SyncMode      json.RawMessage `json:"syncMode"`
+}
+
+// PeerCountResponse : Struct Represent response body from 'http://<endpoint>/eth/v1/node/peer_count' API call
+type PeerCountResponse struct {
+	Data PeerCount `json:"data"`
+}
+
+// PeerCount : Struct Represent response data from 'http://<endpoint>/eth/v1/node/peer_count' API call
+type PeerCount struct {
+	Disconnected  string `json:"disconnected"`
+	Connecting    string `json:"connecting"`
+	Connected     string `json:"connected"`
+	Disconnecting string `json:"disconnecting"`
+}
+
+// PeersResponse : Struct Represent response body from 'http://<endpoint>/eth/v1/node/peers' API call
+type PeersResponse struct {
+	Data []Peer `json:"data"`
+}
+
+// Peer : Struct Represent a single entry of response data from 'http://<endpoint>/eth/v1/node/peers' API call
+type Peer struct {
+	PeerID             string `json:"peer_id"`
+	ENR                string `json:"enr"`
+	LastSeenP2PAddress string `json:"last_seen_p2p_address"`
+	State              string `json:"state"`
+	Direction          string `json:"direction"`
+}
+
+// NodeVersionResponse : Struct Represent response body from 'http://<endpoint>/eth/v1/node/version' API call
+type NodeVersionResponse struct {
+	Data struct {
+		Version string `json:"version"`
+	} `json:"data"`
+}
+
+// NodeIdentityResponse : Struct Represent response body from 'http://<endpoint>/eth/v1/node/identity' API call
+type NodeIdentityResponse struct {
+	Data NodeIdentity `json:"data"`
+}
+
+// NodeIdentity : Struct Represent response data from 'http://<endpoint>/eth/v1/node/identity' API call
+type NodeIdentity struct {
+	PeerID             string   `json:"peer_id"`
+	ENR                string   `json:"enr"`
+	P2PAddresses       []string `json:"p2p_addresses"`
+	DiscoveryAddresses []string `json:"discovery_addresses"`
+	Metadata           struct {
+		SeqNumber string `json:"seq_number"`
+		Attnets   string `json:"attnets"`
+		Syncnets  string `json:"syncnets"`
+	} `json:"metadata"`
+}
+
+// NodeInfo : Struct Represent diagnostics of a consensus node gathered from the '/eth/v1/node/*' API calls
+type NodeInfo struct {
+	Endpoint string
+	// True if the node answered 200 to the health check
+	Healthy bool
+	// True if the node answered 206 to the health check
+	Syncing   bool
+	Version   string
+	Identity  NodeIdentity
+	PeerCount PeerCount
+	// Connected peers of the node
+	Peers []Peer
+	Error error
+}
+
+// BeaconBlockResponse : Struct Represent response body from 'http://<endpoint>/eth/v2/beacon/blocks/<blockID>' API call
+type BeaconBlockResponse struct {
+	Version             string `json:"version"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+	Finalized           bool   `json:"finalized"`
+	Data                struct {
+		Message BeaconBlock `json:"message"`
+	} `json:"data"`
+}
+
+// BeaconBlock : Struct Represent a beacon block message from 'http://<endpoint>/eth/v2/beacon/blocks/<blockID>' API call
+type BeaconBlock struct {
+	Slot          string          `json:"slot"`
+	ProposerIndex string          `json:"proposer_index"`
+	ParentRoot    string          `json:"parent_root"`
+	StateRoot     string          `json:"state_root"`
+	Body          BeaconBlockBody `json:"body"`
+}
+
+// BeaconBlockBody : Struct Represent body of a beacon block
+type BeaconBlockBody struct {
+	Attestations []Attestation `json:"attestations"`
+	// Empty before the merge
+	ExecutionPayload ExecutionPayload `json:"execution_payload"`
+}
+
+// Attestation : Struct Represent an aggregated attestation included in a beacon block. Bits are hex encoded SSZ bitfields
+type Attestation struct {
+	AggregationBits string `json:"aggregation_bits"`
+	// Committees aggregated in the attestation since Electra, with their aggregation bits concatenated in committee order. Empty before
+	CommitteeBits string          `json:"committee_bits,omitempty"`
+	Data          AttestationData `json:"data"`
+}
+
+// AttestationData : Struct Represent the votes of an attestation
+type AttestationData struct {
+	Slot string `json:"slot"`
+	// Committee index before Electra, zero since
+	Index           string                `json:"index"`
+	BeaconBlockRoot string                `json:"beacon_block_root"`
+	Source          AttestationCheckpoint `json:"source"`
+	Target          AttestationCheckpoint `json:"target"`
+}
+
+// AttestationCheckpoint : Struct Represent a checkpoint voted by an attestation
+type AttestationCheckpoint struct {
+	Epoch string `json:"epoch"`
+	Root  string `json:"root"`
+}
+
+// ExecutionPayload : Struct Represent execution payload of a beacon block
+type ExecutionPayload struct {
+	BlockHash    string `json:"block_hash"`
+	BlockNumber  string `json:"block_number"`
+	FeeRecipient string `json:"fee_recipient"`
+	// Empty before Capella
+	Withdrawals []Withdrawal `json:"withdrawals"`
+}
+
+// Withdrawal : Struct Represent a withdrawal in the execution payload of a beacon block. Amount is in gwei
+type Withdrawal struct {
+	Index          string `json:"index"`
+	ValidatorIndex string `json:"validator_index"`
+	Address        string `json:"address"`
+	Amount         string `json:"amount"`
+}
+
+// ExecutionBlock : Struct Represent result of 'eth_getBlockByNumber' json-rpc API call
+type ExecutionBlock struct {
+	Hash       string   `json:"hash"`
+	Number     Quantity `json:"number"`
+	ParentHash string   `json:"parentHash"`
+	Miner      string   `json:"miner"`
+	Timestamp  Quantity `json:"timestamp"`
+	// Zero before London
+	BaseFeePerGas Quantity `json:"baseFeePerGas"`
+}
+
+// FullExecutionBlock : Struct Represent result of 'eth_getBlockByNumber' json-rpc API call with full transaction objects
+type FullExecutionBlock struct {
+	ExecutionBlock
+	Transactions []ExecutionTransaction `json:"transactions"`
+}
+
+// ExecutionTransaction : Struct Represent a transaction object of the json-rpc API. Value is in wei
+type ExecutionTransaction struct {
+	Hash  string      `json:"hash"`
+	From  string      `json:"from"`
+	To    string      `json:"to"`
+	Value BigQuantity `json:"value"`
+}
+
+// Receipt : Struct Represent result of 'eth_getTransactionReceipt' json-rpc API call, also returned for every transaction of a block by 'eth_getBlockReceipts'
+type Receipt struct {
+	TransactionHash string   `json:"transactionHash"`
+	GasUsed         Quantity `json:"gasUsed"`
+	// Price paid per gas in wei, base fee included
+	EffectiveGasPrice BigQuantity `json:"effectiveGasPrice"`
+}
+
+// GenesisResponse : Struct Represent response of /eth/v1/beacon/genesis
+type GenesisResponse struct {
+	Data Genesis `json:"data"`
+}
+
+// Genesis : Struct Represent genesis details of a beacon chain
+type Genesis struct {
+	GenesisTime           string `json:"genesis_time"`
+	GenesisValidatorsRoot string `json:"genesis_validators_root"`
+	GenesisForkVersion    string `json:"genesis_fork_version"`
+}
+
+// SpecResponse : Struct Represent response of /eth/v1/config/spec
+type SpecResponse struct {
+	Data Spec `json:"data"`
+}
+
+// Spec : Struct Represent the chain specification values used by the monitor. Values are decimal strings
+type Spec struct {
+	ConfigName     string `json:"CONFIG_NAME"`
+	PresetBase     string `json:"PRESET_BASE"`
+	SecondsPerSlot string `json:"SECONDS_PER_SLOT"`
+	SlotsPerEpoch  string `json:"SLOTS_PER_EPOCH"`
+	DepositChainID string `json:"DEPOSIT_CHAIN_ID"`
+}
+
+// CommitteesResponse : Struct Represent response body from 'http://<endpoint>/eth/v1/beacon/states/<stateID>/committees' API call
+type CommitteesResponse struct {
+	Data []Committee `json:"data"`
+}
+
+// Committee : Struct Represent an attester committee of a slot
+type Committee struct {
+	Index      string   `json:"index"`
+	Slot       string   `json:"slot"`
+	Validators []string `json:"validators"`
+}
+
+// FinalityCheckpointsResponse : Struct Represent response body from 'http://<endpoint>/eth/v1/beacon/states/<stateID>/finality_checkpoints' API call
+type FinalityCheckpointsResponse struct {
+	Data FinalityCheckpoints `json:"data"`
+}
+
+// FinalityCheckpoints : Struct Represent the justified and finalized checkpoints of a state
+type FinalityCheckpoints struct {
+	PreviousJustified AttestationCheckpoint `json:"previous_justified"`
+	CurrentJustified  AttestationCheckpoint `json:"current_justified"`
+	Finalized         AttestationCheckpoint `json:"finalized"`
+}
+
+// LivenessResponse : Struct Represent response body from 'http://<endpoint>/eth/v1/validator/liveness/<epoch>' API call
+type LivenessResponse struct {
+	Data []ValidatorLiveness `json:"data"`
+}
+
+// ValidatorLiveness : Struct Represent whether a validator was seen active in an epoch
+type ValidatorLiveness struct {
+	Index  string `json:"index"`
+	IsLive bool   `json:"is_live"`
+}
+
+// ProposerDutiesResponse : Struct Represent response body from 'http://<endpoint>/eth/v1/validator/duties/proposer/<epoch>' API call
+type ProposerDutiesResponse struct {
+	DependentRoot string         `json:"dependent_root"`
+	Data          []ProposerDuty `json:"data"`
+}
+
+// ProposerDuty : Struct Represent the block proposer of a slot
+type ProposerDuty struct {
+	Pubkey         string `json:"pubkey"`
+	ValidatorIndex string `json:"validator_index"`
+	Slot           string `json:"slot"`
+}
+
+// ProposerPreparationsResponse : Struct Represent response body from 'http://<endpoint>/eth/v1/validator/prepare_beacon_proposer' API call, where nodes expose it
+type ProposerPreparationsResponse struct {
+	Data []ProposerPreparation `json:"data"`
+}
+
+// ProposerPreparation : Struct Represent the fee recipient a validator client registered for a validator
+type ProposerPreparation struct {
+	ValidatorIndex string `json:"validator_index"`
+	FeeRecipient   string `json:"fee_recipient"`
+}
+
+// ValidatorListResponse : Struct Represent response body from 'http://<endpoint>/eth/v1/beacon/states/<stateID>/validators' API call
+type ValidatorListResponse struct {
+	Data []ValidatorInfo `json:"data"`
+}
+
+// ValidatorInfo : Struct Represent a single entry of response data from 'http://<endpoint>/eth/v1/beacon/states/<stateID>/validators' API call
+type ValidatorInfo struct {
+	Index     string        `json:"index"`
+	Balance   string        `json:"balance"`
+	Status    string        `json:"status"`
+	Validator ValidatorData `json:"validator"`
+}
+
+// ValidatorData : Struct Represent the registry record of a validator
+type ValidatorData struct {
+	Pubkey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	EffectiveBalance      string `json:"effective_balance"`
+	Slashed               bool   `json:"slashed"`
+	ActivationEpoch       string `json:"activation_epoch"`
+	ExitEpoch             string `json:"exit_epoch"`
+}
+
+// KeystoresResponse : Struct Represent response body from 'http://<endpoint>/eth/v1/keystores' keymanager API call
+type KeystoresResponse struct {
+	Data []Keystore `json:"data"`
+}
+
+// Keystore : Struct Represent a keystore loaded in a validator client
+type Keystore struct {
+	ValidatingPubkey string `json:"validating_pubkey"`
+	DerivationPath   string `json:"derivation_path"`
+	Readonly         bool   `json:"readonly"`
+}
+
+// RemoteKeysResponse : Struct Represent response body from 'http://<endpoint>/eth/v1/remotekeys' keymanager API call
+type RemoteKeysResponse struct {
+	Data []RemoteKey `json:"data"`
+}
+
+// RemoteKey : Struct Represent a remote signer key loaded in a validator client
+type RemoteKey struct {
+	Pubkey   string `json:"pubkey"`
+	URL      string `json:"url"`
+	Readonly bool   `json:"readonly"`
+}
+
+// BidTrace : Struct Represent a payload delivered by a relay to a proposer, from 'http://<relay>/relay/v1/data/bidtraces/proposer_payload_delivered' relay data API call
+type BidTrace struct {
+	Slot                 string `json:"slot"`
+	ParentHash           string `json:"parent_hash"`
+	BlockHash            string `json:"block_hash"`
+	BuilderPubkey        string `json:"builder_pubkey"`
+	ProposerPubkey       string `json:"proposer_pubkey"`
+	ProposerFeeRecipient string `json:"proposer_fee_recipient"`
+	GasLimit             string `json:"gas_limit"`
+	GasUsed              string `json:"gas_used"`
+	// Value of the bid paid to the proposer, in wei
+	Value       string `json:"value"`
+	BlockNumber string `json:"block_number"`
+	NumTx       string `json:"num_tx"`
 }
@@ -1,7 +1,15 @@
 package networking
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NethermindEth/posmoni/internal/utils"
 )
 
 /*
@@ -27,3 +35,228 @@ func unmarshalData[J any](data []byte, object J) (J, error) {
 	}
 	return object, nil
 }
+
+/*
+chunkIDs :
+Split validator IDs in chunks to request at once. A chunk has at most validatorsChunkSize IDs, and fewer if they are public keys, so its comma separated IDs don't exceed maxIDsLength.
+
+params :-
+a. ids []string
+Validator indexes or public keys
+
+returns :-
+a. [][]string
+Chunks of IDs in the given order
+*/
+func chunkIDs(ids []string) [][]string {
+	chunks := make([][]string, 0)
+	start, length := 0, 0
+	for i, id := range ids {
+		if i > start && (i-start == validatorsChunkSize || length+1+len(id) > maxIDsLength) {
+			chunks = append(chunks, ids[start:i])
+			start, length = i, 0
+		}
+		if i > start {
+			length++
+		}
+		length += len(id)
+	}
+	if start < len(ids) {
+		chunks = append(chunks, ids[start:])
+	}
+	return chunks
+}
+
+/*
+getData :
+Make a GET request to the given URL and unmarshal the response body into a given struct.
+
+params :-
+a. url string
+URL to make the request to
+b. retryDuration time.Duration
+Duration to wait between retries
+c. object J
+Struct to unmarshal response body into
+
+returns :-
+a. J
+Unmarshalled struct
+b. error
+Error if any
+*/
+func getData[J any](url string, retryDuration time.Duration, object J) (J, error) {
+	return getDataWithHeaders(url, nil, retryDuration, object)
+}
+
+/*
+getDataWithHeaders :
+Make a GET request with custom headers to the given URL and unmarshal the response body into a given struct. Non 200 responses give a *StatusError.
+
+params :-
+a. url string
+URL to make the request to
+b. headers map[string]string
+Headers to add to the request
+c. retryDuration time.Duration
+Duration to wait between retries
+d. object J
+Struct to unmarshal response body into
+
+returns :-
+a. J
+Unmarshalled struct
+b. error
+Error if any
+*/
+func getDataWithHeaders[J any](url string, headers map[string]string, retryDuration time.Duration, object J) (J, error) {
+	resp, err := utils.GetRequestWithHeaders(url, headers, retryDuration)
+	if err != nil {
+		return object, fmt.Errorf(RequestFailedError, url, err)
+	}
+
+	defer resp.Body.Close()
+	contents, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return object, fmt.Errorf(ReadBodyError, err)
+	}
+
+	if resp.StatusCode != 200 {
+		return object, &StatusError{URL: url, Code: resp.StatusCode, Body: string(contents)}
+	}
+
+	return unmarshalData(contents, object)
+}
+
+/*
+postData :
+Make a POST request with a json body to the given URL and unmarshal the response body into a given struct. Non 200 responses give a *StatusError.
+
+params :-
+a. url string
+URL to make the request to
+b. body any
+Value to send json encoded
+c. retryDuration time.Duration
+Duration to wait between retries
+d. object J
+Struct to unmarshal response body into
+
+returns :-
+a. J
+Unmarshalled struct
+b. error
+Error if any
+*/
+func postData[J any](url string, body any, retryDuration time.Duration, object J) (J, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return object, err
+	}
+
+	resp, err := utils.PostRequest(url, "application/json", bytes.NewReader(data), true, retryDuration)
+	if err != nil {
+		return object, fmt.Errorf(RequestFailedError, url, err)
+	}
+
+	defer resp.Body.Close()
+	contents, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return object, fmt.Errorf(ReadBodyError, err)
+	}
+
+	if resp.StatusCode != 200 {
+		return object, &StatusError{URL: url, Code: resp.StatusCode, Body: string(contents)}
+	}
+
+	return unmarshalData(contents, object)
+}
+
+// UnmarshalJSON : Decode a hex encoded quantity
+func (q *Quantity) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf(QuantityError, string(data))
+	}
+
+	if !strings.HasPrefix(s, "0x") || len(s) < 3 {
+		return fmt.Errorf(QuantityError, s)
+	}
+
+	v, err := strconv.ParseUint(s[2:], 16, 64)
+	if err != nil {
+		return fmt.Errorf(QuantityError, s)
+	}
+	*q = Quantity(v)
+	return nil
+}
+
+// UnmarshalJSON : Decode a hex encoded big quantity
+func (q *BigQuantity) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf(QuantityError, string(data))
+	}
+
+	if !strings.HasPrefix(s, "0x") || len(s) < 3 {
+		return fmt.Errorf(QuantityError, s)
+	}
+
+	if _, ok := q.SetString(s[2:], 16); !ok || q.Sign() < 0 {
+		return fmt.Errorf(QuantityError, s)
+	}
+	return nil
+}
+
+/*
+decodeSyncing :
+Decode the result of 'eth_syncing' json-rpc API call. The result is 'false' when the node is synced, or an object with the sync progress otherwise.
+
+params :-
+a. result json.RawMessage
+Result field of the json response
+
+returns :-
+a. ExecutionSyncingStatus
+Decoded sync status
+b. error
+Error if any
+*/
+func decodeSyncing(result json.RawMessage) (ExecutionSyncingStatus, error) {
+	var ess ExecutionSyncingStatus
+
+	var syncing bool
+	if err := json.Unmarshal(result, &syncing); err == nil {
+		ess.IsSyncing = syncing
+		return ess, nil
+	}
+
+	var r ethSyncingResult
+	if err := json.Unmarshal(result, &r); err != nil {
+		return ess, fmt.Errorf(SyncingResultError, string(result))
+	}
+
+	ess.IsSyncing = true
+	ess.StartingBlock = uint64(r.StartingBlock)
+	ess.CurrentBlock = uint64(r.CurrentBlock)
+	ess.HighestBlock = uint64(r.HighestBlock)
+	ess.PulledStates = uint64(r.PulledStates)
+	ess.KnownStates = uint64(r.KnownStates)
+
+	// Sync mode is a plain string in current Nethermind releases, keep any other encoding as is
+	if len(r.SyncMode) > 0 {
+		if err := json.Unmarshal(r.SyncMode, &ess.SyncMode); err != nil {
+			ess.SyncMode = string(r.SyncMode)
+		}
+	}
+
+	return ess, nil
+}
@@ -88,3 +88,77 @@ func TestUnmarshalData(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodeSyncing(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		result  string
+		want    ExecutionSyncingStatus
+		isError bool
+	}{
+		{
+			"Case 1 - Synced",
+			`false`,
+			ExecutionSyncingStatus{},
+			false,
+		},
+		{
+			"Case 2 - Syncing, standard fields",
+			`{"startingBlock":"0x384","currentBlock":"0x386","highestBlock":"0x454"}`,
+			ExecutionSyncingStatus{StartingBlock: 900, CurrentBlock: 902, HighestBlock: 1108, IsSyncing: true},
+			false,
+		},
+		{
+			"Case 3 - Syncing, Geth fields",
+			`{"currentBlock":"0xf4240","healedBytecodeBytes":"0x0","highestBlock":"0xf4a10","knownStates":"0x2710","pulledStates":"0x1388","startingBlock":"0x0","syncedAccounts":"0x0"}`,
+			ExecutionSyncingStatus{CurrentBlock: 1000000, HighestBlock: 1002000, KnownStates: 10000, PulledStates: 5000, IsSyncing: true},
+			false,
+		},
+		{
+			"Case 4 - Syncing, Nethermind fields",
+			`{"startingBlock":"0x0","currentBlock":"0x0","highestBlock":"0xf4a10","isSyncing":true,"syncMode":"SnapSync"}`,
+			ExecutionSyncingStatus{HighestBlock: 1002000, SyncMode: "SnapSync", IsSyncing: true},
+			false,
+		},
+		{
+			"Case 5 - Syncing, null fields",
+			`{"startingBlock":"0x1","currentBlock":"0x2","highestBlock":"0x3","pulledStates":null}`,
+			ExecutionSyncingStatus{StartingBlock: 1, CurrentBlock: 2, HighestBlock: 3, IsSyncing: true},
+			false,
+		},
+		{
+			"Case 6 - Bad quantity, not hex",
+			`{"startingBlock":"0x1","currentBlock":"12","highestBlock":"0x3"}`,
+			ExecutionSyncingStatus{},
+			true,
+		},
+		{
+			"Case 7 - Bad quantity, number",
+			`{"startingBlock":"0x1","currentBlock":12,"highestBlock":"0x3"}`,
+			ExecutionSyncingStatus{},
+			true,
+		},
+		{
+			"Case 8 - Bad result",
+			`666`,
+			ExecutionSyncingStatus{},
+			true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := decodeSyncing([]byte(tc.result))
+
+			descr := fmt.Sprintf("decodeSyncing(%s)", tc.result)
+			if err = utils.CheckErr(descr, tc.isError, err); err != nil {
+				t.Fatal(err)
+			}
+			if !tc.isError {
+				assert.Equal(t, tc.want, got, descr)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package eth2
+
+import (
+	"strconv"
+
+	"github.com/NethermindEth/posmoni/configs"
+	net "github.com/NethermindEth/posmoni/pkg/eth2/networking"
+	log "github.com/sirupsen/logrus"
+)
+
+/*
+NodesStatus :
+Check health, version, identity and peers of the given consensus nodes against the configured peer thresholds.
+
+params :-
+a. endpoints []string
+Consensus endpoints to check
+
+returns :-
+a. []NodeStatus
+Status of the given endpoints
+*/
+func (e *eth2Monitor) NodesStatus(endpoints []string) []NodeStatus {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "NodesStatus"}
+
+	infos := e.beaconClient.NodeInfo(endpoints)
+	statuses := make([]NodeStatus, 0, len(infos))
+	for _, info := range infos {
+		s := e.nodeStatus(info)
+		if s.LowPeers {
+			log.WithFields(logFields).Warnf(LowPeersWarning, s.Endpoint, s.Peers, s.InboundPeers, e.settings.minPeers, e.settings.minInboundPeers)
+		}
+		statuses = append(statuses, s)
+	}
+
+	return statuses
+}
+
+/*
+nodeStatus :
+Fold diagnostics of a consensus node into a NodeStatus and check them against the configured peer thresholds.
+
+params :-
+a. info networking.NodeInfo
+Diagnostics of the node
+
+returns :-
+a. NodeStatus
+Status of the node
+*/
+func (e *eth2Monitor) nodeStatus(info net.NodeInfo) NodeStatus {
+	s := NodeStatus{
+		Endpoint: info.Endpoint,
+		Healthy:  info.Healthy,
+		Syncing:  info.Syncing,
+		Version:  info.Version,
+		PeerID:   info.Identity.PeerID,
+		Error:    info.Error,
+	}
+
+	for _, p := range info.Peers {
+		switch p.Direction {
+		case "inbound":
+			s.InboundPeers++
+		case "outbound":
+			s.OutboundPeers++
+		}
+	}
+
+	// Prefer the counter from '/eth/v1/node/peer_count', some clients paginate or cap the peers list
+	s.Peers = s.InboundPeers + s.OutboundPeers
+	if connected, err := strconv.ParseUint(info.PeerCount.Connected, 10, 64); err == nil {
+		s.Peers = connected
+	}
+
+	// Peer counts are meaningless if the node is unreachable
+	if info.Healthy || info.Syncing {
+		s.LowPeers = s.Peers < e.settings.minPeers || s.InboundPeers < e.settings.minInboundPeers
+	}
+
+	return s
+}
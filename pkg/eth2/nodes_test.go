@@ -0,0 +1,109 @@
+package eth2
+
+import (
+	"errors"
+	"testing"
+
+	net "github.com/NethermindEth/posmoni/pkg/eth2/networking"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodesStatus(t *testing.T) {
+	t.Parallel()
+
+	peers := func(inbound, outbound int) []net.Peer {
+		ps := make([]net.Peer, 0)
+		for i := 0; i < inbound; i++ {
+			ps = append(ps, net.Peer{State: "connected", Direction: "inbound"})
+		}
+		for i := 0; i < outbound; i++ {
+			ps = append(ps, net.Peer{State: "connected", Direction: "outbound"})
+		}
+		return ps
+	}
+
+	tcs := []struct {
+		name     string
+		settings monitorSettings
+		data     []net.NodeInfo
+		want     []NodeStatus
+	}{
+		{
+			"Test case 1, no endpoints",
+			monitorSettings{minPeers: 10},
+			nil,
+			[]NodeStatus{},
+		},
+		{
+			"Test case 2, healthy node, enough peers",
+			monitorSettings{minPeers: 2},
+			[]net.NodeInfo{
+				{
+					Endpoint:  "1",
+					Healthy:   true,
+					Version:   "Lighthouse/v4.0.0",
+					Identity:  net.NodeIdentity{PeerID: "16Uiu2"},
+					PeerCount: net.PeerCount{Connected: "3"},
+					Peers:     peers(1, 2),
+				},
+			},
+			[]NodeStatus{
+				{Endpoint: "1", Healthy: true, Version: "Lighthouse/v4.0.0", PeerID: "16Uiu2", Peers: 3, InboundPeers: 1, OutboundPeers: 2},
+			},
+		},
+		{
+			"Test case 3, healthy node, low connected peers",
+			monitorSettings{minPeers: 10},
+			[]net.NodeInfo{
+				{Endpoint: "1", Healthy: true, PeerCount: net.PeerCount{Connected: "3"}, Peers: peers(1, 2)},
+			},
+			[]NodeStatus{
+				{Endpoint: "1", Healthy: true, Peers: 3, InboundPeers: 1, OutboundPeers: 2, LowPeers: true},
+			},
+		},
+		{
+			"Test case 4, syncing node, no inbound peers",
+			monitorSettings{minPeers: 2, minInboundPeers: 1},
+			[]net.NodeInfo{
+				{Endpoint: "1", Syncing: true, PeerCount: net.PeerCount{Connected: "5"}, Peers: peers(0, 5)},
+			},
+			[]NodeStatus{
+				{Endpoint: "1", Syncing: true, Peers: 5, OutboundPeers: 5, LowPeers: true},
+			},
+		},
+		{
+			"Test case 5, bad peer count, fallback to peers list",
+			monitorSettings{minPeers: 2},
+			[]net.NodeInfo{
+				{Endpoint: "1", Healthy: true, PeerCount: net.PeerCount{Connected: "aaa"}, Peers: peers(2, 2)},
+			},
+			[]NodeStatus{
+				{Endpoint: "1", Healthy: true, Peers: 4, InboundPeers: 2, OutboundPeers: 2},
+			},
+		},
+		{
+			"Test case 6, unreachable node, peers not checked",
+			monitorSettings{minPeers: 10},
+			[]net.NodeInfo{
+				{Endpoint: "1", Error: errors.New("")},
+			},
+			[]NodeStatus{
+				{Endpoint: "1", Error: errors.New("")},
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			tbc := newTestBeaconClient(nil, nil)
+			tbc.niData = tc.data
+			monitor := eth2Monitor{
+				beaconClient: tbc,
+				settings:     tc.settings,
+			}
+
+			got := monitor.NodesStatus(nil)
+			assert.Equal(t, tc.want, got, "NodesStatus() gave wrong results")
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package eth2
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NethermindEth/posmoni/configs"
+	"github.com/NethermindEth/posmoni/pkg/eth2/alerts"
+	log "github.com/sirupsen/logrus"
+)
+
+/*
+CheckPairing :
+Check that every consensus node is following the chain of one of the execution nodes. The execution payload of the consensus head block is looked up by number in every execution node and compared by hash.
+
+params :-
+a. beaconEndpoints []string
+Consensus endpoints to check
+b. executionEndpoints []string
+Execution endpoints to compare against
+
+returns :-
+a. []PairingStatus
+Pairing status of every consensus endpoint
+*/
+func (e *eth2Monitor) CheckPairing(beaconEndpoints, executionEndpoints []string) []PairingStatus {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "CheckPairing"}
+	statuses := make([]PairingStatus, 0, len(beaconEndpoints))
+
+	for _, cl := range beaconEndpoints {
+		s := PairingStatus{Consensus: cl, Execution: make([]string, 0)}
+
+		ss := e.beaconClient.SyncStatus([]string{cl})
+		if len(ss) != 1 || ss[0].Error != nil {
+			s.Error = fmt.Errorf(PairingSyncStatusError, cl)
+			if len(ss) == 1 {
+				s.Error = fmt.Errorf("%v. Error: %v", s.Error, ss[0].Error)
+			}
+			statuses = append(statuses, s)
+			continue
+		}
+		s.ElOffline = ss[0].ElOffline
+
+		head, err := e.beaconClient.Block(cl, "head")
+		if err != nil {
+			s.Error = err
+			statuses = append(statuses, s)
+			continue
+		}
+		s.BlockHash = head.Body.ExecutionPayload.BlockHash
+		s.BlockNumber, err = strconv.ParseUint(head.Body.ExecutionPayload.BlockNumber, 10, 64)
+		if err != nil || s.BlockHash == "" {
+			s.Error = fmt.Errorf(NoExecutionPayloadError, cl)
+			statuses = append(statuses, s)
+			continue
+		}
+
+		for _, el := range executionEndpoints {
+			block, err := e.executionClient.BlockByNumber(el, s.BlockNumber)
+			if err != nil {
+				log.WithFields(logFields).Debugf("Block %d not available in %s. Error: %v", s.BlockNumber, el, err)
+				continue
+			}
+			if strings.EqualFold(block.Hash, s.BlockHash) {
+				s.Execution = append(s.Execution, el)
+			}
+		}
+
+		s.Paired = !s.ElOffline && len(s.Execution) > 0
+		if !s.Paired {
+			log.WithFields(logFields).Warnf(PairingMismatchMsg, cl, s.BlockNumber, s.BlockHash, s.ElOffline)
+		}
+		statuses = append(statuses, s)
+	}
+
+	return statuses
+}
+
+/*
+TrackPairing :
+Periodically check pairing of consensus nodes with execution nodes and raise alerts on pairing changes.
+
+params :-
+a. done <-chan struct{}
+Channel to get stop signal from
+b. beaconEndpoints []string
+Consensus endpoints to check
+c. executionEndpoints []string
+Execution endpoints to compare against
+d. wait time.Duration
+Time between checks
+e. tracker *stateTracker
+Tracker to debounce pairing states with
+
+returns :-
+none
+*/
+func (e *eth2Monitor) TrackPairing(done <-chan struct{}, beaconEndpoints, executionEndpoints []string, wait time.Duration, tracker *stateTracker) {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "TrackPairing"}
+	var w time.Duration
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-time.After(w):
+			// Don't wait the first time
+			w = wait
+
+			for _, s := range e.CheckPairing(beaconEndpoints, executionEndpoints) {
+				if s.Error != nil {
+					// Unreachable nodes are reported by the health checks
+					log.WithFields(logFields).Errorf(PairingCheckError, s.Consensus, s.Error)
+					continue
+				}
+
+				now := time.Now()
+				tr, ok := tracker.observe(s.Consensus, string(s.State()), now)
+				if !ok {
+					continue
+				}
+				if err := e.alerter.Send(pairingAlert(s, tr, now)); err != nil {
+					log.WithFields(logFields).Errorf(SendAlertError, err)
+				}
+			}
+		}
+	}
+}
+
+/*
+State :
+Summarize the pairing status.
+
+params :-
+none
+
+returns :-
+a. PairingState
+Pairing state of the consensus node
+*/
+func (s PairingStatus) State() PairingState {
+	switch {
+	case s.ElOffline:
+		return PairingElOffline
+	case !s.Paired:
+		return PairingMismatch
+	default:
+		return PairingOK
+	}
+}
+
+/*
+pairingAlert :
+Build the alert describing a pairing state transition of a consensus node.
+
+params :-
+a. s PairingStatus
+Pairing status of the consensus node
+b. tr transition
+State transition of the consensus node
+c. now time.Time
+Time of the check
+
+returns :-
+a. alerts.Alert
+Alert describing the transition
+*/
+func pairingAlert(s PairingStatus, tr transition, now time.Time) alerts.Alert {
+	severity := alerts.Critical
+	if PairingState(tr.to) == PairingOK {
+		severity = alerts.Info
+	}
+
+	return alerts.Alert{
+		Kind:     PairingAlert,
+		Severity: severity,
+		Source:   s.Consensus,
+		Message:  fmt.Sprintf(PairingTransitionMsg, s.Consensus, tr.from, tr.to, s.BlockNumber, s.BlockHash, strings.Join(s.Execution, ",")),
+		Labels:   map[string]string{"layer": ConsensusLayer, "state": tr.to, "previous_state": tr.from},
+		Time:     now,
+	}
+}
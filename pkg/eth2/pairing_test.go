@@ -0,0 +1,139 @@
+package eth2
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/posmoni/pkg/eth2/alerts"
+	net "github.com/NethermindEth/posmoni/pkg/eth2/networking"
+	"github.com/stretchr/testify/assert"
+)
+
+func headBlock(number, hash string) map[string]net.BeaconBlock {
+	return map[string]net.BeaconBlock{
+		"head": {Body: net.BeaconBlockBody{ExecutionPayload: net.ExecutionPayload{BlockNumber: number, BlockHash: hash}}},
+	}
+}
+
+func TestCheckPairing(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name     string
+		ssData   [][]net.BeaconSyncingStatus
+		bcBlocks map[string]map[string]net.BeaconBlock
+		exBlocks map[string]map[uint64]net.ExecutionBlock
+		want     []PairingStatus
+	}{
+		{
+			"Test case 1, paired with one of two execution nodes",
+			[][]net.BeaconSyncingStatus{{{Endpoint: "cl1"}}},
+			map[string]map[string]net.BeaconBlock{"cl1": headBlock("100", "0xAB")},
+			map[string]map[uint64]net.ExecutionBlock{
+				"el1": {100: {Hash: "0xab", Number: 100}},
+				"el2": {100: {Hash: "0xcd", Number: 100}},
+			},
+			[]PairingStatus{{Consensus: "cl1", Execution: []string{"el1"}, BlockNumber: 100, BlockHash: "0xAB", Paired: true}},
+		},
+		{
+			"Test case 2, execution nodes on another chain",
+			[][]net.BeaconSyncingStatus{{{Endpoint: "cl1"}}},
+			map[string]map[string]net.BeaconBlock{"cl1": headBlock("100", "0xab")},
+			map[string]map[uint64]net.ExecutionBlock{
+				"el1": {100: {Hash: "0xcd", Number: 100}},
+			},
+			[]PairingStatus{{Consensus: "cl1", Execution: []string{}, BlockNumber: 100, BlockHash: "0xab"}},
+		},
+		{
+			"Test case 3, execution nodes stale",
+			[][]net.BeaconSyncingStatus{{{Endpoint: "cl1"}}},
+			map[string]map[string]net.BeaconBlock{"cl1": headBlock("100", "0xab")},
+			map[string]map[uint64]net.ExecutionBlock{
+				"el1": {99: {Hash: "0xcd", Number: 99}},
+			},
+			[]PairingStatus{{Consensus: "cl1", Execution: []string{}, BlockNumber: 100, BlockHash: "0xab"}},
+		},
+		{
+			"Test case 4, execution node offline for the consensus node",
+			[][]net.BeaconSyncingStatus{{{Endpoint: "cl1", ElOffline: true}}},
+			map[string]map[string]net.BeaconBlock{"cl1": headBlock("100", "0xab")},
+			map[string]map[uint64]net.ExecutionBlock{
+				"el1": {100: {Hash: "0xab", Number: 100}},
+			},
+			[]PairingStatus{{Consensus: "cl1", Execution: []string{"el1"}, BlockNumber: 100, BlockHash: "0xab", ElOffline: true}},
+		},
+		{
+			"Test case 5, consensus node unreachable",
+			[][]net.BeaconSyncingStatus{{{Endpoint: "cl1", Error: errors.New("")}}},
+			nil,
+			nil,
+			[]PairingStatus{{Consensus: "cl1", Execution: []string{}, Error: errors.New("")}},
+		},
+		{
+			"Test case 6, head block without execution payload",
+			[][]net.BeaconSyncingStatus{{{Endpoint: "cl1"}}},
+			map[string]map[string]net.BeaconBlock{"cl1": headBlock("", "")},
+			nil,
+			[]PairingStatus{{Consensus: "cl1", Execution: []string{}, Error: errors.New("")}},
+		},
+		{
+			"Test case 7, two consensus nodes, mixed pairing",
+			[][]net.BeaconSyncingStatus{{{Endpoint: "cl1"}}, {{Endpoint: "cl2"}}},
+			map[string]map[string]net.BeaconBlock{"cl1": headBlock("100", "0xab"), "cl2": headBlock("101", "0xef")},
+			map[string]map[uint64]net.ExecutionBlock{
+				"el1": {100: {Hash: "0xab", Number: 100}},
+			},
+			[]PairingStatus{
+				{Consensus: "cl1", Execution: []string{"el1"}, BlockNumber: 100, BlockHash: "0xab", Paired: true},
+				{Consensus: "cl2", Execution: []string{}, BlockNumber: 101, BlockHash: "0xef"},
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			tbc := newTestBeaconClient(nil, tc.ssData)
+			tbc.blocks = tc.bcBlocks
+			tec := newTestExecutionClient(nil)
+			tec.blocks = tc.exBlocks
+			monitor := eth2Monitor{beaconClient: tbc, executionClient: tec}
+
+			consensus := make([]string, 0)
+			for _, w := range tc.want {
+				consensus = append(consensus, w.Consensus)
+			}
+			got := monitor.CheckPairing(consensus, []string{"el1", "el2"})
+
+			assert.Len(t, got, len(tc.want))
+			for i, w := range tc.want {
+				assert.Equal(t, w.Error != nil, got[i].Error != nil, "CheckPairing() gave unexpected error %v", got[i].Error)
+				got[i].Error, w.Error = nil, nil
+				assert.Equal(t, w, got[i], "CheckPairing() gave wrong results")
+			}
+		})
+	}
+}
+
+func TestTrackPairing(t *testing.T) {
+	t.Parallel()
+
+	tbc := newTestBeaconClient(nil, [][]net.BeaconSyncingStatus{{{Endpoint: "cl1"}}, {{Endpoint: "cl1", ElOffline: true}}})
+	tbc.blocks = map[string]map[string]net.BeaconBlock{"cl1": headBlock("100", "0xab")}
+	tec := newTestExecutionClient(nil)
+	tec.blocks = map[string]map[uint64]net.ExecutionBlock{"el1": {100: {Hash: "0xab", Number: 100}}}
+	am := &alerterMock{}
+	monitor := eth2Monitor{beaconClient: tbc, executionClient: tec, alerter: am}
+
+	done := make(chan struct{})
+	go monitor.TrackPairing(done, []string{"cl1"}, []string{"el1"}, time.Millisecond*100, newStateTracker(0, string(PairingOK)))
+	time.Sleep(time.Millisecond * 150)
+	close(done)
+
+	// First check is paired and should not alert, second one has the execution node offline
+	sent := am.all()
+	assert.Len(t, sent, 1)
+	assert.Equal(t, PairingAlert, sent[0].Kind)
+	assert.Equal(t, alerts.Critical, sent[0].Severity)
+	assert.Equal(t, string(PairingElOffline), sent[0].Labels["state"])
+}
@@ -0,0 +1,255 @@
+package eth2
+
+import (
+	"errors"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NethermindEth/posmoni/configs"
+	"github.com/NethermindEth/posmoni/pkg/eth2/db"
+	net "github.com/NethermindEth/posmoni/pkg/eth2/networking"
+	log "github.com/sirupsen/logrus"
+)
+
+/*
+trackProposals :
+Record the block proposals of the monitored validators in a finalized epoch. A proposal is missed if the consensus nodes have no block at its slot. Proposed blocks are checked against the expected fee recipients and the configured relays.
+
+params :-
+a. epoch uint64
+Finalized epoch
+
+returns :-
+none
+*/
+func (e *eth2Monitor) trackProposals(epoch uint64) {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "trackProposals"}
+
+	duties, err := e.beaconClient.ProposerDuties(strconv.FormatUint(epoch, 10))
+	if err != nil {
+		log.WithFields(logFields).Errorf(ProposerDutiesError, epoch, err)
+		return
+	}
+
+	for _, d := range duties {
+		if !e.validators.Contains(d.ValidatorIndex) {
+			continue
+		}
+		vFields := e.validatorFields(logFields, d.ValidatorIndex)
+
+		idx, err := parseUint(d.ValidatorIndex)
+		if err != nil {
+			log.WithFields(vFields).Errorf(ParseUintError, err)
+			continue
+		}
+		slot, err := strconv.ParseUint(d.Slot, 10, 64)
+		if err != nil {
+			log.WithFields(vFields).Errorf(ParseUintError, err)
+			continue
+		}
+
+		block, missed, err := e.slotBlock(d.Slot)
+		if err != nil {
+			log.WithFields(vFields).Errorf(ProposalBlockError, idx, slot, err)
+			continue
+		}
+		proposal := db.Proposal{Idx: idx, Slot: slot, Epoch: epoch, Missed: missed}
+		if missed {
+			proposal.MissedReward = idealProposerReward(e.totalActiveBalance, uint64(len(duties)))
+			log.WithFields(vFields).Warnf("Block proposal of validator %d at slot %d was missed, losing %d gwei of consensus rewards", idx, slot, proposal.MissedReward)
+		} else {
+			payload := block.Body.ExecutionPayload
+			delivery := e.relayDelivery(d.ValidatorIndex, payload.BlockHash, slot)
+			proposal.ExecutionFees, proposal.MEV, proposal.FeeRecipient, err = e.executionRewards(payload, e.builderPayees(d.ValidatorIndex, delivery))
+			if err != nil {
+				// The proposal is still saved, without execution rewards and with the fee recipient of the payload
+				log.WithFields(vFields).Errorf(ExecutionRewardsError, idx, slot, err)
+			} else {
+				log.WithFields(vFields).Infof("Block proposed by validator %d at slot %d, execution fees: %d gwei, MEV: %d gwei", idx, slot, proposal.ExecutionFees, proposal.MEV)
+			}
+			rewardsKnown := err == nil && len(e.config.execution) > 0
+			if !rewardsKnown && len(delivery.relays) > 0 {
+				// The payload of a block built by a relay names the builder as fee recipient, the proposer is paid to the one of the bid trace.
+				// If the trace has none, the fee recipient is not checked
+				proposal.FeeRecipient = delivery.proposerFeeRecipient
+			}
+			e.checkProposalFeeRecipient(d.ValidatorIndex, slot, proposal.FeeRecipient)
+			e.checkRelayedProposal(d.ValidatorIndex, delivery, &proposal, rewardsKnown)
+		}
+
+		if err := e.repository.SaveProposal(proposal); err != nil {
+			log.WithFields(vFields).Errorf(SaveProposalError, idx, slot, err)
+		}
+	}
+}
+
+/*
+slotBlock :
+Get the block of a slot, asking the consensus endpoints in order until one answers.
+
+params :-
+a. slot string
+Slot of the block. Can also be 'head' or 'finalized'
+
+returns :-
+a. net.BeaconBlock
+Block of the slot, empty if missed
+b. bool
+True if the slot has no block
+c. error
+Error of the last endpoint if none answered
+*/
+func (e *eth2Monitor) slotBlock(slot string) (net.BeaconBlock, bool, error) {
+	var err error
+	for _, endpoint := range e.config.consensus {
+		var block net.BeaconBlock
+		if block, err = e.beaconClient.Block(endpoint, slot); err == nil {
+			return block, false, nil
+		}
+		var statusErr *net.StatusError
+		if errors.As(err, &statusErr) && statusErr.Code == http.StatusNotFound {
+			return net.BeaconBlock{}, true, nil
+		}
+	}
+	return net.BeaconBlock{}, false, err
+}
+
+/*
+executionRewards :
+Get the execution layer rewards of a proposed block in gwei and the address they were paid to, asking the execution endpoints in order until one answers. If the last transaction of the block is sent by the fee recipient of the block to an address of the proposer, or to any address if none of the proposer is known, the block was built with MEV-boost: the payment is the MEV reward and priority fees went to the builder. Otherwise priority fees went to the fee recipient of the validator. Without execution endpoints, the fee recipient of the payload is given.
+
+params :-
+a. payload net.ExecutionPayload
+Execution payload of the block
+b. payees []string
+Addresses of the proposer a builder payment can be sent to, in lower case. Empty if none is known
+
+returns :-
+a. uint64
+Priority fees paid to the validator
+b. uint64
+MEV payment to the validator
+c. string
+Address the rewards were paid to, in lower case
+d. error
+Error of the last endpoint if none answered
+*/
+func (e *eth2Monitor) executionRewards(payload net.ExecutionPayload, payees []string) (uint64, uint64, string, error) {
+	number, err := strconv.ParseUint(payload.BlockNumber, 10, 64)
+	if err != nil || number == 0 {
+		// Block before the merge, or no execution payload
+		return 0, 0, "", nil
+	}
+
+	for _, endpoint := range e.config.execution {
+		var fees, mev uint64
+		var recipient string
+		if fees, mev, recipient, err = e.blockRewards(endpoint, number, payees); err == nil {
+			return fees, mev, recipient, nil
+		}
+	}
+	return 0, 0, strings.ToLower(payload.FeeRecipient), err
+}
+
+/*
+blockRewards :
+Get the execution layer rewards of a block in gwei from an execution endpoint. Receipts are fetched per transaction if the endpoint doesn't support 'eth_getBlockReceipts'.
+
+params :-
+a. endpoint string
+Execution endpoint
+b. number uint64
+Block number
+c. payees []string
+Addresses of the proposer a builder payment can be sent to, in lower case. Empty if none is known
+
+returns :-
+a. uint64
+Priority fees paid to the fee recipient
+b. uint64
+MEV payment to the proposer
+c. string
+Address the rewards were paid to, in lower case
+d. error
+Error if any
+*/
+func (e *eth2Monitor) blockRewards(endpoint string, number uint64, payees []string) (uint64, uint64, string, error) {
+	block, err := e.executionClient.FullBlockByNumber(endpoint, number)
+	if err != nil {
+		return 0, 0, "", err
+	}
+
+	if n := len(block.Transactions); n > 0 {
+		last := block.Transactions[n-1]
+		// The fee recipient of a locally built block can also send funds out, so only payments to the proposer are taken as MEV.
+		// Without known addresses of the proposer, any payment from the fee recipient is
+		if strings.EqualFold(last.From, block.Miner) && !strings.EqualFold(last.To, block.Miner) && last.Value.Sign() > 0 && (len(payees) == 0 || containsAddress(payees, last.To)) {
+			return 0, weiToGwei(&last.Value.Int), strings.ToLower(last.To), nil
+		}
+	}
+
+	receipts, err := e.executionClient.BlockReceipts(endpoint, number)
+	if err != nil {
+		receipts = make([]net.Receipt, 0, len(block.Transactions))
+		for _, tx := range block.Transactions {
+			r, err := e.executionClient.TransactionReceipt(endpoint, tx.Hash)
+			if err != nil {
+				return 0, 0, "", err
+			}
+			receipts = append(receipts, r)
+		}
+	}
+
+	fees := new(big.Int)
+	baseFee := new(big.Int).SetUint64(uint64(block.BaseFeePerGas))
+	for _, r := range receipts {
+		tip := new(big.Int).Sub(&r.EffectiveGasPrice.Int, baseFee)
+		fees.Add(fees, tip.Mul(tip, new(big.Int).SetUint64(uint64(r.GasUsed))))
+	}
+	return weiToGwei(fees), 0, strings.ToLower(block.Miner), nil
+}
+
+/*
+builderPayees :
+Get the addresses of a proposer a block builder pays: its expected fee recipient, the fee recipients registered for it in the consensus nodes, and the fee recipient of the bid trace of the relay that delivered the block.
+
+params :-
+a. validator string
+Validator public index
+b. delivery relayDelivery
+Relays that delivered the block
+
+returns :-
+a. []string
+Addresses in lower case
+*/
+func (e *eth2Monitor) builderPayees(validator string, delivery relayDelivery) []string {
+	payees := make([]string, 0)
+	if expected := e.expectedFeeRecipient(validator); expected != "" {
+		payees = append(payees, expected)
+	}
+	for recipient := range e.registeredFeeRecipients[validator] {
+		payees = append(payees, recipient)
+	}
+	if delivery.proposerFeeRecipient != "" {
+		payees = append(payees, delivery.proposerFeeRecipient)
+	}
+	return payees
+}
+
+// containsAddress : Check if an address is in a list of lower case addresses
+func containsAddress(addresses []string, address string) bool {
+	for _, a := range addresses {
+		if strings.EqualFold(a, address) {
+			return true
+		}
+	}
+	return false
+}
+
+// weiToGwei : Convert an amount of wei to gwei, rounding down
+func weiToGwei(wei *big.Int) uint64 {
+	return new(big.Int).Div(wei, big.NewInt(1_000_000_000)).Uint64()
+}
@@ -0,0 +1,320 @@
+package eth2
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/posmoni/internal/utils"
+	"github.com/NethermindEth/posmoni/pkg/eth2/db"
+	net "github.com/NethermindEth/posmoni/pkg/eth2/networking"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrackProposals(t *testing.T) {
+	duties := []net.ProposerDuty{
+		{ValidatorIndex: "1", Slot: "64"},
+		{ValidatorIndex: "7", Slot: "65"},
+		{ValidatorIndex: "2", Slot: "66"},
+		{ValidatorIndex: "3", Slot: "67"},
+	}
+
+	// Block 100 of validator 3 paid 2 gwei per gas of priority fees on 21000 gas
+	execution := &TestExecutionClient{
+		fullBlocks: map[string]map[uint64]net.FullExecutionBlock{"el1": {
+			100: {ExecutionBlock: net.ExecutionBlock{Miner: "0xfee", BaseFeePerGas: 1000000000}, Transactions: []net.ExecutionTransaction{{Hash: "0x01", From: "0xaa", To: "0xbb"}}},
+		}},
+		receipts: map[string]map[uint64][]net.Receipt{"el1": {
+			100: {{TransactionHash: "0x01", GasUsed: 21000, EffectiveGasPrice: bigQuantity("3000000000")}},
+		}},
+	}
+	payload := net.BeaconBlockBody{ExecutionPayload: net.ExecutionPayload{BlockNumber: "100", FeeRecipient: "0xfee"}}
+	// Block built by a relay, naming the builder as fee recipient
+	relayed := net.BeaconBlockBody{ExecutionPayload: net.ExecutionPayload{BlockNumber: "100", BlockHash: "0xb10c", FeeRecipient: "0xbuilder"}}
+
+	tcs := []struct {
+		name   string
+		blocks map[string]map[string]net.BeaconBlock
+		// execution endpoints, none if nil
+		execution *TestExecutionClient
+		// payloads delivered by relay, no relays if nil
+		payloads map[string][]net.BidTrace
+		want     []db.Proposal
+	}{
+		{
+			"Test case 1, proposed and missed blocks of monitored validators",
+			map[string]map[string]net.BeaconBlock{
+				"cl1": {"64": {Slot: "64", ProposerIndex: "1"}, "65": {Slot: "65", ProposerIndex: "7"}, "67": {Slot: "67", ProposerIndex: "3"}},
+			},
+			nil,
+			nil,
+			[]db.Proposal{
+				{Idx: 1, Slot: 64, Epoch: 2},
+				{Idx: 2, Slot: 66, Epoch: 2, Missed: true},
+				{Idx: 3, Slot: 67, Epoch: 2},
+			},
+		},
+		{
+			"Test case 2, first endpoint failing, second one answers",
+			map[string]map[string]net.BeaconBlock{
+				"cl2": {"64": {Slot: "64", ProposerIndex: "1"}, "67": {Slot: "67", ProposerIndex: "3"}},
+			},
+			nil,
+			nil,
+			[]db.Proposal{
+				{Idx: 1, Slot: 64, Epoch: 2},
+				{Idx: 2, Slot: 66, Epoch: 2, Missed: true},
+				{Idx: 3, Slot: 67, Epoch: 2},
+			},
+		},
+		{
+			"Test case 3, no endpoint answers, nothing saved",
+			nil,
+			nil,
+			nil,
+			[]db.Proposal{},
+		},
+		{
+			"Test case 4, execution rewards of a proposed block",
+			map[string]map[string]net.BeaconBlock{
+				"cl1": {"64": {Slot: "64", ProposerIndex: "1"}, "67": {Slot: "67", ProposerIndex: "3", Body: payload}},
+			},
+			execution,
+			nil,
+			[]db.Proposal{
+				{Idx: 1, Slot: 64, Epoch: 2},
+				{Idx: 2, Slot: 66, Epoch: 2, Missed: true},
+				{Idx: 3, Slot: 67, Epoch: 2, ExecutionFees: 42000, FeeRecipient: "0xfee"},
+			},
+		},
+		{
+			"Test case 5, block built by a relay without execution endpoints, fee recipient of the bid trace",
+			map[string]map[string]net.BeaconBlock{
+				"cl1": {"64": {Slot: "64", ProposerIndex: "1"}, "67": {Slot: "67", ProposerIndex: "3", Body: relayed}},
+			},
+			nil,
+			map[string][]net.BidTrace{"relay1": {{Slot: "67", BlockHash: "0xb10c", Value: "50000000000000000", ProposerFeeRecipient: "0xFEE"}}},
+			[]db.Proposal{
+				{Idx: 1, Slot: 64, Epoch: 2},
+				{Idx: 2, Slot: 66, Epoch: 2, Missed: true},
+				{Idx: 3, Slot: 67, Epoch: 2, FeeRecipient: "0xfee", Relay: "relay1", BidValue: 50000000},
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			monitor, err := setup(nil, net.SubscribeOpts{}, ConfigOpts{Checkers: []CfgChecker{
+				{Key: Validators, ErrMsg: NoValidatorsFoundError, Data: []string{"1", "2", "0xAA03"}},
+				{Key: Consensus, ErrMsg: NoConsensusFoundError, Data: []string{"cl1", "cl2"}},
+			}})
+			if err != nil {
+				t.Fatalf("Setup failed. Error %v", err)
+			}
+			defer cleanup(monitor.repository)
+			tbc := monitor.beaconClient.(*TestBeaconClient)
+			tbc.duties = map[string][]net.ProposerDuty{"2": duties}
+			tbc.blocks = tc.blocks
+			// Validator 3 is configured by public key
+			tbc.registry = []net.ValidatorInfo{{Index: "3", Validator: net.ValidatorData{Pubkey: "0xaa03"}}}
+			monitor.resolveValidators()
+			monitor.settings.feeRecipient = "0xfee"
+			if tc.payloads != nil {
+				monitor.relayClient = &relayMock{payloads: tc.payloads}
+				monitor.settings.relays = []string{"relay1"}
+			}
+			am := &alerterMock{}
+			monitor.alerter = am
+			if tc.execution != nil {
+				monitor.executionClient = tc.execution
+				monitor.config.execution = []string{"el1"}
+			}
+
+			monitor.trackProposals(2)
+
+			got, err := monitor.repository.Proposals(0, 10)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+			for _, a := range am.all() {
+				assert.NotEqual(t, FeeRecipientAlert, a.Kind)
+			}
+		})
+	}
+}
+
+func bigQuantity(value string) net.BigQuantity {
+	var q net.BigQuantity
+	q.SetString(value, 10)
+	return q
+}
+
+func TestExecutionRewards(t *testing.T) {
+	// Base fee of 1 gwei, transactions paying 2 and 3 gwei per gas
+	txs := []net.ExecutionTransaction{
+		{Hash: "0x01", From: "0xaa", To: "0xbb"},
+		{Hash: "0x02", From: "0xcc", To: "0xdd"},
+	}
+	receipts := []net.Receipt{
+		{TransactionHash: "0x01", GasUsed: 21000, EffectiveGasPrice: bigQuantity("2000000000")},
+		{TransactionHash: "0x02", GasUsed: 100000, EffectiveGasPrice: bigQuantity("3000000000")},
+	}
+	local := net.FullExecutionBlock{ExecutionBlock: net.ExecutionBlock{Miner: "0xFEE", BaseFeePerGas: 1000000000}, Transactions: txs}
+	// Built by a builder, paying 0.05 ETH to the fee recipient of the proposer in the last transaction
+	builder := net.FullExecutionBlock{
+		ExecutionBlock: net.ExecutionBlock{Miner: "0xbuilder", BaseFeePerGas: 1000000000},
+		Transactions:   append(append([]net.ExecutionTransaction{}, txs...), net.ExecutionTransaction{Hash: "0x03", From: "0xBuilder", To: "0xfee", Value: bigQuantity("50000000000000000")}),
+	}
+	// Built locally, the fee recipient sending 1 ETH to another address in the last transaction
+	sweep := net.FullExecutionBlock{
+		ExecutionBlock: net.ExecutionBlock{Miner: "0xFEE", BaseFeePerGas: 1000000000},
+		Transactions:   append(append([]net.ExecutionTransaction{}, txs...), net.ExecutionTransaction{Hash: "0x03", From: "0xfee", To: "0xcold", Value: bigQuantity("1000000000000000000")}),
+	}
+	sweepReceipts := append(append([]net.Receipt{}, receipts...), net.Receipt{TransactionHash: "0x03", GasUsed: 21000, EffectiveGasPrice: bigQuantity("1000000000")})
+
+	tcs := []struct {
+		name      string
+		payload   net.ExecutionPayload
+		execution []string
+		client    *TestExecutionClient
+		// addresses of the proposer
+		payees   []string
+		wantFees uint64
+		wantMEV  uint64
+		// address the rewards were paid to
+		wantRecipient string
+		isError       bool
+	}{
+		{
+			"Test case 1, locally built block, block receipts",
+			net.ExecutionPayload{BlockNumber: "100"},
+			[]string{"el1"},
+			&TestExecutionClient{
+				fullBlocks: map[string]map[uint64]net.FullExecutionBlock{"el1": {100: local}},
+				receipts:   map[string]map[uint64][]net.Receipt{"el1": {100: receipts}},
+			},
+			[]string{"0xfee"},
+			221000,
+			0,
+			"0xfee",
+			false,
+		},
+		{
+			"Test case 2, locally built block, receipts per transaction",
+			net.ExecutionPayload{BlockNumber: "100"},
+			[]string{"el1"},
+			&TestExecutionClient{
+				fullBlocks: map[string]map[uint64]net.FullExecutionBlock{"el1": {100: local}},
+				txReceipts: map[string]map[string]net.Receipt{"el1": {"0x01": receipts[0], "0x02": receipts[1]}},
+			},
+			[]string{"0xfee"},
+			221000,
+			0,
+			"0xfee",
+			false,
+		},
+		{
+			"Test case 3, MEV-boost block, first endpoint failing",
+			net.ExecutionPayload{BlockNumber: "100"},
+			[]string{"el1", "el2"},
+			&TestExecutionClient{
+				fullBlocks: map[string]map[uint64]net.FullExecutionBlock{"el2": {100: builder}},
+			},
+			[]string{"0xfee"},
+			0,
+			50000000,
+			"0xfee",
+			false,
+		},
+		{
+			"Test case 4, fee recipient sending funds out of a locally built block",
+			net.ExecutionPayload{BlockNumber: "100"},
+			[]string{"el1"},
+			&TestExecutionClient{
+				fullBlocks: map[string]map[uint64]net.FullExecutionBlock{"el1": {100: sweep}},
+				receipts:   map[string]map[uint64][]net.Receipt{"el1": {100: sweepReceipts}},
+			},
+			[]string{"0xfee"},
+			221000,
+			0,
+			"0xfee",
+			false,
+		},
+		{
+			"Test case 5, builder payment to an address unknown to the proposer",
+			net.ExecutionPayload{BlockNumber: "100"},
+			[]string{"el1"},
+			&TestExecutionClient{
+				fullBlocks: map[string]map[uint64]net.FullExecutionBlock{"el1": {100: builder}},
+				receipts:   map[string]map[uint64][]net.Receipt{"el1": {100: sweepReceipts}},
+			},
+			[]string{"0xcold"},
+			221000,
+			0,
+			"0xbuilder",
+			false,
+		},
+		{
+			"Test case 6, MEV-boost block, no known address of the proposer",
+			net.ExecutionPayload{BlockNumber: "100"},
+			[]string{"el1"},
+			&TestExecutionClient{
+				fullBlocks: map[string]map[uint64]net.FullExecutionBlock{"el1": {100: builder}},
+				receipts:   map[string]map[uint64][]net.Receipt{"el1": {100: sweepReceipts}},
+			},
+			nil,
+			0,
+			50000000,
+			"0xfee",
+			false,
+		},
+		{
+			"Test case 7, block before the merge",
+			net.ExecutionPayload{},
+			[]string{"el1"},
+			&TestExecutionClient{},
+			[]string{"0xfee"},
+			0,
+			0,
+			"",
+			false,
+		},
+		{
+			"Test case 8, missing receipt, fee recipient of the payload",
+			net.ExecutionPayload{BlockNumber: "100", FeeRecipient: "0xBuilder"},
+			[]string{"el1"},
+			&TestExecutionClient{
+				fullBlocks: map[string]map[uint64]net.FullExecutionBlock{"el1": {100: local}},
+				txReceipts: map[string]map[string]net.Receipt{"el1": {"0x01": receipts[0]}},
+			},
+			[]string{"0xfee"},
+			0,
+			0,
+			"0xbuilder",
+			true,
+		},
+		{
+			"Test case 9, no execution endpoints, fee recipient of the payload",
+			net.ExecutionPayload{BlockNumber: "100", FeeRecipient: "0xFEE"},
+			nil,
+			&TestExecutionClient{},
+			[]string{"0xfee"},
+			0,
+			0,
+			"0xfee",
+			false,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			monitor := &eth2Monitor{executionClient: tc.client, config: eth2Config{execution: tc.execution}}
+
+			fees, mev, recipient, err := monitor.executionRewards(tc.payload, tc.payees)
+
+			if err := utils.CheckErr("executionRewards()", tc.isError, err); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.wantFees, fees)
+			assert.Equal(t, tc.wantMEV, mev)
+			assert.Equal(t, tc.wantRecipient, recipient)
+		})
+	}
+}
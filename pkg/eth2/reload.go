@@ -0,0 +1,275 @@
+package eth2
+
+import (
+	"os"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/NethermindEth/posmoni/configs"
+	"github.com/NethermindEth/posmoni/pkg/eth2/db"
+	net "github.com/NethermindEth/posmoni/pkg/eth2/networking"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// configWatchInterval : Time between checks of the config file for changes
+const configWatchInterval = 5 * time.Second
+
+// pipelines : Struct Represent a set of running monitoring goroutines sharing a stop signal
+type pipelines struct {
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// run : Run a function in a goroutine tracked by the pipelines
+func (p *pipelines) run(f func()) {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		f()
+	}()
+}
+
+// stop : Signal the goroutines to stop and wait for them
+func (p *pipelines) stop() {
+	close(p.done)
+	p.wg.Wait()
+}
+
+/*
+watchConfig :
+Periodically check the config file for changes and reload the configuration when it changes, until done is closed. Running goroutines are stopped when done is closed.
+
+params :-
+a. done <-chan struct{}
+Channel to get stop signal from
+b. path string
+Config file path. Only done is waited for if empty
+c. wait time.Duration
+Time between checks
+
+returns :-
+none
+*/
+func (e *eth2Monitor) watchConfig(done <-chan struct{}, path string, wait time.Duration) {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "watchConfig"}
+
+	var mtime time.Time
+	if info, err := os.Stat(path); err == nil {
+		mtime = info.ModTime()
+	}
+
+	var tick <-chan time.Time
+	if path != "" {
+		ticker := time.NewTicker(wait)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-done:
+			e.reloadMu.Lock()
+			if e.running != nil {
+				e.running.stop()
+				e.running = nil
+			}
+			e.reloadMu.Unlock()
+			return
+		case <-tick:
+			info, err := os.Stat(path)
+			if err != nil || info.ModTime().Equal(mtime) {
+				continue
+			}
+			mtime = info.ModTime()
+			log.WithFields(logFields).Infof("Config file %s changed, reloading", path)
+			if err := e.Reload(); err != nil {
+				log.WithFields(logFields).Errorf(ReloadConfigError, err)
+			}
+		}
+	}
+}
+
+/*
+Reload :
+Read the configuration again and apply it to the running monitor. Validators are applied live, new ones are created in the repository and removed ones marked inactive. Monitoring goroutines are restarted only when endpoints or settings change.
+
+params :-
+none
+
+returns :-
+a. error
+Error if the configuration is invalid. The current configuration is kept
+*/
+func (e *eth2Monitor) Reload() error {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "Reload"}
+
+	e.reloadMu.Lock()
+	defer e.reloadMu.Unlock()
+
+	if viper.ConfigFileUsed() != "" {
+		if err := viper.ReadInConfig(); err != nil {
+			return err
+		}
+	}
+	cfg, err := Init(e.opts.Checkers)
+	if err != nil {
+		return err
+	}
+	settings := loadSettings()
+	if err := settings.validate(); err != nil {
+		return err
+	}
+	settings.keymanagers, err = loadKeymanagers()
+	if err != nil {
+		return err
+	}
+
+	added, removed, err := e.validators.reconfigure(cfg.validators, cfg.groups)
+	if err != nil {
+		return err
+	}
+	e.syncRepository(added, removed)
+	log.WithFields(logFields).Infof("Configuration reloaded, %d validators added and %d removed", len(added), len(removed))
+
+	if viper.IsSet(LogLevel) {
+		configs.InitLogging()
+	}
+
+	if reflect.DeepEqual(cfg.consensus, e.config.consensus) && reflect.DeepEqual(cfg.execution, e.config.execution) && reflect.DeepEqual(settings, e.settings) {
+		return nil
+	}
+
+	log.WithFields(logFields).Info("Endpoints or settings changed, restarting monitoring")
+	if e.running != nil {
+		e.running.stop()
+	}
+	e.config.consensus, e.config.execution = cfg.consensus, cfg.execution
+	if e.settingsAlerter && settings.alertsWebhook != e.settings.alertsWebhook {
+		e.alerter = newAlerter(settings)
+	}
+	e.settings = settings
+	if e.keymanagerClient == nil && len(e.settings.keymanagers) > 0 {
+		e.keymanagerClient = &net.KeymanagerClient{RetryDuration: time.Minute}
+	}
+	if e.relayClient == nil && (len(e.settings.relays) > 0 || len(e.settings.mevBoost) > 0) {
+		e.relayClient = &net.RelayClient{RetryDuration: time.Minute}
+	}
+	e.subscriberOpts.Endpoints = e.config.consensus
+	e.eventOpts.Endpoints = e.config.consensus
+	if len(e.config.consensus) > 0 {
+		e.beaconClient.SetEndpoints(e.config.consensus)
+	}
+	if e.running != nil {
+		e.running = e.startPipelines()
+	}
+	return nil
+}
+
+/*
+syncRepository :
+Create validators added to the configuration in the repository, and mark removed ones as inactive. Public keys are resolved to indexes with the consensus node.
+
+params :-
+a. added []string
+Validators added to the configuration
+b. removed []string
+Validators removed from the configuration
+
+returns :-
+none
+*/
+func (e *eth2Monitor) syncRepository(added, removed []string) {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "syncRepository"}
+
+	for _, idx := range e.resolveIndexes(added) {
+		if _, err := e.repository.FirstOrCreate(db.Validator{Idx: idx}); err != nil {
+			log.WithFields(logFields).Errorf(SyncValidatorError, idx, err)
+			continue
+		}
+		// Validators configured again become active
+		if err := e.repository.SetInactive(idx, false); err != nil {
+			log.WithFields(logFields).Errorf(SyncValidatorError, idx, err)
+		}
+	}
+	for _, idx := range e.resolveIndexes(removed) {
+		if err := e.repository.SetInactive(idx, true); err != nil {
+			log.WithFields(logFields).Errorf(SyncValidatorError, idx, err)
+		}
+	}
+}
+
+/*
+resolveIndexes :
+Get the indexes of validators given as indexes or public keys. Public keys unknown to the consensus node are skipped.
+
+params :-
+a. validators []string
+Validator indexes or public keys
+
+returns :-
+a. []uint
+Validator indexes
+*/
+func (e *eth2Monitor) resolveIndexes(validators []string) []uint {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "resolveIndexes"}
+
+	idxs := make([]uint, 0, len(validators))
+	pubkeys := make([]string, 0)
+	for _, v := range validators {
+		if idx, err := strconv.ParseUint(v, 10, 64); err == nil {
+			idxs = append(idxs, uint(idx))
+		} else {
+			pubkeys = append(pubkeys, v)
+		}
+	}
+	if len(pubkeys) == 0 {
+		return idxs
+	}
+
+	infos, err := e.beaconClient.Validators("head", pubkeys)
+	if err != nil {
+		log.WithFields(logFields).Errorf(ResolveValidatorsError, err)
+		return idxs
+	}
+	for _, info := range infos {
+		idx, err := strconv.ParseUint(info.Index, 10, 64)
+		if err != nil {
+			log.WithFields(logFields).Errorf(ParseUintError, err)
+			continue
+		}
+		idxs = append(idxs, uint(idx))
+	}
+	return idxs
+}
+
+/*
+resolveValidators :
+Resolve the indexes of the monitored validators configured by public key with the consensus node. Only public keys not resolved yet are requested, so validators not deposited yet are resolved once they are.
+
+params :-
+none
+
+returns :-
+none
+*/
+func (e *eth2Monitor) resolveValidators() {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "resolveValidators"}
+
+	pubkeys := e.validators.Unresolved()
+	if len(pubkeys) == 0 {
+		return
+	}
+	infos, err := e.beaconClient.Validators("head", pubkeys)
+	if err != nil {
+		log.WithFields(logFields).Errorf(ValidatorIndexesError, err)
+		return
+	}
+	indexes := make(map[string]string, len(infos))
+	for _, info := range infos {
+		indexes[info.Validator.Pubkey] = info.Index
+	}
+	e.validators.SetIndexes(indexes)
+}
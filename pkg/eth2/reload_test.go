@@ -0,0 +1,153 @@
+package eth2
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/posmoni/pkg/eth2/db"
+	net "github.com/NethermindEth/posmoni/pkg/eth2/networking"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestReload(t *testing.T) {
+	td := t.TempDir()
+	defer cleanInitTestCase()
+
+	f, err := setupYML(td, `
+validators: [1, 2, "0xaa03"]
+consensus: "cl1"
+health_interval: 3600`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	viper.SetConfigFile(f)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	ormdb, err := gorm.Open(sqlite.Open("file:reload?mode=memory&cache=shared"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	repository := &db.SQLiteRepository{DB: ormdb}
+	tbc := newTestBeaconClient(nil, nil)
+	tbc.registry = []net.ValidatorInfo{
+		{Index: "3", Validator: net.ValidatorData{Pubkey: "0xaa03"}},
+		{Index: "4", Validator: net.ValidatorData{Pubkey: "0xaa04"}},
+	}
+	monitor, err := NewEth2Monitor(repository, tbc, &net.ExecutionClient{}, net.SubscribeOpts{Subscriber: testSubscriber{}}, ConfigOpts{
+		Checkers: []CfgChecker{
+			{Key: Validators, ErrMsg: NoValidatorsFoundError},
+			{Key: Consensus, ErrMsg: NoConsensusFoundError},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := populateDb(repository, []db.Validator{{Idx: 1}, {Idx: 2}, {Idx: 3}}); err != nil {
+		t.Fatal(err)
+	}
+
+	monitor.sourcesInterval = time.Millisecond * 10
+	doneChans, err := monitor.Monitor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(doneChans[0])
+	running := monitor.running
+
+	// Validators changed, endpoints and settings did not
+	if err := os.WriteFile(f, []byte(`
+validators: [2, "0xaa04", 5]
+consensus: "cl1"
+health_interval: 3600`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, monitor.Reload())
+	assert.Equal(t, []string{"2", "0xaa04", "5"}, monitor.validators.List())
+	assert.Same(t, running, monitor.running, "Reload() restarted monitoring without endpoint or settings changes")
+
+	want := map[uint]bool{1: true, 2: false, 3: true, 4: false, 5: false}
+	for idx, inactive := range want {
+		v, err := repository.Validator(idx)
+		if assert.NoError(t, err, "validator %d not in the repository", idx) {
+			assert.Equal(t, inactive, v.Inactive, "validator %d has wrong inactive flag", idx)
+		}
+	}
+
+	// Invalid configuration is not applied
+	if err := os.WriteFile(f, []byte(`
+validators: [1, 0-99999999]
+consensus: "cl1"`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	assert.Error(t, monitor.Reload())
+	assert.Equal(t, []string{"2", "0xaa04", "5"}, monitor.validators.List())
+
+	// Invalid settings are refused
+	if err := os.WriteFile(f, []byte(`
+validators: [1, 2]
+consensus: "cl1"
+health_interval: 0`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	assert.Error(t, monitor.Reload())
+	assert.Equal(t, []string{"2", "0xaa04", "5"}, monitor.validators.List())
+	assert.Equal(t, time.Hour, monitor.settings.healthInterval)
+
+	// Endpoints and settings changed, removed validator configured again
+	if err := os.WriteFile(f, []byte(`
+validators: [1, 2]
+consensus: "cl2"
+health_interval: 60`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, monitor.Reload())
+	assert.NotSame(t, running, monitor.running, "Reload() did not restart monitoring")
+	assert.Equal(t, []string{"cl2"}, tbc.endpoints)
+	assert.Equal(t, time.Minute, monitor.settings.healthInterval)
+	v, err := repository.Validator(1)
+	assert.NoError(t, err)
+	assert.False(t, v.Inactive)
+
+	// A file source added by a reload is watched without restarting monitoring
+	running = monitor.running
+	list := writeFile(t, td, "validators.txt", "6\n")
+	if err := os.WriteFile(f, []byte(fmt.Sprintf(`
+validators: [1, 2, %q]
+consensus: "cl2"
+health_interval: 60`, list)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, monitor.Reload())
+	assert.Same(t, running, monitor.running, "Reload() restarted monitoring without endpoint or settings changes")
+	assert.Equal(t, []string{"1", "2", "6"}, monitor.validators.List())
+	writeFile(t, td, "validators.txt", "7\n")
+	os.Chtimes(list, time.Now(), time.Now().Add(time.Second))
+	time.Sleep(time.Millisecond * 100)
+	assert.Equal(t, []string{"1", "2", "7"}, monitor.validators.List())
+
+	// Config file changes are picked up
+	watchDone, watchStopped := make(chan struct{}), make(chan struct{})
+	go func() {
+		monitor.watchConfig(watchDone, f, time.Millisecond*10)
+		close(watchStopped)
+	}()
+	time.Sleep(time.Millisecond * 20)
+	if err := os.WriteFile(f, []byte(`
+validators: [1, 2, 3]
+consensus: "cl2"
+health_interval: 60`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	os.Chtimes(f, time.Now(), time.Now().Add(time.Second))
+	time.Sleep(time.Millisecond * 100)
+	assert.Equal(t, []string{"1", "2", "3"}, monitor.validators.List())
+	close(watchDone)
+	<-watchStopped
+}
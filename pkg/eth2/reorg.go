@@ -0,0 +1,350 @@
+package eth2
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NethermindEth/posmoni/configs"
+	"github.com/NethermindEth/posmoni/pkg/eth2/alerts"
+	"github.com/NethermindEth/posmoni/pkg/eth2/db"
+	net "github.com/NethermindEth/posmoni/pkg/eth2/networking"
+	log "github.com/sirupsen/logrus"
+)
+
+// headBufferSlots : Number of slots of head blocks kept to find the blocks removed by a reorg (two epochs)
+const headBufferSlots = 64
+
+// headBuffer : Struct Keep the recent head blocks and reorgs seen, to find orphaned blocks and ignore repeated reorg events
+type headBuffer struct {
+	// Block root of the head seen at every slot
+	heads map[uint64]string
+	// Slot of every reorg already handled, keyed by old and new head roots
+	reorgs map[string]uint64
+}
+
+func newHeadBuffer() *headBuffer {
+	return &headBuffer{heads: make(map[uint64]string), reorgs: make(map[string]uint64)}
+}
+
+/*
+add :
+Record a new head block and forget the ones older than the buffer window.
+
+params :-
+a. slot uint64
+Slot of the head block
+b. root string
+Root of the head block
+
+returns :-
+none
+*/
+func (b *headBuffer) add(slot uint64, root string) {
+	b.heads[slot] = root
+	if slot < headBufferSlots {
+		return
+	}
+	for s := range b.heads {
+		if s < slot-headBufferSlots {
+			delete(b.heads, s)
+		}
+	}
+	for k, s := range b.reorgs {
+		if s < slot-headBufferSlots {
+			delete(b.reorgs, k)
+		}
+	}
+}
+
+/*
+orphaned :
+Get the head blocks removed from the canonical chain by a reorg, and forget them.
+
+params :-
+a. r net.ChainReorg
+Reorg event data
+b. slot uint64
+Slot of the new head
+c. depth uint64
+Depth of the reorg
+
+returns :-
+a. []uint64
+Slots affected by the reorg
+b. []string
+Roots of the blocks removed from the canonical chain. The old head is always included
+c. bool
+False if the reorg was already handled
+*/
+func (b *headBuffer) orphaned(r net.ChainReorg, slot, depth uint64) ([]uint64, []string, bool) {
+	key := r.OldHeadBlock + r.NewHeadBlock
+	if _, ok := b.reorgs[key]; ok {
+		return nil, nil, false
+	}
+	b.reorgs[key] = slot
+
+	// Listing more slots than the buffer keeps is pointless, and protects from bogus depths
+	if depth > headBufferSlots {
+		depth = headBufferSlots
+	}
+	var from uint64
+	if depth <= slot {
+		from = slot - depth + 1
+	}
+
+	affected := make([]uint64, 0, depth)
+	roots := []string{r.OldHeadBlock}
+	for s := from; s <= slot && depth > 0; s++ {
+		affected = append(affected, s)
+		root, ok := b.heads[s]
+		if !ok {
+			continue
+		}
+		delete(b.heads, s)
+		if root != r.NewHeadBlock && root != r.OldHeadBlock {
+			roots = append(roots, root)
+		}
+	}
+
+	return affected, roots, true
+}
+
+/*
+TrackReorgs :
+Follow head and chain reorg events, record every reorg in the repository and raise alerts when a reorg is deeper than the threshold or removes a block proposed by one of our validators.
+
+params :-
+a. events <-chan net.Event
+Channel to get head and chain reorg events from
+
+returns :-
+none
+*/
+func (e *eth2Monitor) TrackReorgs(events <-chan net.Event) {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "TrackReorgs"}
+	buffer := newHeadBuffer()
+
+	for ev := range events {
+		switch ev.Topic {
+		case net.HeadEvent:
+			var h net.Head
+			if err := json.Unmarshal(ev.Data, &h); err != nil {
+				log.WithFields(logFields).Errorf(ParseEventError, ev.Topic, ev.Endpoint, err)
+				continue
+			}
+			slot, err := strconv.ParseUint(h.Slot, 10, 64)
+			if err != nil {
+				log.WithFields(logFields).Errorf(ParseEventError, ev.Topic, ev.Endpoint, err)
+				continue
+			}
+			buffer.add(slot, h.Block)
+		case net.ChainReorgEvent:
+			report, ok, err := e.reorgReport(buffer, ev)
+			if err != nil {
+				log.WithFields(logFields).Errorf(ParseEventError, ev.Topic, ev.Endpoint, err)
+				continue
+			}
+			if !ok {
+				log.WithFields(logFields).Debugf("Chain reorg at slot %d already handled", report.Slot)
+				continue
+			}
+			e.handleReorg(report)
+		}
+	}
+}
+
+/*
+reorgReport :
+Build the report of a chain reorg event, looking up the proposers of the orphaned blocks.
+
+params :-
+a. buffer *headBuffer
+Recent head blocks
+b. ev net.Event
+Chain reorg event
+
+returns :-
+a. ReorgReport
+Reorg report
+b. bool
+False if the reorg was already handled
+c. error
+Error if the event could not be parsed
+*/
+func (e *eth2Monitor) reorgReport(buffer *headBuffer, ev net.Event) (ReorgReport, bool, error) {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "reorgReport"}
+
+	var r net.ChainReorg
+	if err := json.Unmarshal(ev.Data, &r); err != nil {
+		return ReorgReport{}, false, err
+	}
+	slot, err := strconv.ParseUint(r.Slot, 10, 64)
+	if err != nil {
+		return ReorgReport{}, false, err
+	}
+	depth, err := strconv.ParseUint(r.Depth, 10, 64)
+	if err != nil {
+		return ReorgReport{}, false, err
+	}
+
+	report := ReorgReport{
+		Endpoint:          ev.Endpoint,
+		Slot:              slot,
+		Depth:             depth,
+		OldHeadBlock:      r.OldHeadBlock,
+		NewHeadBlock:      r.NewHeadBlock,
+		OldHeadState:      r.OldHeadState,
+		NewHeadState:      r.NewHeadState,
+		OrphanedProposals: make([]OrphanedProposal, 0),
+	}
+
+	affected, roots, ok := buffer.orphaned(r, slot, depth)
+	if !ok {
+		return report, false, nil
+	}
+	report.AffectedSlots = affected
+
+	for _, root := range roots {
+		// Orphaned blocks are still known by the node that saw them
+		block, err := e.beaconClient.Block(ev.Endpoint, root)
+		if err != nil {
+			log.WithFields(logFields).Errorf(OrphanedBlockError, root, ev.Endpoint, err)
+			continue
+		}
+		blockSlot, err := strconv.ParseUint(block.Slot, 10, 64)
+		if err != nil {
+			log.WithFields(logFields).Errorf(ParseUintError, err)
+			continue
+		}
+		proposer, err := strconv.ParseUint(block.ProposerIndex, 10, 64)
+		if err != nil {
+			log.WithFields(logFields).Errorf(ParseUintError, err)
+			continue
+		}
+		if e.isTracked(proposer) {
+			report.OrphanedProposals = append(report.OrphanedProposals, OrphanedProposal{Slot: blockSlot, ValidatorIndex: proposer, Block: root})
+		}
+	}
+	sort.Slice(report.OrphanedProposals, func(i, j int) bool {
+		return report.OrphanedProposals[i].Slot < report.OrphanedProposals[j].Slot
+	})
+
+	return report, true, nil
+}
+
+/*
+isTracked :
+Check if a validator is one of ours, either configured by index or already known by the repository.
+
+params :-
+a. idx uint64
+Validator index
+
+returns :-
+a. bool
+True if the validator is tracked by the monitor
+*/
+func (e *eth2Monitor) isTracked(idx uint64) bool {
+	if e.validators.Contains(strconv.FormatUint(idx, 10)) {
+		return true
+	}
+
+	v, err := e.repository.Validator(uint(idx))
+	return err == nil && uint64(v.Idx) == idx
+}
+
+/*
+handleReorg :
+Record a reorg in the repository and raise its alerts.
+
+params :-
+a. r ReorgReport
+Reorg report
+
+returns :-
+none
+*/
+func (e *eth2Monitor) handleReorg(r ReorgReport) {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "handleReorg"}
+	log.WithFields(logFields).Warnf(ReorgMsg, r.Depth, r.Slot, r.Endpoint, r.OldHeadBlock, r.NewHeadBlock)
+
+	slots := make([]string, 0, len(r.AffectedSlots))
+	for _, s := range r.AffectedSlots {
+		slots = append(slots, strconv.FormatUint(s, 10))
+	}
+	proposers := make([]string, 0, len(r.OrphanedProposals))
+	for _, p := range r.OrphanedProposals {
+		proposers = append(proposers, strconv.FormatUint(p.ValidatorIndex, 10))
+	}
+
+	err := e.repository.SaveReorg(db.Reorg{
+		Slot:              r.Slot,
+		Depth:             r.Depth,
+		OldHeadBlock:      r.OldHeadBlock,
+		NewHeadBlock:      r.NewHeadBlock,
+		OldHeadState:      r.OldHeadState,
+		NewHeadState:      r.NewHeadState,
+		AffectedSlots:     strings.Join(slots, ","),
+		OrphanedProposals: strings.Join(proposers, ","),
+	})
+	if err != nil {
+		log.WithFields(logFields).Errorf(SaveReorgError, r.Slot, err)
+	}
+
+	for _, a := range reorgAlerts(r, e.settings.reorgDepthThreshold, time.Now()) {
+		if a.Kind == OrphanedProposalAlert {
+			a.Labels = e.groupLabels(a.Labels, a.Labels["validator"])
+		}
+		if err := e.alerter.Send(a); err != nil {
+			log.WithFields(logFields).Errorf(SendAlertError, err)
+		}
+	}
+}
+
+/*
+reorgAlerts :
+Build the alerts of a reorg: one if the reorg is deeper than the threshold, and one for every orphaned proposal of our validators.
+
+params :-
+a. r ReorgReport
+Reorg report
+b. threshold uint64
+Reorgs deeper than this are alerted
+c. now time.Time
+Time of the reorg
+
+returns :-
+a. []alerts.Alert
+Alerts to send
+*/
+func reorgAlerts(r ReorgReport, threshold uint64, now time.Time) []alerts.Alert {
+	as := make([]alerts.Alert, 0)
+
+	if r.Depth > threshold {
+		as = append(as, alerts.Alert{
+			Kind:     ReorgAlert,
+			Severity: alerts.Warning,
+			Source:   r.Endpoint,
+			Message:  fmt.Sprintf(ReorgMsg, r.Depth, r.Slot, r.Endpoint, r.OldHeadBlock, r.NewHeadBlock),
+			Labels:   map[string]string{"slot": strconv.FormatUint(r.Slot, 10), "depth": strconv.FormatUint(r.Depth, 10)},
+			Time:     now,
+		})
+	}
+
+	for _, p := range r.OrphanedProposals {
+		as = append(as, alerts.Alert{
+			Kind:     OrphanedProposalAlert,
+			Severity: alerts.Critical,
+			Source:   r.Endpoint,
+			Message:  fmt.Sprintf(OrphanedProposalMsg, p.Block, p.ValidatorIndex, p.Slot),
+			Labels:   map[string]string{"slot": strconv.FormatUint(p.Slot, 10), "validator": strconv.FormatUint(p.ValidatorIndex, 10)},
+			Time:     now,
+		})
+	}
+
+	return as
+}
@@ -0,0 +1,154 @@
+package eth2
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/posmoni/pkg/eth2/alerts"
+	"github.com/NethermindEth/posmoni/pkg/eth2/db"
+	net "github.com/NethermindEth/posmoni/pkg/eth2/networking"
+	"github.com/stretchr/testify/assert"
+)
+
+func headEvent(slot int, root string) net.Event {
+	return net.Event{Endpoint: "cl1", Topic: net.HeadEvent, Data: []byte(fmt.Sprintf(`{"slot":"%d","block":"%s"}`, slot, root))}
+}
+
+func reorgEvent(endpoint string, slot, depth int, oldHead, newHead string) net.Event {
+	return net.Event{
+		Endpoint: endpoint,
+		Topic:    net.ChainReorgEvent,
+		Data:     []byte(fmt.Sprintf(`{"slot":"%d","depth":"%d","old_head_block":"%s","new_head_block":"%s"}`, slot, depth, oldHead, newHead)),
+	}
+}
+
+func TestTrackReorgs(t *testing.T) {
+	t.Parallel()
+
+	blocks := map[string]map[string]net.BeaconBlock{
+		"cl1": {
+			"0xa1": {Slot: "100", ProposerIndex: "7"},
+			"0xa2": {Slot: "101", ProposerIndex: "8"},
+			"0xa3": {Slot: "102", ProposerIndex: "9"},
+		},
+	}
+
+	tcs := []struct {
+		name       string
+		validators []string
+		events     []net.Event
+		want       []db.Reorg
+		// wanted alert kinds
+		wantAlerts []string
+	}{
+		{
+			"Test case 1, no reorgs",
+			[]string{"7"},
+			[]net.Event{headEvent(100, "0xa1"), headEvent(101, "0xa2")},
+			nil,
+			[]string{},
+		},
+		{
+			"Test case 2, depth 1 reorg of a block of another validator",
+			[]string{"7"},
+			[]net.Event{headEvent(100, "0xa1"), headEvent(101, "0xa2"), reorgEvent("cl1", 101, 1, "0xa2", "0xb2")},
+			[]db.Reorg{{Slot: 101, Depth: 1, OldHeadBlock: "0xa2", NewHeadBlock: "0xb2", AffectedSlots: "101"}},
+			[]string{},
+		},
+		{
+			"Test case 3, depth 1 reorg of one of our blocks",
+			[]string{"8"},
+			[]net.Event{headEvent(100, "0xa1"), headEvent(101, "0xa2"), reorgEvent("cl1", 101, 1, "0xa2", "0xb2")},
+			[]db.Reorg{{Slot: 101, Depth: 1, OldHeadBlock: "0xa2", NewHeadBlock: "0xb2", AffectedSlots: "101", OrphanedProposals: "8"}},
+			[]string{OrphanedProposalAlert},
+		},
+		{
+			"Test case 4, deep reorg removing several of our blocks",
+			[]string{"7", "9"},
+			[]net.Event{headEvent(100, "0xa1"), headEvent(101, "0xa2"), headEvent(102, "0xa3"), reorgEvent("cl1", 102, 3, "0xa3", "0xb3")},
+			[]db.Reorg{{Slot: 102, Depth: 3, OldHeadBlock: "0xa3", NewHeadBlock: "0xb3", AffectedSlots: "100,101,102", OrphanedProposals: "7,9"}},
+			[]string{ReorgAlert, OrphanedProposalAlert, OrphanedProposalAlert},
+		},
+		{
+			"Test case 5, same reorg seen by two nodes",
+			[]string{},
+			[]net.Event{headEvent(101, "0xa2"), headEvent(102, "0xa3"), reorgEvent("cl1", 102, 2, "0xa3", "0xb3"), reorgEvent("cl2", 102, 2, "0xa3", "0xb3")},
+			[]db.Reorg{{Slot: 102, Depth: 2, OldHeadBlock: "0xa3", NewHeadBlock: "0xb3", AffectedSlots: "101,102"}},
+			[]string{ReorgAlert},
+		},
+		{
+			"Test case 6, bad events are skipped",
+			[]string{"8"},
+			[]net.Event{
+				{Endpoint: "cl1", Topic: net.HeadEvent, Data: []byte(`{{`)},
+				{Endpoint: "cl1", Topic: net.ChainReorgEvent, Data: []byte(`{"slot":"abc","depth":"1"}`)},
+				reorgEvent("cl1", 101, 1, "0xa2", "0xb2"),
+			},
+			[]db.Reorg{{Slot: 101, Depth: 1, OldHeadBlock: "0xa2", NewHeadBlock: "0xb2", AffectedSlots: "101", OrphanedProposals: "8"}},
+			[]string{OrphanedProposalAlert},
+		},
+		{
+			"Test case 7, old head unknown by the node",
+			[]string{"8"},
+			[]net.Event{reorgEvent("cl1", 101, 1, "0xff", "0xb2")},
+			[]db.Reorg{{Slot: 101, Depth: 1, OldHeadBlock: "0xff", NewHeadBlock: "0xb2", AffectedSlots: "101"}},
+			[]string{},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			tbc := newTestBeaconClient(nil, nil)
+			tbc.blocks = blocks
+			vs, err := newValidatorSet(tc.validators, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rm := &repositoryMock{}
+			am := &alerterMock{}
+			monitor := eth2Monitor{
+				beaconClient: tbc,
+				repository:   rm,
+				alerter:      am,
+				validators:   vs,
+				settings:     monitorSettings{reorgDepthThreshold: 1},
+			}
+
+			events := make(chan net.Event, len(tc.events))
+			for _, ev := range tc.events {
+				events <- ev
+			}
+			close(events)
+			monitor.TrackReorgs(events)
+
+			assert.Equal(t, tc.want, rm.reorgs, "TrackReorgs() saved wrong reorgs")
+			kinds := make([]string, 0)
+			for _, a := range am.all() {
+				kinds = append(kinds, a.Kind)
+			}
+			assert.Equal(t, tc.wantAlerts, kinds, "TrackReorgs() sent wrong alerts")
+		})
+	}
+}
+
+func TestReorgAlerts(t *testing.T) {
+	t.Parallel()
+
+	r := ReorgReport{
+		Endpoint:          "cl1",
+		Slot:              102,
+		Depth:             2,
+		OrphanedProposals: []OrphanedProposal{{Slot: 101, ValidatorIndex: 8, Block: "0xa2"}},
+	}
+
+	got := reorgAlerts(r, 2, time.Now())
+	assert.Len(t, got, 1)
+	assert.Equal(t, alerts.Critical, got[0].Severity)
+	assert.Equal(t, "8", got[0].Labels["validator"])
+
+	got = reorgAlerts(r, 1, time.Now())
+	assert.Len(t, got, 2)
+	assert.Equal(t, ReorgAlert, got[0].Kind)
+	assert.Equal(t, alerts.Warning, got[0].Severity)
+}
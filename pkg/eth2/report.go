@@ -0,0 +1,285 @@
+package eth2
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/NethermindEth/posmoni/pkg/eth2/db"
+)
+
+// Report formats
+const (
+	CSVFormat  = "csv"
+	JSONFormat = "json"
+)
+
+// reportDateLayout : Layout of dates accepted as report bounds
+const reportDateLayout = "2006-01-02"
+
+// reportLookback : Epochs before a report window searched for the balance the window starts from, about a day
+const reportLookback = 225
+
+// ValidatorReport : Struct Represent balance changes and duties of a validator over a window of epochs. Amounts are in gwei
+type ValidatorReport struct {
+	Validator uint   `json:"validator"`
+	Group     string `json:"group,omitempty"`
+	// First and last epochs with a balance snapshot in the window. The start balance can come from a snapshot before the window
+	FromEpoch    uint64 `json:"from_epoch"`
+	ToEpoch      uint64 `json:"to_epoch"`
+	StartBalance uint64 `json:"start_balance"`
+	EndBalance   uint64 `json:"end_balance"`
+	// Effective balance of the last snapshot with one, zero if unknown
+	EndEffectiveBalance uint64 `json:"end_effective_balance"`
+	// Sum of balance increases and decreases between snapshots, not counting withdrawals
+	Rewards            uint64 `json:"rewards"`
+	Penalties          uint64 `json:"penalties"`
+	MissedAttestations uint   `json:"missed_attestations"`
+	Proposals          uint   `json:"proposals"`
+	MissedProposals    uint   `json:"missed_proposals"`
+	Withdrawals        uint64 `json:"withdrawals"`
+	// Consensus rewards lost against an ideal validator by attestations and missed proposals
+	MissedRewards uint64 `json:"missed_rewards"`
+}
+
+// reportHeader : CSV header of the report
+var reportHeader = []string{
+	"validator", "group", "from_epoch", "to_epoch", "start_balance_gwei", "end_balance_gwei", "end_effective_balance_gwei", "rewards_gwei", "penalties_gwei",
+	"missed_attestations", "proposals", "missed_proposals", "withdrawals_gwei", "missed_rewards_gwei",
+}
+
+/*
+BuildReport :
+Build the report of every validator with balance snapshots, proposals or attestations in a window of epochs, from the repository. Balance changes between consecutive snapshots are counted as rewards or penalties, after adding back withdrawn amounts. The window starts from the last snapshot before it, so reports of consecutive windows add up.
+
+params :-
+a. r db.Repository
+Repository to read snapshots and proposals from
+b. fromEpoch uint64
+First epoch of the window
+c. toEpoch uint64
+Last epoch of the window, included
+
+returns :-
+a. []ValidatorReport
+Report of every validator, sorted by index
+b. error
+Error if any
+*/
+func BuildReport(r db.Repository, fromEpoch, toEpoch uint64) ([]ValidatorReport, error) {
+	if fromEpoch > toEpoch {
+		return nil, fmt.Errorf(ReportRangeError, fromEpoch, toEpoch)
+	}
+
+	snapshots, err := windowSnapshots(r, fromEpoch, toEpoch)
+	if err != nil {
+		return nil, err
+	}
+	proposals, err := r.Proposals(fromEpoch, toEpoch)
+	if err != nil {
+		return nil, err
+	}
+	attestations, err := r.Attestations(fromEpoch, toEpoch)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]ValidatorReport, 0)
+	byIdx := make(map[uint]int)
+	walkSnapshots(snapshots, fromEpoch, func(prev, s *db.BalanceSnapshot) {
+		j, ok := byIdx[s.Idx]
+		if !ok {
+			rep := ValidatorReport{Validator: s.Idx, FromEpoch: s.Epoch, StartBalance: s.Balance}
+			if prev != nil {
+				rep.StartBalance = prev.Balance
+			}
+			if v, err := r.Validator(s.Idx); err == nil {
+				rep.Group = v.Group
+			}
+			j = len(reports)
+			byIdx[s.Idx] = j
+			reports = append(reports, rep)
+		}
+
+		rep := &reports[j]
+		if prev != nil {
+			if change := balanceChange(prev, s); change >= 0 {
+				rep.Rewards += uint64(change)
+			} else {
+				rep.Penalties += uint64(-change)
+			}
+			rep.Withdrawals += s.Withdrawn
+		}
+		if s.Missed {
+			rep.MissedAttestations++
+		}
+		rep.ToEpoch, rep.EndBalance = s.Epoch, s.Balance
+		if s.EffectiveBalance > 0 {
+			rep.EndEffectiveBalance = s.EffectiveBalance
+		}
+	})
+
+	report := func(idx uint, epoch uint64) *ValidatorReport {
+		j, ok := byIdx[idx]
+		if !ok {
+			j = len(reports)
+			byIdx[idx] = j
+			reports = append(reports, ValidatorReport{Validator: idx, FromEpoch: epoch, ToEpoch: epoch})
+		}
+		return &reports[j]
+	}
+	for _, p := range proposals {
+		rep := report(p.Idx, p.Epoch)
+		rep.Proposals++
+		if p.Missed {
+			rep.MissedProposals++
+			rep.MissedRewards += p.MissedReward
+		}
+	}
+	for _, a := range attestations {
+		report(a.Idx, a.Epoch).MissedRewards += a.MissedReward
+	}
+
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Validator < reports[j].Validator })
+	return reports, nil
+}
+
+// windowSnapshots : Get the balance snapshots of a window of epochs, and the ones of the lookback before it, sorted by validator index and epoch
+func windowSnapshots(r db.Repository, fromEpoch, toEpoch uint64) ([]db.BalanceSnapshot, error) {
+	lookFrom := uint64(0)
+	if fromEpoch > reportLookback {
+		lookFrom = fromEpoch - reportLookback
+	}
+	return r.Snapshots(lookFrom, toEpoch)
+}
+
+/*
+walkSnapshots :
+Call a function for every snapshot in a window with the previous snapshot of the same validator, which is the last one before the window for the first snapshot in it.
+
+params :-
+a. snapshots []db.BalanceSnapshot
+Snapshots sorted by validator index and epoch
+b. fromEpoch uint64
+First epoch of the window. Earlier snapshots are only used as previous ones
+c. f func(prev, s *db.BalanceSnapshot)
+Function to call. prev is nil for the first snapshot of a validator
+
+returns :-
+none
+*/
+func walkSnapshots(snapshots []db.BalanceSnapshot, fromEpoch uint64, f func(prev, s *db.BalanceSnapshot)) {
+	var prev *db.BalanceSnapshot
+	for i := range snapshots {
+		s := &snapshots[i]
+		if prev != nil && prev.Idx != s.Idx {
+			prev = nil
+		}
+		if s.Epoch >= fromEpoch {
+			f(prev, s)
+		}
+		prev = s
+	}
+}
+
+// balanceChange : Get the balance change in gwei from a snapshot to the next one, adding back withdrawals since they lower the balance without being a penalty. Zero without previous snapshot
+func balanceChange(prev, s *db.BalanceSnapshot) int64 {
+	if prev == nil {
+		return 0
+	}
+	return int64(s.Balance+s.Withdrawn) - int64(prev.Balance)
+}
+
+/*
+WriteReport :
+Write a report in CSV or JSON format.
+
+params :-
+a. w io.Writer
+Writer to write the report to
+b. reports []ValidatorReport
+Report of every validator
+c. format string
+Output format, csv or json
+
+returns :-
+a. error
+Error if any
+*/
+func WriteReport(w io.Writer, reports []ValidatorReport, format string) error {
+	switch format {
+	case JSONFormat:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(reports)
+	case CSVFormat:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(reportHeader); err != nil {
+			return err
+		}
+		for _, r := range reports {
+			record := []string{
+				fmt.Sprint(r.Validator), r.Group, fmt.Sprint(r.FromEpoch), fmt.Sprint(r.ToEpoch), fmt.Sprint(r.StartBalance), fmt.Sprint(r.EndBalance),
+				fmt.Sprint(r.EndEffectiveBalance), fmt.Sprint(r.Rewards), fmt.Sprint(r.Penalties), fmt.Sprint(r.MissedAttestations), fmt.Sprint(r.Proposals), fmt.Sprint(r.MissedProposals),
+				fmt.Sprint(r.Withdrawals), fmt.Sprint(r.MissedRewards),
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	default:
+		return fmt.Errorf(ReportFormatError, format, []string{CSVFormat, JSONFormat})
+	}
+}
+
+/*
+ReportEpoch :
+Parse a report bound given as an epoch or a date in YYYY-MM-DD format, in UTC. Start dates give the first epoch starting on the day and end dates the last one, so windows of consecutive days do not overlap.
+
+params :-
+a. value string
+Epoch or date
+b. end bool
+True if value is the end of the window
+c. clock func() (*Clock, error)
+Clock of the network, only called for dates
+
+returns :-
+a. uint64
+Epoch
+b. error
+Error if any
+*/
+func ReportEpoch(value string, end bool, clock func() (*Clock, error)) (uint64, error) {
+	if epoch, err := strconv.ParseUint(value, 10, 64); err == nil {
+		return epoch, nil
+	}
+
+	day, err := time.Parse(reportDateLayout, value)
+	if err != nil {
+		return 0, fmt.Errorf(InvalidReportBoundError, value)
+	}
+	c, err := clock()
+	if err != nil {
+		return 0, err
+	}
+	if end {
+		// Last epoch starting before the next day
+		next := day.AddDate(0, 0, 1)
+		if !next.After(c.Genesis()) {
+			return 0, nil
+		}
+		return c.EpochAt(next.Add(-time.Nanosecond)), nil
+	}
+	epoch := c.EpochAt(day)
+	if c.EpochStart(epoch).Before(day) {
+		epoch++
+	}
+	return epoch, nil
+}
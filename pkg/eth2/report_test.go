@@ -0,0 +1,237 @@
+package eth2
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/posmoni/internal/utils"
+	"github.com/NethermindEth/posmoni/pkg/eth2/db"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestBuildReport(t *testing.T) {
+	ormdb, err := gorm.Open(sqlite.Open("file:report?mode=memory&cache=shared"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	repository := &db.SQLiteRepository{DB: ormdb}
+	defer cleanup(repository)
+	if err := repository.Migrate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := populateDb(repository, []db.Validator{{Idx: 1, Group: "acme"}, {Idx: 2}}); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshots := []db.BalanceSnapshot{
+		// Before the window, only the last one is used as the start balance
+		{Idx: 1, Epoch: 8, Balance: 32000000000},
+		{Idx: 1, Epoch: 9, Balance: 32000009000},
+		{Idx: 1, Epoch: 10, Balance: 32000020000},
+		{Idx: 1, Epoch: 11, Balance: 32000015000, Missed: true, EffectiveBalance: 32000000000},
+		// Withdrawal of 20000 gwei with 10000 gwei of rewards, effective balance unknown
+		{Idx: 1, Epoch: 12, Balance: 32000005000, Withdrawn: 20000},
+		{Idx: 2, Epoch: 11, Balance: 32000000000, EffectiveBalance: 32000000000},
+		{Idx: 2, Epoch: 12, Balance: 31999990000, Missed: true, EffectiveBalance: 31000000000},
+		// After the window
+		{Idx: 2, Epoch: 13, Balance: 32000000000},
+	}
+	for _, s := range snapshots {
+		if err := repository.SaveSnapshot(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Saving a snapshot again replaces it
+	if err := repository.SaveSnapshot(db.BalanceSnapshot{Idx: 1, Epoch: 9, Balance: 32000010000}); err != nil {
+		t.Fatal(err)
+	}
+
+	proposals := []db.Proposal{
+		{Idx: 1, Slot: 330, Epoch: 10},
+		{Idx: 3, Slot: 390, Epoch: 12, Missed: true, MissedReward: 40000},
+		{Idx: 2, Slot: 420, Epoch: 13},
+	}
+	for _, p := range proposals {
+		if err := repository.SaveProposal(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	attestations := []db.Attestation{
+		{Idx: 1, Epoch: 11, Slot: 355, Missed: true, IdealReward: 14000, MissedReward: 24000},
+		{Idx: 1, Epoch: 12, Slot: 390, InclusionSlot: 391, InclusionDistance: 1, CorrectHead: true, CorrectTarget: true, CorrectSource: true, Effectiveness: 1, IdealReward: 14000},
+		// Only attestations in the window count
+		{Idx: 2, Epoch: 13, Slot: 420, Missed: true, IdealReward: 14000, MissedReward: 24000},
+	}
+	for _, a := range attestations {
+		if err := repository.SaveAttestation(a); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tcs := []struct {
+		name    string
+		from    uint64
+		to      uint64
+		want    []ValidatorReport
+		isError bool
+	}{
+		{
+			"Test case 1, window with snapshots before and after it",
+			10,
+			12,
+			[]ValidatorReport{
+				{Validator: 1, Group: "acme", FromEpoch: 10, ToEpoch: 12, StartBalance: 32000010000, EndBalance: 32000005000, EndEffectiveBalance: 32000000000, Rewards: 20000, Penalties: 5000, MissedAttestations: 1, Proposals: 1, Withdrawals: 20000, MissedRewards: 24000},
+				{Validator: 2, FromEpoch: 11, ToEpoch: 12, StartBalance: 32000000000, EndBalance: 31999990000, EndEffectiveBalance: 31000000000, Penalties: 10000, MissedAttestations: 1},
+				{Validator: 3, FromEpoch: 12, ToEpoch: 12, Proposals: 1, MissedProposals: 1, MissedRewards: 40000},
+			},
+			false,
+		},
+		{
+			"Test case 2, consecutive windows add up",
+			8,
+			9,
+			[]ValidatorReport{
+				{Validator: 1, Group: "acme", FromEpoch: 8, ToEpoch: 9, StartBalance: 32000000000, EndBalance: 32000010000, Rewards: 10000},
+			},
+			false,
+		},
+		{
+			"Test case 3, empty window",
+			100,
+			200,
+			[]ValidatorReport{},
+			false,
+		},
+		{
+			"Test case 4, start after end",
+			12,
+			10,
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := BuildReport(repository, tc.from, tc.to)
+
+			if err := utils.CheckErr("BuildReport()", tc.isError, err); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	t.Parallel()
+
+	reports := []ValidatorReport{
+		{Validator: 1, Group: "acme", FromEpoch: 10, ToEpoch: 12, StartBalance: 32000010000, EndBalance: 32000005000, EndEffectiveBalance: 32000000000, Rewards: 20000, Penalties: 5000, MissedAttestations: 1, Proposals: 1, Withdrawals: 20000, MissedRewards: 24000},
+	}
+
+	tcs := []struct {
+		name    string
+		format  string
+		want    string
+		isError bool
+	}{
+		{
+			"Test case 1, csv",
+			CSVFormat,
+			"validator,group,from_epoch,to_epoch,start_balance_gwei,end_balance_gwei,end_effective_balance_gwei,rewards_gwei,penalties_gwei,missed_attestations,proposals,missed_proposals,withdrawals_gwei,missed_rewards_gwei\n" +
+				"1,acme,10,12,32000010000,32000005000,32000000000,20000,5000,1,1,0,20000,24000\n",
+			false,
+		},
+		{
+			"Test case 2, json",
+			JSONFormat,
+			`[
+  {
+    "validator": 1,
+    "group": "acme",
+    "from_epoch": 10,
+    "to_epoch": 12,
+    "start_balance": 32000010000,
+    "end_balance": 32000005000,
+    "end_effective_balance": 32000000000,
+    "rewards": 20000,
+    "penalties": 5000,
+    "missed_attestations": 1,
+    "proposals": 1,
+    "missed_proposals": 0,
+    "withdrawals": 20000,
+    "missed_rewards": 24000
+  }
+]
+`,
+			false,
+		},
+		{
+			"Test case 3, unknown format",
+			"xlsx",
+			"",
+			true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := WriteReport(&buf, reports, tc.format)
+
+			if err := utils.CheckErr("WriteReport()", tc.isError, err); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+}
+
+func TestReportEpoch(t *testing.T) {
+	t.Parallel()
+
+	// Mainnet timing with genesis at midnight, 225 epochs a day
+	genesis := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() (*Clock, error) {
+		return NewClock(genesis, 12, 32)
+	}
+	// Genesis a minute later, days start inside an epoch
+	lateClock := func() (*Clock, error) {
+		return NewClock(genesis.Add(time.Minute), 12, 32)
+	}
+
+	tcs := []struct {
+		name    string
+		value   string
+		end     bool
+		clock   func() (*Clock, error)
+		want    uint64
+		isError bool
+	}{
+		{"Test case 1, epoch", "1234", false, nil, 1234, false},
+		{"Test case 2, start date at an epoch boundary", "2022-01-02", false, clock, 225, false},
+		{"Test case 3, end date", "2022-01-02", true, clock, 449, false},
+		{"Test case 4, start date inside an epoch", "2022-01-04", false, lateClock, 675, false},
+		{"Test case 5, end date inside an epoch", "2022-01-03", true, lateClock, 674, false},
+		{"Test case 6, end date before genesis", "2021-12-30", true, clock, 0, false},
+		{"Test case 7, invalid value", "last-month", false, clock, 0, true},
+		{"Test case 8, no clock", "2022-01-02", false, func() (*Clock, error) { return nil, fmt.Errorf("no clock") }, 0, true},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ReportEpoch(tc.value, tc.end, tc.clock)
+
+			if err := utils.CheckErr("ReportEpoch()", tc.isError, err); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
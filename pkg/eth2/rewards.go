@@ -0,0 +1,207 @@
+package eth2
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/NethermindEth/posmoni/configs"
+	"github.com/NethermindEth/posmoni/pkg/eth2/alerts"
+	"github.com/NethermindEth/posmoni/pkg/eth2/db"
+	log "github.com/sirupsen/logrus"
+)
+
+// Reward constants of the consensus specs since Altair
+const (
+	baseRewardFactor   = 64
+	timelySourceWeight = 14
+	timelyTargetWeight = 26
+	timelyHeadWeight   = 14
+	proposerWeight     = 8
+	weightDenominator  = 64
+	// Inclusion distance of attestations included in the next slot, the only one rewarded for the head vote
+	minInclusionDelay = 1
+)
+
+// totalActiveBalanceTTL : Epochs the total active balance of the network is reused for, about a day. It changes slowly, and fetching it means getting the whole registry
+const totalActiveBalanceTTL = 225
+
+/*
+updateTotalActiveBalance :
+Fetch the total effective balance of the active validators of the network, needed to compute ideal rewards. It is fetched again once it is older than a day of epochs.
+
+params :-
+a. stateID string
+Blockchain state ID from when to get the active validators
+b. epoch uint64
+Epoch of the state
+
+returns :-
+none
+*/
+func (e *eth2Monitor) updateTotalActiveBalance(stateID string, epoch uint64) {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "updateTotalActiveBalance"}
+	if e.totalActiveBalance > 0 && epoch < e.totalActiveEpoch+totalActiveBalanceTTL {
+		return
+	}
+
+	active, err := e.beaconClient.ActiveValidators(stateID)
+	if err != nil {
+		// The previous total, if any, is kept until the next try
+		log.WithFields(logFields).Errorf(TotalActiveBalanceError, err)
+		return
+	}
+	var total uint64
+	for _, v := range active {
+		effective, err := strconv.ParseUint(v.Validator.EffectiveBalance, 10, 64)
+		if err != nil {
+			log.WithFields(logFields).Errorf(ParseUintError, err)
+			continue
+		}
+		total += effective
+	}
+	e.totalActiveBalance, e.totalActiveEpoch = total, epoch
+	log.WithFields(logFields).Infof("Total active balance at epoch %d: %s ETH of %d validators", epoch, formatGwei(int64(total)), len(active))
+}
+
+// integerSquareRoot : Get the largest integer whose square is not above n, as in the consensus specs
+func integerSquareRoot(n uint64) uint64 {
+	x := n
+	y := (x + 1) / 2
+	for y < x {
+		x = y
+		y = (x + n/x) / 2
+	}
+	return x
+}
+
+// baseRewardPerIncrement : Get the base reward in gwei of each ether of effective balance, zero if the total active balance is unknown
+func baseRewardPerIncrement(totalActive uint64) uint64 {
+	if totalActive == 0 {
+		return 0
+	}
+	return effectiveBalanceIncrement * baseRewardFactor / integerSquareRoot(totalActive)
+}
+
+/*
+attestationRewards :
+Get the reward of an ideal attestation and the rewards an attestation lost against it, following the consensus specs since Deneb. The source vote is rewarded if included within the square root of the slots of an epoch, the target vote if included at all, and the head vote if included in the next slot. Votes are only rewarded if the votes before them are correct. Source and target votes not rewarded are also penalized by the same amount. Full participation of the network is assumed, so rewards are slightly above the actual ones.
+
+params :-
+a. a db.Attestation
+Attestation, with its inclusion and votes
+b. effective uint64
+Effective balance of the validator in gwei
+c. totalActive uint64
+Total active balance of the network in gwei
+d. slotsPerEpoch uint64
+Slots of an epoch
+
+returns :-
+a. uint64
+Reward in gwei of an ideal attestation
+b. uint64
+Rewards in gwei lost against the ideal attestation, with penalties
+*/
+func attestationRewards(a db.Attestation, effective, totalActive, slotsPerEpoch uint64) (uint64, uint64) {
+	baseReward := effective / effectiveBalanceIncrement * baseRewardPerIncrement(totalActive)
+	source := baseReward * timelySourceWeight / weightDenominator
+	target := baseReward * timelyTargetWeight / weightDenominator
+	head := baseReward * timelyHeadWeight / weightDenominator
+
+	matchingTarget := !a.Missed && a.CorrectSource && a.CorrectTarget
+	var missed uint64
+	if a.Missed || !a.CorrectSource || a.InclusionDistance > integerSquareRoot(slotsPerEpoch) {
+		missed += 2 * source
+	}
+	if !matchingTarget {
+		missed += 2 * target
+	}
+	if !matchingTarget || !a.CorrectHead || a.InclusionDistance > minInclusionDelay {
+		missed += head
+	}
+	return source + target + head, missed
+}
+
+// idealProposerReward : Get the consensus rewards in gwei of an ideal block proposal, for the attestations and sync committee signatures it includes with full participation
+func idealProposerReward(totalActive, slotsPerEpoch uint64) uint64 {
+	if slotsPerEpoch == 0 {
+		return 0
+	}
+	totalBaseRewards := totalActive / effectiveBalanceIncrement * baseRewardPerIncrement(totalActive)
+	return totalBaseRewards * proposerWeight / weightDenominator / slotsPerEpoch
+}
+
+// epochRewards : Struct Represent the attestation rewards of a set of validators in an epoch
+type epochRewards struct {
+	// Group with its own missed rewards threshold, nil for validators checked against the monitor threshold
+	group *ValidatorGroup
+	// Ideal rewards in gwei
+	ideal uint64
+	// Rewards lost against the ideal ones in gwei
+	missed uint64
+	// Validators that lost rewards
+	losing int
+}
+
+// add : Add the rewards of a validator
+func (r *epochRewards) add(ideal, missed uint64) {
+	r.ideal += ideal
+	r.missed += missed
+	if missed > 0 {
+		r.losing++
+	}
+}
+
+/*
+checkMissedRewards :
+Alert if monitored validators lost too large a share of the ideal attestation rewards of an epoch, against the threshold of their group or the configured one. The alert is marked as network-wide while the whole network is degraded.
+
+params :-
+a. epoch uint64
+Target epoch of the attestations
+b. r epochRewards
+Rewards of the validators
+
+returns :-
+none
+*/
+func (e *eth2Monitor) checkMissedRewards(epoch uint64, r epochRewards) {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "checkMissedRewards"}
+	threshold := e.settings.missedRewardsThreshold
+	if r.group != nil {
+		threshold = r.group.Thresholds.MissedRewards
+	}
+	if threshold == 0 || r.ideal == 0 {
+		return
+	}
+	share := float64(r.missed) / float64(r.ideal) * 100
+	if share < float64(threshold) {
+		return
+	}
+
+	alert := alerts.Alert{
+		Kind:     MissedRewardsAlert,
+		Severity: alerts.Warning,
+		Source:   strconv.FormatUint(epoch, 10),
+		Message:  fmt.Sprintf(MissedRewardsMsg, epoch, formatGwei(int64(r.missed)), formatGwei(int64(r.ideal)), share, r.losing),
+		Labels: map[string]string{
+			"epoch": strconv.FormatUint(epoch, 10), "ideal_rewards": strconv.FormatUint(r.ideal, 10),
+			"missed_rewards": strconv.FormatUint(r.missed, 10), "validators": strconv.Itoa(r.losing),
+		},
+		Time: time.Now(),
+	}
+	if r.group != nil {
+		alert.Message = fmt.Sprintf(GroupMissedRewardsMsg, r.group.Name, alert.Message)
+		alert.Labels["group"] = r.group.Name
+		for k, v := range r.group.Labels {
+			if _, set := alert.Labels[k]; !set {
+				alert.Labels[k] = v
+			}
+		}
+	}
+	e.networkWide(&alert)
+	if err := e.alerter.Send(alert); err != nil {
+		log.WithFields(logFields).Errorf(SendAlertError, err)
+	}
+}
@@ -0,0 +1,159 @@
+package eth2
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/posmoni/pkg/eth2/alerts"
+	"github.com/NethermindEth/posmoni/pkg/eth2/db"
+	net "github.com/NethermindEth/posmoni/pkg/eth2/networking"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntegerSquareRoot(t *testing.T) {
+	t.Parallel()
+
+	for n, want := range map[uint64]uint64{0: 0, 1: 1, 15: 3, 16: 4, 32: 5, 32000000000000000: 178885438} {
+		assert.Equal(t, want, integerSquareRoot(n), "integerSquareRoot(%d)", n)
+	}
+}
+
+func TestAttestationRewards(t *testing.T) {
+	t.Parallel()
+
+	// A million validators of 32 ether, base reward of 11424 gwei: 2499 gwei for source and head votes, 4641 gwei for target votes
+	total := uint64(32000000000000000)
+	tcs := []struct {
+		name       string
+		att        db.Attestation
+		effective  uint64
+		wantIdeal  uint64
+		wantMissed uint64
+	}{
+		{
+			"Test case 1, ideal attestation",
+			db.Attestation{InclusionDistance: 1, CorrectHead: true, CorrectTarget: true, CorrectSource: true},
+			32000000000,
+			9639,
+			0,
+		},
+		{
+			"Test case 2, included after the next slot, head not rewarded",
+			db.Attestation{InclusionDistance: 2, CorrectHead: true, CorrectTarget: true, CorrectSource: true},
+			32000000000,
+			9639,
+			2499,
+		},
+		{
+			"Test case 3, included after 5 slots, source penalized",
+			db.Attestation{InclusionDistance: 6, CorrectHead: true, CorrectTarget: true, CorrectSource: true},
+			32000000000,
+			9639,
+			7497,
+		},
+		{
+			"Test case 4, wrong target, head not rewarded either",
+			db.Attestation{InclusionDistance: 1, CorrectHead: true, CorrectSource: true},
+			32000000000,
+			9639,
+			11781,
+		},
+		{
+			"Test case 5, missed",
+			db.Attestation{Missed: true},
+			32000000000,
+			9639,
+			16779,
+		},
+		{
+			"Test case 6, lower effective balance",
+			db.Attestation{Missed: true},
+			16000000000,
+			4818,
+			8387,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ideal, missed := attestationRewards(tc.att, tc.effective, total, 32)
+			assert.Equal(t, tc.wantIdeal, ideal)
+			assert.Equal(t, tc.wantMissed, missed)
+		})
+	}
+}
+
+func TestIdealProposerReward(t *testing.T) {
+	t.Parallel()
+
+	// An eighth of the base rewards of a slot
+	assert.Equal(t, uint64(44625000), idealProposerReward(32000000000000000, 32))
+	assert.Equal(t, uint64(0), idealProposerReward(0, 32))
+	assert.Equal(t, uint64(0), idealProposerReward(32000000000000000, 0))
+}
+
+func TestUpdateTotalActiveBalance(t *testing.T) {
+	t.Parallel()
+
+	tbc := &TestBeaconClient{}
+	monitor := eth2Monitor{beaconClient: tbc}
+
+	// Unavailable
+	monitor.updateTotalActiveBalance("finalized", 10)
+	assert.Equal(t, uint64(0), monitor.totalActiveBalance)
+
+	tbc.active = []net.ValidatorInfo{
+		{Index: "1", Validator: net.ValidatorData{EffectiveBalance: "32000000000"}},
+		{Index: "2", Validator: net.ValidatorData{EffectiveBalance: "31000000000"}},
+	}
+	monitor.updateTotalActiveBalance("finalized", 10)
+	assert.Equal(t, uint64(63000000000), monitor.totalActiveBalance)
+
+	// Reused for a day of epochs
+	tbc.active = append(tbc.active, net.ValidatorInfo{Index: "3", Validator: net.ValidatorData{EffectiveBalance: "32000000000"}})
+	monitor.updateTotalActiveBalance("finalized", 234)
+	assert.Equal(t, uint64(63000000000), monitor.totalActiveBalance)
+	monitor.updateTotalActiveBalance("finalized", 235)
+	assert.Equal(t, uint64(95000000000), monitor.totalActiveBalance)
+
+	// The last total is kept on errors
+	tbc.active = nil
+	monitor.updateTotalActiveBalance("finalized", 500)
+	assert.Equal(t, uint64(95000000000), monitor.totalActiveBalance)
+}
+
+func TestCheckMissedRewards(t *testing.T) {
+	t.Parallel()
+
+	am := &alerterMock{}
+	monitor := eth2Monitor{settings: monitorSettings{missedRewardsThreshold: 10}, alerter: am}
+
+	// Below the threshold, or ideal rewards unknown
+	monitor.checkMissedRewards(5, epochRewards{ideal: 100000, missed: 9999, losing: 1})
+	monitor.checkMissedRewards(5, epochRewards{})
+	assert.Empty(t, am.all())
+
+	monitor.checkMissedRewards(6, epochRewards{ideal: 100000, missed: 10000, losing: 2})
+	sent := am.all()
+	if assert.Len(t, sent, 1) {
+		assert.Equal(t, MissedRewardsAlert, sent[0].Kind)
+		assert.Equal(t, alerts.Warning, sent[0].Severity)
+		assert.Equal(t, "attestations of epoch 6 lost 0.000010000 ETH against ideal rewards of 0.000100000 ETH (10.0%). Validators with losses: 2", sent[0].Message)
+	}
+
+	// Groups with their own threshold
+	group := &ValidatorGroup{Name: "acme", Labels: map[string]string{"customer": "acme"}, Thresholds: GroupThresholds{MissedRewards: 20}}
+	monitor.checkMissedRewards(6, epochRewards{group: group, ideal: 100000, missed: 19999, losing: 1})
+	assert.Len(t, am.all(), 1)
+	monitor.checkMissedRewards(6, epochRewards{group: group, ideal: 100000, missed: 20000, losing: 1})
+	sent = am.all()
+	if assert.Len(t, sent, 2) {
+		assert.Equal(t, "group acme: attestations of epoch 6 lost 0.000020000 ETH against ideal rewards of 0.000100000 ETH (20.0%). Validators with losses: 1", sent[1].Message)
+		assert.Equal(t, "acme", sent[1].Labels["group"])
+		assert.Equal(t, "acme", sent[1].Labels["customer"])
+	}
+
+	// Disabled
+	monitor.settings.missedRewardsThreshold = 0
+	monitor.checkMissedRewards(7, epochRewards{ideal: 100000, missed: 100000, losing: 3})
+	assert.Len(t, am.all(), 2)
+}
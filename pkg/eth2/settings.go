@@ -0,0 +1,243 @@
+package eth2
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/NethermindEth/posmoni/pkg/eth2/alerts"
+	"github.com/spf13/viper"
+)
+
+// monitorSettings : Struct Represent tunable thresholds of the monitor
+type monitorSettings struct {
+	// Minimum number of connected peers a consensus node should have
+	minPeers uint64
+	// Minimum number of inbound peers a consensus node should have. Zero disables the check
+	minInboundPeers uint64
+	// Time between node health checks
+	healthInterval time.Duration
+	// Time a node health state should last before alerting about it
+	healthGracePeriod time.Duration
+	// Webhook URL to post alerts to. Alerts are only logged if empty
+	alertsWebhook string
+	// Chain reorgs deeper than this number of slots are alerted
+	reorgDepthThreshold uint64
+	// Consecutive missed attestations of a validator before alerting
+	missedAttestationsThreshold uint
+	// Effective balance in gwei below which a validator is alerted. Zero disables the check
+	minEffectiveBalance uint64
+	// Percentage of the ideal attestation rewards of an epoch lost before alerting. Zero disables the check
+	missedRewardsThreshold uint
+	// Epochs finality can fall behind its usual delay before alerting
+	finalityStallEpochs uint64
+	// Percentage of the network attesting to the correct target below which the network is degraded. Zero disables the check
+	minParticipation uint
+	// Network preset name. With the custom network, genesis and spec are read from the consensus nodes
+	network string
+	// Keymanager APIs of the validator clients. Keys are not checked if empty
+	keymanagers []Keymanager
+	// Monitor keys loaded in the validator clients that are not configured
+	keymanagerAutoMonitor bool
+	// Expected fee recipient of every validator, in lower case. Not checked if empty
+	feeRecipient string
+	// Expected fee recipients by validator index, in lower case
+	feeRecipients map[string]string
+	// MEV-boost relay URLs without relay public keys. Proposals are not checked against relays if empty
+	relays []string
+	// mev-boost endpoints. Not checked if empty
+	mevBoost []string
+}
+
+// settingDefaults : Default values of the monitor settings, also shown in the command line flags help
+var settingDefaults = map[string]any{
+	MinPeers:                    10,
+	MinInboundPeers:             0,
+	HealthInterval:              60,
+	HealthGracePeriod:           180,
+	AlertsWebhook:               "",
+	ReorgDepthThreshold:         1,
+	Network:                     CustomNetwork,
+	MissedAttestationsThreshold: 1,
+	MinEffectiveBalance:         32,
+	MissedRewardsThreshold:      10,
+	FinalityStallEpochs:         2,
+	MinParticipation:            80,
+	KeymanagerAutoMonitor:       true,
+	FeeRecipient:                "",
+}
+
+/*
+loadSettings :
+Get monitor settings from config file or enviroment variables, falling back to default values.
+
+params :-
+none
+
+returns :-
+a. monitorSettings
+Monitor settings
+*/
+func loadSettings() monitorSettings {
+	for k, v := range settingDefaults {
+		viper.BindEnv(k)
+		viper.SetDefault(k, v)
+	}
+
+	return monitorSettings{
+		minPeers:                    viper.GetUint64(MinPeers),
+		minInboundPeers:             viper.GetUint64(MinInboundPeers),
+		healthInterval:              time.Duration(viper.GetInt64(HealthInterval)) * time.Second,
+		healthGracePeriod:           time.Duration(viper.GetInt64(HealthGracePeriod)) * time.Second,
+		alertsWebhook:               viper.GetString(AlertsWebhook),
+		reorgDepthThreshold:         viper.GetUint64(ReorgDepthThreshold),
+		network:                     strings.ToLower(viper.GetString(Network)),
+		missedAttestationsThreshold: viper.GetUint(MissedAttestationsThreshold),
+		minEffectiveBalance:         viper.GetUint64(MinEffectiveBalance) * gweiPerEth,
+		missedRewardsThreshold:      viper.GetUint(MissedRewardsThreshold),
+		finalityStallEpochs:         viper.GetUint64(FinalityStallEpochs),
+		minParticipation:            viper.GetUint(MinParticipation),
+		keymanagerAutoMonitor:       viper.GetBool(KeymanagerAutoMonitor),
+		feeRecipient:                strings.ToLower(viper.GetString(FeeRecipient)),
+		feeRecipients:               loadFeeRecipients(),
+		relays:                      loadRelays(),
+		mevBoost:                    loadURLs(MevBoost),
+	}
+}
+
+/*
+validate :
+Check the monitor settings, so invalid values are refused instead of breaking the monitoring loops or silently disabling alerts.
+
+params :-
+none
+
+returns :-
+a. error
+Error listing every invalid setting. Nil if the settings are valid
+*/
+func (s monitorSettings) validate() error {
+	var cfg Config
+	cfg.setSettings(s)
+	errs := cfg.validateSettings()
+	if len(errs) == 0 {
+		return nil
+	}
+	msgs := make([]string, 0, len(errs))
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Errorf(InvalidSettingsError, strings.Join(msgs, "; "))
+}
+
+/*
+loadFeeRecipients :
+Get the expected fee recipients by validator index from config file, enviroment variables or flags. Environment variables give them as 'index=address' pairs separated by commas.
+
+params :-
+none
+
+returns :-
+a. map[string]string
+Fee recipients in lower case by validator index. Nil if none is configured
+*/
+func loadFeeRecipients() map[string]string {
+	viper.BindEnv(FeeRecipients)
+
+	var raw map[string]string
+	if s, ok := viper.Get(FeeRecipients).(string); ok {
+		raw = make(map[string]string)
+		for _, pair := range strings.Split(s, ",") {
+			if k, v, found := strings.Cut(pair, "="); found {
+				raw[strings.TrimSpace(k)] = strings.TrimSpace(v)
+			}
+		}
+	} else {
+		raw = viper.GetStringMapString(FeeRecipients)
+	}
+
+	if len(raw) == 0 {
+		return nil
+	}
+	recipients := make(map[string]string, len(raw))
+	for k, v := range raw {
+		recipients[k] = strings.ToLower(v)
+	}
+	return recipients
+}
+
+/*
+loadURLs :
+Get a list of URLs from config file, enviroment variables or flags. Environment variables give them separated by commas. Trailing slashes are removed.
+
+params :-
+a. key string
+Configuration key
+
+returns :-
+a. []string
+URLs. Nil if none is configured
+*/
+func loadURLs(key string) []string {
+	viper.BindEnv(key)
+
+	var raw []string
+	if s, ok := viper.Get(key).(string); ok {
+		raw = strings.Split(s, ",")
+	} else {
+		raw = viper.GetStringSlice(key)
+	}
+
+	var urls []string
+	for _, u := range raw {
+		if u = strings.TrimSuffix(strings.TrimSpace(u), "/"); u != "" {
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
+
+/*
+loadRelays :
+Get the MEV-boost relay URLs from config file, enviroment variables or flags. Relays can be given as in mev-boost, with their public key as user of the URL, which is removed since the data API doesn't need it.
+
+params :-
+none
+
+returns :-
+a. []string
+Relay URLs. Nil if none is configured
+*/
+func loadRelays() []string {
+	relays := loadURLs(Relays)
+	for i, r := range relays {
+		if u, err := url.Parse(r); err == nil && u.User != nil {
+			u.User = nil
+			relays[i] = u.String()
+		}
+	}
+	return relays
+}
+
+/*
+newAlerter :
+Build the alerter described by the monitor settings. Alerts are always logged, and also posted to a webhook if one is configured.
+
+params :-
+a. s monitorSettings
+Monitor settings
+
+returns :-
+a. alerts.Alerter
+Alerter to deliver alerts with
+*/
+func newAlerter(s monitorSettings) alerts.Alerter {
+	if s.alertsWebhook == "" {
+		return alerts.LogAlerter{}
+	}
+	return alerts.MultiAlerter{
+		alerts.LogAlerter{},
+		alerts.WebhookAlerter{URL: s.alertsWebhook, RetryDuration: time.Minute},
+	}
+}
@@ -0,0 +1,625 @@
+package eth2
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NethermindEth/posmoni/configs"
+	log "github.com/sirupsen/logrus"
+)
+
+// maxRangeSize : Biggest validator index range accepted, to catch typos like 1000-19999999
+const maxRangeSize = 1000000
+
+// validatorSourcesInterval : Time between checks of validator files for changes
+const validatorSourcesInterval = 30 * time.Second
+
+var (
+	indexRegex  = regexp.MustCompile(`^\d+$`)
+	pubkeyRegex = regexp.MustCompile(`^0x[0-9a-fA-F]+$`)
+	rangeRegex  = regexp.MustCompile(`^(\d+)-(\d+)$`)
+)
+
+// depositData : Struct Represent an entry of a deposit_data-*.json file of staking-deposit-cli
+type depositData struct {
+	Pubkey string `json:"pubkey"`
+}
+
+// validatorSet : Struct Represent the validators to monitor, expanded from their sources. Safe for concurrent use
+type validatorSet struct {
+	mu sync.RWMutex
+	// Serializes expansions of the sources, so a reload of file sources doesn't overwrite a new configuration
+	loadMu sync.Mutex
+	// Ungrouped validator entries as configured
+	entries []string
+	// Validator groups with their entries as configured
+	groups []ValidatorGroup
+	// Expanded validators, without duplicates
+	configured []string
+	// Keys loaded in the validator clients that are monitored without being configured
+	loaded []string
+	// Configured and loaded validators
+	validators []string
+	// Group of every grouped validator
+	byValidator map[string]ValidatorGroup
+	// Group of every grouped validator configured by public key, by resolved index
+	byIndex map[string]ValidatorGroup
+	// Modification time of every file source when it was last read
+	files map[string]time.Time
+	// Index of every public key resolved by the consensus node, by normalized public key
+	indexes map[string]string
+}
+
+/*
+newValidatorSet :
+Factory for validatorSet. Entries can be validator indexes, public keys, index ranges like '1000-1999', paths to files with validators separated by new lines or commas, and deposit_data-*.json files.
+
+params :-
+a. entries []string
+Validator entries, grouped or not
+b. groups []ValidatorGroup
+Validator groups. Their entries should also be in entries
+
+returns :-
+a. *validatorSet
+Expanded validators
+b. error
+Error if any source could not be expanded
+*/
+func newValidatorSet(entries []string, groups []ValidatorGroup) (*validatorSet, error) {
+	grouped := make(map[string]bool)
+	for _, g := range groups {
+		for _, v := range g.Validators {
+			grouped[v] = true
+		}
+	}
+
+	s := &validatorSet{entries: make([]string, 0), groups: groups}
+	for _, v := range entries {
+		if !grouped[v] {
+			s.entries = append(s.entries, v)
+		}
+	}
+
+	_, _, err := s.load()
+	return s, err
+}
+
+/*
+load :
+Expand every source of the set, replacing the current validators.
+
+params :-
+none
+
+returns :-
+a. []string
+Configured validators that were not configured before
+b. []string
+Validators that are no longer configured
+c. error
+Error if any source could not be expanded. The current validators are kept
+*/
+func (s *validatorSet) load() ([]string, []string, error) {
+	s.loadMu.Lock()
+	defer s.loadMu.Unlock()
+	s.mu.RLock()
+	entries, groups := s.entries, s.groups
+	s.mu.RUnlock()
+
+	files := make(map[string]time.Time)
+	seen := make(map[string]bool)
+	validators := make([]string, 0)
+	byValidator := make(map[string]ValidatorGroup)
+
+	add := func(vs []string, g *ValidatorGroup) {
+		for _, v := range vs {
+			if g != nil {
+				if _, ok := byValidator[v]; !ok {
+					byValidator[v] = *g
+				}
+			}
+			if !seen[v] {
+				seen[v] = true
+				validators = append(validators, v)
+			}
+		}
+	}
+
+	for _, entry := range entries {
+		vs, err := expandEntry(entry, files)
+		if err != nil {
+			return nil, nil, err
+		}
+		add(vs, nil)
+	}
+	for i := range groups {
+		for _, entry := range groups[i].Validators {
+			vs, err := expandEntry(entry, files)
+			if err != nil {
+				return nil, nil, err
+			}
+			add(vs, &groups[i])
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	added, removed := diffValidators(s.configured, validators)
+	s.configured, s.byValidator, s.files = validators, byValidator, files
+	s.merge()
+	s.indexGroups()
+	return added, removed, nil
+}
+
+/*
+reconfigure :
+Replace the configured sources of the set and expand them.
+
+params :-
+a. entries []string
+Validator entries, grouped or not
+b. groups []ValidatorGroup
+Validator groups. Their entries should also be in entries
+
+returns :-
+a. []string
+Configured validators that were not configured before
+b. []string
+Validators that are no longer configured
+c. error
+Error if any source could not be expanded. The current sources are kept
+*/
+func (s *validatorSet) reconfigure(entries []string, groups []ValidatorGroup) ([]string, []string, error) {
+	s.loadMu.Lock()
+	defer s.loadMu.Unlock()
+	next, err := newValidatorSet(entries, groups)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	added, removed := diffValidators(s.configured, next.configured)
+	s.entries, s.groups = next.entries, next.groups
+	s.configured, s.byValidator, s.files = next.configured, next.byValidator, next.files
+	s.merge()
+	s.indexGroups()
+	return added, removed, nil
+}
+
+// diffValidators : Get the validators only in next, and the ones only in prev
+func diffValidators(prev, next []string) ([]string, []string) {
+	inPrev := make(map[string]bool, len(prev))
+	for _, v := range prev {
+		inPrev[v] = true
+	}
+	inNext := make(map[string]bool, len(next))
+	added := make([]string, 0)
+	for _, v := range next {
+		inNext[v] = true
+		if !inPrev[v] {
+			added = append(added, v)
+		}
+	}
+	removed := make([]string, 0)
+	for _, v := range prev {
+		if !inNext[v] {
+			removed = append(removed, v)
+		}
+	}
+	return added, removed
+}
+
+/*
+SetLoaded :
+Set the keys loaded in the validator clients that should be monitored besides the configured validators.
+
+params :-
+a. keys []string
+Validator public keys
+
+returns :-
+none
+*/
+func (s *validatorSet) SetLoaded(keys []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loaded = append([]string{}, keys...)
+	s.merge()
+}
+
+// merge : Join configured and loaded validators. Should be called with the lock held
+func (s *validatorSet) merge() {
+	seen := make(map[string]bool, len(s.configured))
+	s.validators = make([]string, 0, len(s.configured)+len(s.loaded))
+	for _, v := range append(append([]string{}, s.configured...), s.loaded...) {
+		if !seen[v] {
+			seen[v] = true
+			s.validators = append(s.validators, v)
+		}
+	}
+}
+
+// indexGroups : Key the groups of validators configured by public key by their resolved index. Should be called with the lock held
+func (s *validatorSet) indexGroups() {
+	s.byIndex = make(map[string]ValidatorGroup)
+	for v, g := range s.byValidator {
+		if idx, ok := s.indexes[normalizePubkey(v)]; ok {
+			s.byIndex[idx] = g
+		}
+	}
+}
+
+/*
+changed :
+Check if any file source has been modified since it was last read.
+
+params :-
+none
+
+returns :-
+a. bool
+True if the set should be reloaded
+*/
+func (s *validatorSet) changed() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for path, mtime := range s.files {
+		info, err := os.Stat(path)
+		if err != nil || !info.ModTime().Equal(mtime) {
+			return true
+		}
+	}
+	return false
+}
+
+// List : Get the validators to monitor
+func (s *validatorSet) List() []string {
+	if s == nil {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]string{}, s.validators...)
+}
+
+// Configured : Get the validators expanded from the configured sources
+func (s *validatorSet) Configured() []string {
+	if s == nil {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]string{}, s.configured...)
+}
+
+// Group : Get the group of a validator, as configured or by the resolved index of a public key
+func (s *validatorSet) Group(validator string) (ValidatorGroup, bool) {
+	if s == nil {
+		return ValidatorGroup{}, false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if g, ok := s.byValidator[validator]; ok {
+		return g, true
+	}
+	g, ok := s.byIndex[validator]
+	return g, ok
+}
+
+/*
+SetIndexes :
+Add indexes of validators configured by public key, resolved by the consensus node. Indexes of a public key never change, so they are kept across reloads.
+
+params :-
+a. indexes map[string]string
+Validator index by public key
+
+returns :-
+none
+*/
+func (s *validatorSet) SetIndexes(indexes map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.indexes == nil {
+		s.indexes = make(map[string]string, len(indexes))
+	}
+	for pk, idx := range indexes {
+		s.indexes[normalizePubkey(pk)] = idx
+	}
+	s.indexGroups()
+}
+
+// Unresolved : Get the validators configured by public key whose index is unknown
+func (s *validatorSet) Unresolved() []string {
+	if s == nil {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	pubkeys := make([]string, 0)
+	for _, v := range s.validators {
+		if _, ok := s.indexes[normalizePubkey(v)]; !ok && !indexRegex.MatchString(v) {
+			pubkeys = append(pubkeys, v)
+		}
+	}
+	return pubkeys
+}
+
+// Indexes : Get the indexes of the monitored validators. Public keys whose index is unknown are skipped
+func (s *validatorSet) Indexes() []string {
+	if s == nil {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	seen := make(map[string]bool, len(s.validators))
+	idxs := make([]string, 0, len(s.validators))
+	for _, v := range s.validators {
+		idx := v
+		if !indexRegex.MatchString(v) {
+			if idx = s.indexes[normalizePubkey(v)]; idx == "" {
+				continue
+			}
+		}
+		if !seen[idx] {
+			seen[idx] = true
+			idxs = append(idxs, idx)
+		}
+	}
+	return idxs
+}
+
+// Groups : Get the configured validator groups
+func (s *validatorSet) Groups() []ValidatorGroup {
+	if s == nil {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]ValidatorGroup{}, s.groups...)
+}
+
+// Contains : Check if a validator is monitored, as configured or by the resolved index of a public key
+func (s *validatorSet) Contains(validator string) bool {
+	if s == nil {
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, v := range s.validators {
+		if v == validator {
+			return true
+		}
+		if idx, ok := s.indexes[normalizePubkey(v)]; ok && idx == validator {
+			return true
+		}
+	}
+	return false
+}
+
+// hasFiles : Check if any source of the set is a file
+func (s *validatorSet) hasFiles() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.files) > 0
+}
+
+/*
+expandEntry :
+Expand a validator entry into validators.
+
+params :-
+a. entry string
+Validator index, public key, index range or file path
+b. files map[string]time.Time
+Modification time of the files read, to fill with the file of the entry
+
+returns :-
+a. []string
+Validators of the entry
+b. error
+Error if any
+*/
+func expandEntry(entry string, files map[string]time.Time) ([]string, error) {
+	entry = strings.TrimSpace(entry)
+	if isValidatorID(entry) || rangeRegex.MatchString(entry) {
+		return expandID(entry)
+	}
+
+	info, err := os.Stat(entry)
+	if err != nil {
+		return nil, fmt.Errorf(ValidatorSourceError, entry, err)
+	}
+	files[entry] = info.ModTime()
+
+	contents, err := os.ReadFile(entry)
+	if err != nil {
+		return nil, fmt.Errorf(ValidatorSourceError, entry, err)
+	}
+
+	if match, _ := filepath.Match("deposit_data-*.json", filepath.Base(entry)); match {
+		return parseDepositData(entry, contents)
+	}
+	return parseValidatorsFile(entry, contents)
+}
+
+// isValidatorID : Check if an entry is a validator index or public key
+func isValidatorID(entry string) bool {
+	return indexRegex.MatchString(entry) || pubkeyRegex.MatchString(entry)
+}
+
+/*
+expandID :
+Expand a validator index, public key or index range.
+
+params :-
+a. entry string
+Validator index, public key or index range
+
+returns :-
+a. []string
+Validators of the entry
+b. error
+Error if the range is invalid
+*/
+func expandID(entry string) ([]string, error) {
+	m := rangeRegex.FindStringSubmatch(entry)
+	if m == nil {
+		return []string{entry}, nil
+	}
+
+	from, err := strconv.ParseUint(m[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf(InvalidRangeError, entry)
+	}
+	to, err := strconv.ParseUint(m[2], 10, 64)
+	if err != nil || to < from || to-from >= maxRangeSize {
+		return nil, fmt.Errorf(InvalidRangeError, entry)
+	}
+
+	vs := make([]string, 0, to-from+1)
+	for i := from; i <= to; i++ {
+		vs = append(vs, strconv.FormatUint(i, 10))
+	}
+	return vs, nil
+}
+
+/*
+parseValidatorsFile :
+Get validators from a file. CSV files (.csv) have validators in the first column, and a header is skipped. Other files have indexes, public keys or index ranges separated by new lines or commas. Empty lines and lines starting with '#' are skipped.
+
+params :-
+a. path string
+File path
+b. contents []byte
+File contents
+
+returns :-
+a. []string
+Validators of the file
+b. error
+Error if any field is not a validator
+*/
+func parseValidatorsFile(path string, contents []byte) ([]string, error) {
+	isCSV := strings.EqualFold(filepath.Ext(path), ".csv")
+	vs := make([]string, 0)
+	for n, line := range strings.Split(string(contents), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, ",")
+		if isCSV {
+			if n == 0 && !hasValidatorField(fields[:1]) {
+				// CSV header
+				continue
+			}
+			fields = fields[:1]
+		}
+		for _, f := range fields {
+			f = strings.TrimSpace(f)
+			if f == "" {
+				continue
+			}
+			if !isValidatorID(f) && !rangeRegex.MatchString(f) {
+				return nil, fmt.Errorf(ValidatorFileError, path, n+1, f)
+			}
+			ids, err := expandID(f)
+			if err != nil {
+				return nil, err
+			}
+			vs = append(vs, ids...)
+		}
+	}
+	return vs, nil
+}
+
+// hasValidatorField : Check if any field of a line is a validator
+func hasValidatorField(fields []string) bool {
+	for _, f := range fields {
+		f = strings.TrimSpace(f)
+		if isValidatorID(f) || rangeRegex.MatchString(f) {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+parseDepositData :
+Get validator public keys from a deposit_data-*.json file of staking-deposit-cli.
+
+params :-
+a. path string
+File path
+b. contents []byte
+File contents
+
+returns :-
+a. []string
+Validator public keys, 0x prefixed
+b. error
+Error if any
+*/
+func parseDepositData(path string, contents []byte) ([]string, error) {
+	var deposits []depositData
+	if err := json.Unmarshal(contents, &deposits); err != nil {
+		return nil, fmt.Errorf(ValidatorSourceError, path, err)
+	}
+
+	vs := make([]string, 0, len(deposits))
+	for _, d := range deposits {
+		pk := "0x" + strings.TrimPrefix(d.Pubkey, "0x")
+		if !pubkeyRegex.MatchString(pk) {
+			return nil, fmt.Errorf(ValidatorSourceError, path, fmt.Errorf("invalid pubkey %q", d.Pubkey))
+		}
+		vs = append(vs, pk)
+	}
+	return vs, nil
+}
+
+/*
+watchValidatorSources :
+Periodically check validator files for changes, and reload the validators when any of them changes. Added validators are created in the repository and removed ones marked inactive. Checks are skipped while the set has no file sources.
+
+params :-
+a. done <-chan struct{}
+Channel to get stop signal from
+b. wait time.Duration
+Time between checks
+
+returns :-
+none
+*/
+func (e *eth2Monitor) watchValidatorSources(done <-chan struct{}, wait time.Duration) {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "watchValidatorSources"}
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-time.After(wait):
+			if !e.validators.hasFiles() || !e.validators.changed() {
+				continue
+			}
+			added, removed, err := e.validators.load()
+			if err != nil {
+				log.WithFields(logFields).Errorf(ReloadValidatorsError, err)
+				continue
+			}
+			e.syncRepository(added, removed)
+			log.WithFields(logFields).Infof("Validator sources changed, %d validators added and %d removed, monitoring %d validators", len(added), len(removed), len(e.validators.List()))
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package eth2
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/posmoni/internal/utils"
+	"github.com/NethermindEth/posmoni/pkg/eth2/db"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func writeFile(t *testing.T, dir, name, contents string) string {
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestNewValidatorSet(t *testing.T) {
+	t.Parallel()
+	td := t.TempDir()
+
+	list := writeFile(t, td, "validators.txt", "# our validators\n10,11\n\n0xaabb\n20-22\n")
+	csv := writeFile(t, td, "validators.csv", "index,customer\n30,acme\n31,acme\n")
+	deposits := writeFile(t, td, "deposit_data-1663939185.json", `[{"pubkey":"a1b2","withdrawal_credentials":"00","amount":32000000000},{"pubkey":"c3d4"}]`)
+	bad := writeFile(t, td, "bad.txt", "10\nvalidator-eleven\n")
+	badDeposits := writeFile(t, td, "deposit_data-1.json", `{"pubkey":"a1b2"}`)
+
+	tcs := []struct {
+		name    string
+		entries []string
+		groups  []ValidatorGroup
+		want    []string
+		// wanted group of some validators
+		wantGroups map[string]string
+		isError    bool
+	}{
+		{
+			"Test case 1, indexes and public keys",
+			[]string{"1", "0x1414fa980b"},
+			nil,
+			[]string{"1", "0x1414fa980b"},
+			nil,
+			false,
+		},
+		{
+			"Test case 2, ranges",
+			[]string{"1-3", "5", "3-4"},
+			nil,
+			[]string{"1", "2", "3", "5", "4"},
+			nil,
+			false,
+		},
+		{
+			"Test case 3, reversed range",
+			[]string{"3-1"},
+			nil,
+			nil,
+			nil,
+			true,
+		},
+		{
+			"Test case 4, huge range",
+			[]string{"0-99999999"},
+			nil,
+			nil,
+			nil,
+			true,
+		},
+		{
+			"Test case 5, files",
+			[]string{list, csv, deposits},
+			nil,
+			[]string{"10", "11", "0xaabb", "20", "21", "22", "30", "31", "0xa1b2", "0xc3d4"},
+			nil,
+			false,
+		},
+		{
+			"Test case 6, missing file",
+			[]string{filepath.Join(td, "missing.txt")},
+			nil,
+			nil,
+			nil,
+			true,
+		},
+		{
+			"Test case 7, bad validator in file",
+			[]string{bad},
+			nil,
+			nil,
+			nil,
+			true,
+		},
+		{
+			"Test case 8, bad deposit data",
+			[]string{badDeposits},
+			nil,
+			nil,
+			nil,
+			true,
+		},
+		{
+			"Test case 9, groups with ranges and files",
+			[]string{"1", "2-3", csv},
+			[]ValidatorGroup{
+				{Name: "acme", Validators: []string{"2-3", csv}},
+			},
+			[]string{"1", "2", "3", "30", "31"},
+			map[string]string{"1": "", "2": "acme", "3": "acme", "31": "acme"},
+			false,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := newValidatorSet(tc.entries, tc.groups)
+
+			descr := fmt.Sprintf("newValidatorSet(%v)", tc.entries)
+			if err = utils.CheckErr(descr, tc.isError, err); err != nil {
+				t.Fatal(err)
+			}
+			if tc.isError {
+				return
+			}
+			assert.Equal(t, tc.want, got.List(), descr)
+			for v, want := range tc.wantGroups {
+				g, _ := got.Group(v)
+				assert.Equal(t, want, g.Name, descr+" gave wrong group to "+v)
+			}
+		})
+	}
+}
+
+func TestWatchValidatorSources(t *testing.T) {
+	t.Parallel()
+	td := t.TempDir()
+
+	path := writeFile(t, td, "validators.txt", "1\n2\n")
+	vs, err := newValidatorSet([]string{path}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, vs.hasFiles())
+	assert.False(t, vs.changed())
+
+	ormdb, err := gorm.Open(sqlite.Open("file:sources?mode=memory&cache=shared"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	repository := &db.SQLiteRepository{DB: ormdb}
+	if err := repository.Migrate(); err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup(repository)
+	if err := populateDb(repository, []db.Validator{{Idx: 1}, {Idx: 2}}); err != nil {
+		t.Fatal(err)
+	}
+
+	monitor := eth2Monitor{validators: vs, repository: repository, beaconClient: &TestBeaconClient{}}
+	done := make(chan struct{})
+	defer close(done)
+	go monitor.watchValidatorSources(done, time.Millisecond*10)
+
+	// Bad contents are not loaded
+	writeFile(t, td, "validators.txt", "1\ntwo\n")
+	os.Chtimes(path, time.Now(), time.Now().Add(time.Second))
+	time.Sleep(time.Millisecond * 50)
+	assert.Equal(t, []string{"1", "2"}, vs.List())
+
+	// Added validators are created, removed ones marked inactive
+	writeFile(t, td, "validators.txt", "2\n3-4\n")
+	os.Chtimes(path, time.Now(), time.Now().Add(2*time.Second))
+	time.Sleep(time.Millisecond * 50)
+	assert.Equal(t, []string{"2", "3", "4"}, vs.List())
+	assert.True(t, vs.Contains("4"))
+	assert.False(t, vs.changed())
+
+	want := map[uint]bool{1: true, 2: false, 3: false, 4: false}
+	for idx, inactive := range want {
+		v, err := repository.Validator(idx)
+		if assert.NoError(t, err, "validator %d not in the repository", idx) {
+			assert.Equal(t, inactive, v.Inactive, "validator %d has wrong inactive flag", idx)
+		}
+	}
+}
+
+func TestValidatorSetIndexes(t *testing.T) {
+	t.Parallel()
+
+	vs, err := newValidatorSet([]string{"1", "0xAA02", "0xaa03"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"0xAA02", "0xaa03"}, vs.Unresolved())
+	assert.Equal(t, []string{"1"}, vs.Indexes())
+
+	// Public keys are matched in any case, and resolved ones kept across reloads
+	vs.SetIndexes(map[string]string{"0xaa02": "2"})
+	assert.Equal(t, []string{"0xaa03"}, vs.Unresolved())
+	assert.Equal(t, []string{"1", "2"}, vs.Indexes())
+	assert.True(t, vs.Contains("2"))
+	assert.True(t, vs.Contains("0xaa03"))
+	assert.False(t, vs.Contains("3"))
+	assert.False(t, vs.Contains(""))
+	if _, _, err := vs.reconfigure([]string{"0xaa02", "1"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, vs.Unresolved())
+	assert.Equal(t, []string{"2", "1"}, vs.Indexes())
+
+	// Groups of public keys, from the config or deposit data files, are found by index
+	deposits := writeFile(t, t.TempDir(), "deposit_data-1.json", `[{"pubkey":"aa04"}]`)
+	groups := []ValidatorGroup{{Name: "acme", Validators: []string{"0xAA02", deposits}}}
+	if _, _, err := vs.reconfigure([]string{"1", "0xAA02", deposits}, groups); err != nil {
+		t.Fatal(err)
+	}
+	_, ok := vs.Group("4")
+	assert.False(t, ok)
+	vs.SetIndexes(map[string]string{"0xaa04": "4"})
+	for _, v := range []string{"2", "4", "0xAA02"} {
+		g, ok := vs.Group(v)
+		assert.True(t, ok, v)
+		assert.Equal(t, "acme", g.Name, v)
+	}
+	_, ok = vs.Group("1")
+	assert.False(t, ok)
+}
@@ -0,0 +1,80 @@
+package eth2
+
+import "time"
+
+// unknownState : State of a key that has not been observed yet
+const unknownState = "unknown"
+
+// trackedState : Struct Represent state history of a tracked key
+type trackedState struct {
+	// Last state reported
+	current string
+	// Last state seen, waiting for the grace period to become current
+	pending string
+	// Time from when pending state has been seen continuously
+	since time.Time
+}
+
+// transition : Struct Represent a reported state change
+type transition struct {
+	from string
+	to   string
+	// Time the new state had been seen continuously when reported
+	lasted time.Duration
+}
+
+// stateTracker : Struct Debounce states of several keys (endpoints, validators, etc.) into state transitions
+type stateTracker struct {
+	// Time a state should last before becoming current
+	grace time.Duration
+	// State that is not reported when it is the first state seen of a key
+	quiet  string
+	states map[string]*trackedState
+}
+
+func newStateTracker(grace time.Duration, quiet string) *stateTracker {
+	return &stateTracker{grace: grace, quiet: quiet, states: make(map[string]*trackedState)}
+}
+
+/*
+observe :
+Record the state of a key. A transition is reported when a new state has been seen continuously for the grace period.
+
+params :-
+a. key string
+Key the state belongs to
+b. state string
+State seen
+c. now time.Time
+Time the state was seen
+
+returns :-
+a. transition
+State transition
+b. bool
+True if a transition should be reported
+*/
+func (t *stateTracker) observe(key, state string, now time.Time) (transition, bool) {
+	st, ok := t.states[key]
+	if !ok {
+		st = &trackedState{current: unknownState, pending: state, since: now}
+		t.states[key] = st
+	}
+
+	if state != st.pending {
+		st.pending = state
+		st.since = now
+	}
+
+	if st.pending == st.current || now.Sub(st.since) < t.grace {
+		return transition{}, false
+	}
+
+	tr := transition{from: st.current, to: st.pending, lasted: now.Sub(st.since).Round(time.Second)}
+	st.current = st.pending
+	if tr.from == unknownState && tr.to == t.quiet {
+		return transition{}, false
+	}
+
+	return tr, true
+}
@@ -1,9 +1,13 @@
 package eth2
 
+import "time"
+
 // Eth2Config : Struct Represent monitor configuration data
 type eth2Config struct {
-	// List of validator addresses or public index to monitor
+	// List of validator addresses or public index to monitor, as loaded at startup. The validator set of the monitor holds the current ones
 	validators []string
+	// Named groups of validators with labels and thresholds, as loaded at startup. Validators of groups are also in validators
+	groups []ValidatorGroup
 	// List of consensus nodes from which to interact with Beacon chain API
 	consensus []string
 	// List of execution nodes from which to interact with Ethereum json-rpc API
@@ -23,6 +27,195 @@ type ConfigOpts struct {
 // EndpointSyncStatus : Struct Represent sync status of an endpoint
 type EndpointSyncStatus struct {
 	Endpoint string
-	Synced   bool
+	// Either ConsensusLayer or ExecutionLayer
+	Layer  string
+	Synced bool
+	// Head slot of a consensus node
+	HeadSlot uint64
+	// Slots a consensus node is behind
+	SyncDistance uint64
+	// Current block of a syncing execution node
+	CurrentBlock uint64
+	// Highest known block of a syncing execution node
+	HighestBlock uint64
+	// Slots or blocks synced per second since the previous check. Zero if unknown
+	Speed float64
+	// Estimated time to finish syncing. Zero if unknown
+	ETA   time.Duration
+	Error error
+}
+
+// NodeStatus : Struct Represent diagnostics of a consensus node checked against the monitor thresholds
+type NodeStatus struct {
+	Endpoint string
+	Healthy  bool
+	Syncing  bool
+	Version  string
+	PeerID   string
+	// Number of connected peers
+	Peers uint64
+	// Number of connected peers that dialed the node
+	InboundPeers uint64
+	// Number of connected peers dialed by the node
+	OutboundPeers uint64
+	// True if the number of connected or inbound peers is below the configured thresholds
+	LowPeers bool
 	Error    error
 }
+
+// NodeState : Represent the health state of a node
+type NodeState string
+
+const (
+	// No health check has been done yet
+	NodeUnknown NodeState = unknownState
+	NodeHealthy NodeState = "healthy"
+	// Node is up and synced but below the peer thresholds
+	NodeDegraded NodeState = "degraded"
+	NodeSyncing  NodeState = "syncing"
+	NodeDown     NodeState = "down"
+)
+
+// Node layers
+const (
+	ConsensusLayer = "consensus"
+	ExecutionLayer = "execution"
+)
+
+// EndpointHealthStatus : Struct Represent health state of an endpoint
+type EndpointHealthStatus struct {
+	Endpoint string
+	// Either ConsensusLayer or ExecutionLayer
+	Layer string
+	State NodeState
+	Error error
+}
+
+// PairingState : Represent whether a consensus node is following the chain of an execution node
+type PairingState string
+
+const (
+	PairingOK PairingState = "paired"
+	// Consensus node reports its execution node is offline
+	PairingElOffline PairingState = "el_offline"
+	// No execution node has the execution payload of the consensus head
+	PairingMismatch PairingState = "mismatch"
+)
+
+// PairingStatus : Struct Represent pairing status of a consensus node with the execution nodes
+type PairingStatus struct {
+	Consensus string
+	// Execution endpoints having the execution payload of the consensus head
+	Execution []string
+	// Execution payload of the consensus head
+	BlockNumber uint64
+	BlockHash   string
+	ElOffline   bool
+	Paired      bool
+	Error       error
+}
+
+// OrphanedProposal : Struct Represent a block of one of our validators removed from the canonical chain
+type OrphanedProposal struct {
+	Slot           uint64
+	ValidatorIndex uint64
+	Block          string
+}
+
+// ReorgReport : Struct Represent a chain reorganisation seen by a consensus node
+type ReorgReport struct {
+	Endpoint string
+	// Slot of the new head
+	Slot         uint64
+	Depth        uint64
+	OldHeadBlock string
+	NewHeadBlock string
+	OldHeadState string
+	NewHeadState string
+	// Slots whose blocks were removed from the canonical chain
+	AffectedSlots     []uint64
+	OrphanedProposals []OrphanedProposal
+}
+
+// Keymanager : Struct Represent the keymanager API of a validator client
+type Keymanager struct {
+	URL string `yaml:"url"`
+	// Bearer token of the keymanager API
+	Token string `yaml:"token,omitempty"`
+	// File with the bearer token, read on every check. Used if Token is empty
+	TokenFile string `yaml:"token_file,omitempty"`
+}
+
+// MevBoostState : Represent whether mev-boost is up with a relay available
+type MevBoostState string
+
+const (
+	MevBoostUp   MevBoostState = "up"
+	MevBoostDown MevBoostState = "down"
+)
+
+// NetworkState : Represent the health of the whole network, from its finality and participation
+type NetworkState string
+
+const (
+	NetworkHealthy NetworkState = "healthy"
+	// Participation below the configured minimum
+	NetworkLowParticipation NetworkState = "low_participation"
+	// Finality behind its usual delay for more epochs than configured
+	NetworkFinalityStalled NetworkState = "finality_stalled"
+	// No finality for long enough that inactive validators are penalized
+	NetworkInactivityLeak NetworkState = "inactivity_leak"
+)
+
+// KeyState : Represent whether a validator key is loaded in the validator clients and monitored
+type KeyState string
+
+const (
+	// Monitored key loaded in one validator client
+	KeyLoaded KeyState = "loaded"
+	// Monitored key not loaded in any validator client
+	KeyNotLoaded KeyState = "not_loaded"
+	// Key loaded in a validator client but not configured to be monitored
+	KeyNotMonitored KeyState = "not_monitored"
+	// Key loaded in several validator clients. Slashing risk
+	KeyLoadedTwice KeyState = "loaded_twice"
+)
+
+// KeyStatus : Struct Represent the state of a validator key in the validator clients
+type KeyStatus struct {
+	Pubkey string
+	// Validator as configured. Public key for not monitored keys
+	Validator string
+	State     KeyState
+	// Keymanager URLs of the validator clients the key is loaded in
+	Clients []string
+}
+
+// KeyReport : Struct Represent the drift between monitored validators and keys loaded in the validator clients
+type KeyReport struct {
+	Keys []KeyStatus
+	// Errors of the keymanagers or the consensus node. Keys that could not be checked are not in the report
+	Errors []error
+}
+
+// LiveValidator : Struct Represent a monitored validator seen active on the network during a doppelganger check
+type LiveValidator struct {
+	Validator string
+	Epoch     uint64
+	// True if the consensus node saw the validator active in the epoch
+	Liveness bool
+	// Slot of the first block including an attestation of the validator for the epoch, zero if none was found
+	InclusionSlot uint64
+}
+
+// DoppelgangerReport : Struct Represent the result of a doppelganger check of the monitored validators
+type DoppelgangerReport struct {
+	// Epochs watched, included
+	FromEpoch uint64
+	ToEpoch   uint64
+	// Validator indexes checked
+	Validators []string
+	Live       []LiveValidator
+	// Errors of the consensus node. The check is incomplete if any
+	Errors []error
+}
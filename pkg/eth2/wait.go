@@ -0,0 +1,83 @@
+package eth2
+
+// SyncWaiter : Struct Track consecutive synced checks of a set of endpoints
+type SyncWaiter struct {
+	// Consecutive synced checks every endpoint needs
+	required int
+	// Current consecutive synced checks of every endpoint
+	streaks map[string]int
+}
+
+/*
+NewSyncWaiter :
+Factory for SyncWaiter.
+
+params :-
+a. endpoints []string
+Endpoints that should be synced
+b. consecutive int
+Consecutive synced checks every endpoint needs. Values below 1 are treated as 1
+
+returns :-
+a. *SyncWaiter
+Waiter for the given endpoints
+*/
+func NewSyncWaiter(endpoints []string, consecutive int) *SyncWaiter {
+	if consecutive < 1 {
+		consecutive = 1
+	}
+
+	streaks := make(map[string]int, len(endpoints))
+	for _, e := range endpoints {
+		streaks[e] = 0
+	}
+	return &SyncWaiter{required: consecutive, streaks: streaks}
+}
+
+/*
+Observe :
+Record a sync check of an endpoint. A check that is not synced or that failed resets the endpoint streak. Checks of unknown endpoints are ignored.
+
+params :-
+a. s EndpointSyncStatus
+Sync status of the endpoint
+
+returns :-
+a. bool
+True if every endpoint has been synced for the required consecutive checks
+*/
+func (w *SyncWaiter) Observe(s EndpointSyncStatus) bool {
+	if _, ok := w.streaks[s.Endpoint]; ok {
+		if s.Synced && s.Error == nil {
+			w.streaks[s.Endpoint]++
+		} else {
+			w.streaks[s.Endpoint] = 0
+		}
+	}
+
+	return w.Synced()
+}
+
+/*
+Synced :
+Check if every endpoint has been synced for the required consecutive checks.
+
+params :-
+none
+
+returns :-
+a. bool
+True if every endpoint is synced. False if there are no endpoints to wait for
+*/
+func (w *SyncWaiter) Synced() bool {
+	if len(w.streaks) == 0 {
+		return false
+	}
+
+	for _, streak := range w.streaks {
+		if streak < w.required {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,77 @@
+package eth2
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncWaiter(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name        string
+		endpoints   []string
+		consecutive int
+		checks      []EndpointSyncStatus
+		// wanted result of every Observe call
+		want []bool
+	}{
+		{
+			"Test case 1, one endpoint, synced at first check",
+			[]string{"1"},
+			1,
+			[]EndpointSyncStatus{{Endpoint: "1", Synced: true}},
+			[]bool{true},
+		},
+		{
+			"Test case 2, two endpoints, synced once both are",
+			[]string{"1", "2"},
+			1,
+			[]EndpointSyncStatus{{Endpoint: "1", Synced: true}, {Endpoint: "2", Synced: false}, {Endpoint: "1", Synced: true}, {Endpoint: "2", Synced: true}},
+			[]bool{false, false, false, true},
+		},
+		{
+			"Test case 3, consecutive checks needed, streak reset by syncing",
+			[]string{"1"},
+			2,
+			[]EndpointSyncStatus{{Endpoint: "1", Synced: true}, {Endpoint: "1", Synced: false}, {Endpoint: "1", Synced: true}, {Endpoint: "1", Synced: true}},
+			[]bool{false, false, false, true},
+		},
+		{
+			"Test case 4, consecutive checks needed, streak reset by error",
+			[]string{"1"},
+			2,
+			[]EndpointSyncStatus{{Endpoint: "1", Synced: true}, {Endpoint: "1", Error: errors.New("")}, {Endpoint: "1", Synced: true}},
+			[]bool{false, false, false},
+		},
+		{
+			"Test case 5, unknown endpoint ignored",
+			[]string{"1"},
+			1,
+			[]EndpointSyncStatus{{Endpoint: "2", Synced: true}, {Endpoint: "1", Synced: true}},
+			[]bool{false, true},
+		},
+		{
+			"Test case 6, no endpoints, never synced",
+			[]string{},
+			0,
+			[]EndpointSyncStatus{{Endpoint: "1", Synced: true}},
+			[]bool{false},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			w := NewSyncWaiter(tc.endpoints, tc.consecutive)
+
+			got := make([]bool, 0)
+			for _, c := range tc.checks {
+				got = append(got, w.Observe(c))
+			}
+
+			assert.Equal(t, tc.want, got, "Observe() gave wrong results")
+		})
+	}
+}
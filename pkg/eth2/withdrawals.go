@@ -0,0 +1,139 @@
+package eth2
+
+import (
+	"strconv"
+
+	"github.com/NethermindEth/posmoni/configs"
+	"github.com/NethermindEth/posmoni/pkg/eth2/db"
+	net "github.com/NethermindEth/posmoni/pkg/eth2/networking"
+	log "github.com/sirupsen/logrus"
+)
+
+// withdrawalScanLimit : Most slots scanned for withdrawals between balance checks, 4 epochs on mainnet. Older slots are skipped after a long pause
+const withdrawalScanLimit = 128
+
+/*
+trackWithdrawals :
+Scan the blocks since the previous balance check up to the head for withdrawals of the monitored validators, and record them. After a restart the scan goes on from the slot of the last balance snapshot. Without one, as on the first run, only the head block is scanned. If a block can't be fetched, the scan stops at the slot before it, so balances read at the returned slot match the withdrawals found.
+
+params :-
+a. epoch uint64
+Epoch of the finalized checkpoint the balances are checked for
+
+returns :-
+a. string
+State ID to read balances from, the last scanned slot or 'head' if the head block could not be fetched
+b. map[uint]uint64
+Gwei withdrawn by validator index since the previous balance check
+c. bool
+True if blocks since the previous balance check were skipped, so balance drops can't be told apart from withdrawals
+*/
+func (e *eth2Monitor) trackWithdrawals(epoch uint64) (string, map[uint]uint64, bool) {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "trackWithdrawals"}
+	withdrawn := make(map[uint]uint64)
+
+	if e.withdrawalSlot == 0 {
+		slot, err := e.repository.LastSnapshotSlot()
+		if err != nil {
+			log.WithFields(logFields).Errorf(LastScannedSlotError, err)
+		}
+		e.withdrawalSlot = slot
+	}
+
+	head, _, err := e.slotBlock("head")
+	if err != nil {
+		log.WithFields(logFields).Errorf(WithdrawalBlockError, "head", err)
+		return "head", withdrawn, e.withdrawalSlot != 0
+	}
+	headSlot, err := strconv.ParseUint(head.Slot, 10, 64)
+	if err != nil {
+		log.WithFields(logFields).Errorf(ParseUintError, err)
+		return "head", withdrawn, e.withdrawalSlot != 0
+	}
+	if e.withdrawalSlot != 0 && e.withdrawalSlot >= headSlot {
+		// Head did not move since the previous check
+		return head.Slot, withdrawn, false
+	}
+
+	from, skipped := headSlot, false
+	if e.withdrawalSlot != 0 {
+		from = e.withdrawalSlot + 1
+		if headSlot-from >= withdrawalScanLimit {
+			log.WithFields(logFields).Warnf("Skipping withdrawals of slots %d to %d, balance drops are not checked for missed attestations", from, headSlot-withdrawalScanLimit)
+			from, skipped = headSlot-withdrawalScanLimit+1, true
+		}
+	}
+
+	scanned := e.withdrawalSlot
+	for slot := from; slot <= headSlot; slot++ {
+		block := head
+		if slot != headSlot {
+			var missed bool
+			block, missed, err = e.slotBlock(strconv.FormatUint(slot, 10))
+			if err != nil {
+				log.WithFields(logFields).Errorf(WithdrawalBlockError, strconv.FormatUint(slot, 10), err)
+				break
+			}
+			if missed {
+				scanned = slot
+				continue
+			}
+		}
+		e.recordWithdrawals(block, slot, epoch, withdrawn)
+		scanned = slot
+	}
+
+	e.withdrawalSlot = scanned
+	return strconv.FormatUint(scanned, 10), withdrawn, skipped
+}
+
+/*
+recordWithdrawals :
+Save the withdrawals of the monitored validators in a block and add them to the withdrawn amounts.
+
+params :-
+a. block net.BeaconBlock
+Block to get withdrawals from
+b. slot uint64
+Slot of the block
+c. epoch uint64
+Epoch of the finalized checkpoint the withdrawals are accounted in
+d. withdrawn map[uint]uint64
+Gwei withdrawn by validator index, updated in place
+
+returns :-
+none
+*/
+func (e *eth2Monitor) recordWithdrawals(block net.BeaconBlock, slot, epoch uint64, withdrawn map[uint]uint64) {
+	logFields := log.Fields{configs.Component: "ETH2 Monitor", "Method": "recordWithdrawals"}
+
+	for _, w := range block.Body.ExecutionPayload.Withdrawals {
+		if !e.validators.Contains(w.ValidatorIndex) {
+			continue
+		}
+		vFields := e.validatorFields(logFields, w.ValidatorIndex)
+
+		idx, err := parseUint(w.ValidatorIndex)
+		if err != nil {
+			log.WithFields(vFields).Errorf(ParseUintError, err)
+			continue
+		}
+		index, err := strconv.ParseUint(w.Index, 10, 64)
+		if err != nil {
+			log.WithFields(vFields).Errorf(ParseUintError, err)
+			continue
+		}
+		amount, err := strconv.ParseUint(w.Amount, 10, 64)
+		if err != nil {
+			log.WithFields(vFields).Errorf(ParseUintError, err)
+			continue
+		}
+
+		log.WithFields(vFields).Infof("Withdrawal of %d gwei from validator %d at slot %d", amount, idx, slot)
+		withdrawn[idx] += amount
+		err = e.repository.SaveWithdrawal(db.Withdrawal{Index: index, Idx: idx, Slot: slot, Epoch: epoch, Address: w.Address, Amount: amount})
+		if err != nil {
+			log.WithFields(vFields).Errorf(SaveWithdrawalError, index, idx, slot, err)
+		}
+	}
+}
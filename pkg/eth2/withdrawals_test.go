@@ -0,0 +1,152 @@
+package eth2
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/posmoni/pkg/eth2/db"
+	net "github.com/NethermindEth/posmoni/pkg/eth2/networking"
+	"github.com/stretchr/testify/assert"
+)
+
+func withdrawalBlock(slot string, withdrawals ...net.Withdrawal) net.BeaconBlock {
+	return net.BeaconBlock{Slot: slot, Body: net.BeaconBlockBody{ExecutionPayload: net.ExecutionPayload{Withdrawals: withdrawals}}}
+}
+
+func TestTrackWithdrawals(t *testing.T) {
+	tcs := []struct {
+		name string
+		// last scanned slot before the call
+		lastSlot uint64
+		// slot of the last balance snapshot in db, none if 0
+		savedSlot uint64
+		blocks    map[string]map[string]net.BeaconBlock
+		// state ID the balances are read from
+		wantState     string
+		wantWithdrawn map[uint]uint64
+		wantSlot      uint64
+		wantSkipped   bool
+		// withdrawals in db after the call
+		want []db.Withdrawal
+	}{
+		{
+			"Test case 1, first scan, only the head block",
+			0,
+			0,
+			map[string]map[string]net.BeaconBlock{"cl1": {
+				"head": withdrawalBlock("10", net.Withdrawal{Index: "5", ValidatorIndex: "1", Address: "0xaa", Amount: "15000"}),
+				"9":    withdrawalBlock("9", net.Withdrawal{Index: "4", ValidatorIndex: "1", Address: "0xaa", Amount: "14000"}),
+			}},
+			"10",
+			map[uint]uint64{1: 15000},
+			10,
+			false,
+			[]db.Withdrawal{{Index: 5, Idx: 1, Slot: 10, Epoch: 2, Address: "0xaa", Amount: 15000}},
+		},
+		{
+			"Test case 2, blocks since the previous scan, missed slot and unmonitored validators",
+			8,
+			0,
+			map[string]map[string]net.BeaconBlock{"cl1": {
+				"head": withdrawalBlock("11", net.Withdrawal{Index: "7", ValidatorIndex: "2", Address: "0xbb", Amount: "16000"}),
+				"9": withdrawalBlock("9",
+					net.Withdrawal{Index: "2", ValidatorIndex: "1", Address: "0xaa", Amount: "14000"},
+					net.Withdrawal{Index: "3", ValidatorIndex: "9", Address: "0xcc", Amount: "99000"},
+				),
+				"11": withdrawalBlock("11"),
+			}},
+			"11",
+			map[uint]uint64{1: 14000, 2: 16000},
+			11,
+			false,
+			[]db.Withdrawal{
+				{Index: 2, Idx: 1, Slot: 9, Epoch: 2, Address: "0xaa", Amount: 14000},
+				{Index: 7, Idx: 2, Slot: 11, Epoch: 2, Address: "0xbb", Amount: 16000},
+			},
+		},
+		{
+			"Test case 3, head did not move",
+			11,
+			0,
+			map[string]map[string]net.BeaconBlock{"cl1": {
+				"head": withdrawalBlock("11", net.Withdrawal{Index: "7", ValidatorIndex: "2", Address: "0xbb", Amount: "16000"}),
+			}},
+			"11",
+			map[uint]uint64{},
+			11,
+			false,
+			[]db.Withdrawal{},
+		},
+		{
+			"Test case 4, long pause, old slots skipped, validator configured by public key",
+			10,
+			0,
+			map[string]map[string]net.BeaconBlock{"cl1": {
+				"head": withdrawalBlock("500"),
+				"11":   withdrawalBlock("11", net.Withdrawal{Index: "7", ValidatorIndex: "2", Address: "0xbb", Amount: "16000"}),
+				"400":  withdrawalBlock("400", net.Withdrawal{Index: "8", ValidatorIndex: "3", Address: "0xdd", Amount: "17000"}),
+			}},
+			"500",
+			map[uint]uint64{3: 17000},
+			500,
+			true,
+			[]db.Withdrawal{{Index: 8, Idx: 3, Slot: 400, Epoch: 2, Address: "0xdd", Amount: 17000}},
+		},
+		{
+			"Test case 5, no head block, balances read at head",
+			10,
+			0,
+			nil,
+			"head",
+			map[uint]uint64{},
+			10,
+			true,
+			[]db.Withdrawal{},
+		},
+		{
+			"Test case 6, restart, scan from the slot of the last balance snapshot",
+			0,
+			8,
+			map[string]map[string]net.BeaconBlock{"cl1": {
+				"head": withdrawalBlock("10"),
+				"9":    withdrawalBlock("9", net.Withdrawal{Index: "2", ValidatorIndex: "1", Address: "0xaa", Amount: "14000"}),
+			}},
+			"10",
+			map[uint]uint64{1: 14000},
+			10,
+			false,
+			[]db.Withdrawal{{Index: 2, Idx: 1, Slot: 9, Epoch: 2, Address: "0xaa", Amount: 14000}},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			monitor, err := setup(nil, net.SubscribeOpts{}, ConfigOpts{Checkers: []CfgChecker{
+				{Key: Validators, ErrMsg: NoValidatorsFoundError, Data: []string{"1", "2", "0xaa03"}},
+				{Key: Consensus, ErrMsg: NoConsensusFoundError, Data: []string{"cl1"}},
+			}})
+			if err != nil {
+				t.Fatalf("Setup failed. Error %v", err)
+			}
+			defer cleanup(monitor.repository)
+			monitor.beaconClient.(*TestBeaconClient).blocks = tc.blocks
+			monitor.beaconClient.(*TestBeaconClient).registry = []net.ValidatorInfo{{Index: "3", Validator: net.ValidatorData{Pubkey: "0xaa03"}}}
+			monitor.resolveValidators()
+			monitor.withdrawalSlot = tc.lastSlot
+			if tc.savedSlot != 0 {
+				if err := monitor.repository.SaveSnapshot(db.BalanceSnapshot{Idx: 1, Epoch: 1, Slot: tc.savedSlot}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			state, withdrawn, skipped := monitor.trackWithdrawals(2)
+
+			assert.Equal(t, tc.wantState, state)
+			assert.Equal(t, tc.wantWithdrawn, withdrawn)
+			assert.Equal(t, tc.wantSlot, monitor.withdrawalSlot)
+			assert.Equal(t, tc.wantSkipped, skipped)
+			got, err := monitor.repository.Withdrawals(0, 10)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}